@@ -2,41 +2,153 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"time"
 
+	webhookclient "github.com/hse-telescope/core/internal/clients/webhook"
 	"github.com/hse-telescope/core/internal/config"
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/grpcserver"
+	"github.com/hse-telescope/core/internal/health"
+	"github.com/hse-telescope/core/internal/lifecycle"
+	"github.com/hse-telescope/core/internal/loglevel"
+	"github.com/hse-telescope/core/internal/metrics"
+	"github.com/hse-telescope/core/internal/providers/catalog"
 	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/providers/group"
+	"github.com/hse-telescope/core/internal/providers/idempotency"
+	"github.com/hse-telescope/core/internal/providers/manifest"
 	"github.com/hse-telescope/core/internal/providers/project"
 	"github.com/hse-telescope/core/internal/providers/relation"
 	"github.com/hse-telescope/core/internal/providers/service"
+	"github.com/hse-telescope/core/internal/providers/webhook"
 	"github.com/hse-telescope/core/internal/repository/db"
 	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/memory"
+	"github.com/hse-telescope/core/internal/repository/sqlite"
 	"github.com/hse-telescope/core/internal/server"
 	"github.com/hse-telescope/logger"
 	"github.com/hse-telescope/tracer"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func main() {
-	configPath := os.Args[1]
-	conf, err := config.Parse(configPath)
+	conf, err := config.Load(os.Args[1:], os.Environ())
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		panic(err)
+		fmt.Fprintf(os.Stderr, "invalid config:\n%v\n", err)
+		os.Exit(2)
 	}
-	storage, err := db.New(conf.DB.GetDBURL(), conf.DB.MigrationsPath)
+	storage, err := newStorage(conf)
 	if err != nil {
 		panic(err)
 	}
 
+	telemetry := health.WatchTelemetry(conf.OTELCollectorURL, time.Minute)
 	logger.SetupLogger(context.Background(), "core", conf.OTELCollectorURL, conf.Logger)
 	tracer.SetupTracer(context.Background(), "core", conf.OTELCollectorURL)
 
-	facade := facade.New(storage)
+	watcher := config.NewWatcher(conf, os.Args[1:], os.Environ())
+	logLevel := new(slog.LevelVar)
+	loglevel.SetDefault(logLevel)
+	watcher.Subscribe(func(runtime config.Runtime) {
+		// Load has validated the level
+		level, _ := runtime.Level()
+		logLevel.Set(level)
+	})
+
+	broker := events.NewBroker()
+	facade := facade.New(storage, broker, facade.NewCache(conf.Cache))
+
+	registerMetrics(conf, storage, facade)
 
 	ProjectProvide := project.New(facade)
 	GraphProvider := graph.New(facade)
 	ServiceProvide := service.New(facade)
 	RelationProvide := relation.New(facade)
+	CatalogProvide := catalog.New(facade)
+	GroupProvide := group.New(facade)
+	WebhookProvide := webhook.New(facade)
+	ManifestProvide := manifest.New(facade)
+	IdempotencyProvide := idempotency.New(facade, conf.Idempotency)
+
+	lc := lifecycle.New(conf.Shutdown)
+	lc.OnClose("logger", lifecycle.FlushLogs)
+	lc.OnClose("tracer", lifecycle.FlushTraces)
+	if closer, ok := storage.(io.Closer); ok {
+		lc.OnClose("storage", func(context.Context) error {
+			return closer.Close()
+		})
+	}
+
+	lc.Go("config watcher", watcher.Run)
 
-	s := server.New(conf, ProjectProvide, GraphProvider, ServiceProvide, RelationProvide)
-	panic(s.Start())
+	dispatcher := webhook.NewDispatcher(facade, webhookclient.New(conf.Clients.Webhook), conf.Webhooks)
+	lc.Go("webhook dispatcher", dispatcher.Run)
+	lc.Go("idempotency key sweeper", IdempotencyProvide.Run)
+
+	gs := grpcserver.New(conf, broker, ProjectProvide, GraphProvider, ServiceProvide, RelationProvide)
+	lc.Serve("grpc server", gs)
+
+	s := server.New(conf, ProjectProvide, GraphProvider, ServiceProvide, RelationProvide, CatalogProvide, GroupProvide, WebhookProvide, ManifestProvide, IdempotencyProvide)
+	s.Health().Register("telemetry", health.Informational, telemetry)
+	registerStorageChecks(s.Health(), storage)
+	watcher.Subscribe(func(runtime config.Runtime) {
+		s.SetLimits(runtime.Limits)
+		gs.SetLimits(runtime.Limits)
+	})
+	if certs := s.Certificates(); certs != nil {
+		lc.Go("certificate reloader", certs.Run)
+	}
+	lc.Serve("http server", s)
+
+	err = lc.Run(context.Background())
+	if err != nil {
+		panic(err)
+	}
+}
+
+// registerMetrics exposes the connection pool of the storage, when it has
+// one, and the number of stored entities
+func registerMetrics(conf config.Config, storage facade.Storage, counter metrics.EntityCounter) {
+	if pooled, ok := storage.(metrics.Pooled); ok {
+		prometheus.MustRegister(metrics.NewPool(pooled, conf.Storage))
+	}
+	prometheus.MustRegister(metrics.NewEntities(counter))
+}
+
+// registerStorageChecks makes readiness depend on the storage backends that
+// have a connection or a schema to check
+func registerStorageChecks(registry *health.Registry, storage facade.Storage) {
+	if pinger, ok := storage.(health.Pinger); ok {
+		registry.Register("database", health.Readiness, health.Ping(pinger))
+	}
+	type migrations interface {
+		CheckMigrations(ctx context.Context) (string, error)
+	}
+	if m, ok := storage.(migrations); ok {
+		registry.Register("migrations", health.Readiness, health.CheckerFunc(m.CheckMigrations))
+	}
+}
+
+// newStorage picks the storage backend. SQLite runs core as a single binary,
+// the in-memory one loses everything on restart and is meant for local runs
+// and tests.
+func newStorage(conf config.Config) (facade.Storage, error) {
+	switch conf.Storage {
+	case config.StoragePostgres:
+		return db.New(conf.DB)
+	case config.StorageSQLite:
+		return sqlite.New(conf.SQLite)
+	case config.StorageMemory:
+		return memory.New(), nil
+	}
+	return nil, fmt.Errorf("unknown storage %q", conf.Storage)
 }
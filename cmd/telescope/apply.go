@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hse-telescope/core/pkg/client"
+)
+
+var changeOps = map[string]string{
+	client.ActionCreate: "+",
+	client.ActionUpdate: "~",
+	client.ActionDelete: "-",
+}
+
+func printPlan(out io.Writer, plan client.Plan) {
+	for _, ch := range plan.Changes {
+		line := fmt.Sprintf("%s %s %s", changeOps[ch.Action], ch.Type, ch.Key)
+		if len(ch.Fields) > 0 {
+			line += ": " + strings.Join(ch.Fields, ", ")
+		}
+		fmt.Fprintln(out, line)
+	}
+	if len(plan.Changes) == 0 {
+		fmt.Fprintln(out, "graph is up to date")
+	}
+}
+
+// apply makes the graph match the document through the manifest of its
+// project: services are matched by name and relations by name and
+// endpoints, missing ones are created, changed ones are updated and the
+// ones absent from the document are deleted. The server plans and applies
+// the changes in one transaction. With dryRun the changes are only printed.
+func apply(ctx context.Context, c *client.Client, graph_id int, doc Document, dryRun bool, out io.Writer) error {
+	graph, err := c.GetGraph(ctx, graph_id)
+	if err != nil {
+		return err
+	}
+	// Graphs are matched by name, so the manifest cannot rename one
+	if doc.Name != "" && doc.Name != graph.Name {
+		return fmt.Errorf("document describes graph %q, graph %d is named %q", doc.Name, graph_id, graph.Name)
+	}
+	doc.Name = graph.Name
+
+	// The manifest describes the whole project, the other graphs are kept
+	// the way they are now
+	graphs, err := c.GetProjectGraphs(ctx, graph.ProjectID)
+	if err != nil {
+		return err
+	}
+	manifest := client.Manifest{Graphs: make([]client.ManifestGraph, 0, len(graphs))}
+	for _, gr := range graphs {
+		if gr.ID == graph_id {
+			manifest.Graphs = append(manifest.Graphs, document2Manifest(doc))
+			continue
+		}
+		other, err := export(ctx, c, gr.ID)
+		if err != nil {
+			return err
+		}
+		manifest.Graphs = append(manifest.Graphs, document2Manifest(other))
+	}
+
+	plan, err := c.PlanProject(ctx, graph.ProjectID, manifest)
+	if err != nil {
+		return err
+	}
+	// Other graphs only show up in the plan when they have changed since
+	// they were read, applying would revert them
+	for _, ch := range plan.Changes {
+		if ch.Graph != graph.Name {
+			return fmt.Errorf("graph %q of the project has changed meanwhile, try again", ch.Graph)
+		}
+	}
+	if dryRun || len(plan.Changes) == 0 {
+		printPlan(out, plan)
+		return nil
+	}
+
+	plan, err = c.ApplyProject(ctx, graph.ProjectID, manifest)
+	if err != nil {
+		return err
+	}
+	printPlan(out, plan)
+	return nil
+}
+
+// importDocument creates a new graph of the project from the document
+func importDocument(ctx context.Context, c *client.Client, project_id int, doc Document) (client.Graph, error) {
+	graph, err := c.CreateGraph(ctx, client.Graph{ProjectID: project_id, Name: doc.Name})
+	if err != nil {
+		return client.Graph{}, err
+	}
+
+	ids := make(map[string]int, len(doc.Services))
+	if len(doc.Services) > 0 {
+		services := make([]client.Service, 0, len(doc.Services))
+		for _, serv := range doc.Services {
+			services = append(services, document2Service(serv, graph.ID))
+		}
+		created, err := c.CreateServices(ctx, graph.ID, services)
+		if err != nil {
+			return graph, err
+		}
+		for i, id := range created {
+			ids[services[i].Name] = id
+		}
+	}
+	if len(doc.Relations) > 0 {
+		relations := make([]client.Relation, 0, len(doc.Relations))
+		for _, rel := range doc.Relations {
+			relations = append(relations, document2Relation(rel, graph.ID, ids))
+		}
+		err = c.CreateRelations(ctx, graph.ID, relations)
+		if err != nil {
+			return graph, err
+		}
+	}
+	return graph, nil
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hse-telescope/core/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+// Document is the portable form of a graph. Relations reference services by
+// name, so a document can be imported into any project.
+type Document struct {
+	Name      string             `yaml:"name" json:"name"`
+	Services  []DocumentService  `yaml:"services" json:"services"`
+	Relations []DocumentRelation `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+type DocumentService struct {
+	Name        string              `yaml:"name" json:"name"`
+	Description string              `yaml:"description,omitempty" json:"description,omitempty"`
+	Kind        string              `yaml:"kind,omitempty" json:"kind,omitempty"`
+	OwnerTeam   string              `yaml:"owner_team,omitempty" json:"owner_team,omitempty"`
+	Tags        []string            `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Attributes  map[string]string   `yaml:"attributes,omitempty" json:"attributes,omitempty"`
+	Links       client.ServiceLinks `yaml:"links,omitempty" json:"links,omitempty"`
+	X           float32             `yaml:"x" json:"x"`
+	Y           float32             `yaml:"y" json:"y"`
+}
+
+type DocumentRelation struct {
+	Name              string  `yaml:"name" json:"name"`
+	Description       string  `yaml:"description,omitempty" json:"description,omitempty"`
+	From              string  `yaml:"from" json:"from"`
+	To                string  `yaml:"to" json:"to"`
+	Protocol          string  `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	Async             bool    `yaml:"async,omitempty" json:"async,omitempty"`
+	Bidirectional     bool    `yaml:"bidirectional,omitempty" json:"bidirectional,omitempty"`
+	Criticality       string  `yaml:"criticality,omitempty" json:"criticality,omitempty"`
+	ExpectedRPS       float64 `yaml:"expected_rps,omitempty" json:"expected_rps,omitempty"`
+	ExpectedLatencyMS float64 `yaml:"expected_latency_ms,omitempty" json:"expected_latency_ms,omitempty"`
+}
+
+// relationKey identifies a relation within a graph
+type relationKey struct {
+	Name string
+	From string
+	To   string
+}
+
+func (r DocumentRelation) key() relationKey {
+	return relationKey{Name: r.Name, From: r.From, To: r.To}
+}
+
+// Validate checks that service names are unique and relations reference
+// services of the document
+func (d Document) Validate() error {
+	names := make(map[string]bool, len(d.Services))
+	for _, serv := range d.Services {
+		if serv.Name == "" {
+			return fmt.Errorf("service name must not be empty")
+		}
+		if names[serv.Name] {
+			return fmt.Errorf("service %q is declared twice", serv.Name)
+		}
+		names[serv.Name] = true
+	}
+	relations := make(map[relationKey]bool, len(d.Relations))
+	for _, rel := range d.Relations {
+		if !names[rel.From] || !names[rel.To] {
+			return fmt.Errorf("relation %q references unknown service", rel.Name)
+		}
+		if relations[rel.key()] {
+			return fmt.Errorf("relation %q from %q to %q is declared twice", rel.Name, rel.From, rel.To)
+		}
+		relations[rel.key()] = true
+	}
+	return nil
+}
+
+func readDocument(path string) (Document, error) {
+	bytes, err := os.ReadFile(path) // nolint:gosec
+	if err != nil {
+		return Document{}, err
+	}
+
+	var doc Document
+	// YAML is a superset of JSON
+	err = yaml.Unmarshal(bytes, &doc)
+	if err != nil {
+		return Document{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return doc, doc.Validate()
+}
+
+func writeDocument(out io.Writer, path string, format string, doc Document) error {
+	if format == "" {
+		format = "yaml"
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			format = "json"
+		}
+	}
+
+	var bytes []byte
+	var err error
+	switch format {
+	case "yaml":
+		bytes, err = yaml.Marshal(doc)
+	case "json":
+		bytes, err = json.MarshalIndent(doc, "", "  ")
+		bytes = append(bytes, '\n')
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if path == "" || path == "-" {
+		_, err = out.Write(bytes)
+		return err
+	}
+	return os.WriteFile(path, bytes, 0o644) // nolint:gosec
+}
+
+func newDocument(graph client.Graph, services []client.Service, relations []client.Relation) Document {
+	doc := Document{
+		Name:      graph.Name,
+		Services:  make([]DocumentService, 0, len(services)),
+		Relations: make([]DocumentRelation, 0, len(relations)),
+	}
+	names := make(map[int]string, len(services))
+	for _, serv := range services {
+		names[serv.ID] = serv.Name
+		doc.Services = append(doc.Services, service2Document(serv))
+	}
+	for _, rel := range relations {
+		doc.Relations = append(doc.Relations, relation2Document(rel, names))
+	}
+	return doc
+}
+
+func service2Document(serv client.Service) DocumentService {
+	return DocumentService{
+		Name:        serv.Name,
+		Description: serv.Description,
+		Kind:        serv.Kind,
+		OwnerTeam:   serv.OwnerTeam,
+		Tags:        serv.Tags,
+		Attributes:  serv.Attributes,
+		Links:       serv.Links,
+		X:           serv.X,
+		Y:           serv.Y,
+	}
+}
+
+func document2Service(serv DocumentService, graph_id int) client.Service {
+	return client.Service{
+		GraphID:     graph_id,
+		Name:        serv.Name,
+		Description: serv.Description,
+		Kind:        serv.Kind,
+		OwnerTeam:   serv.OwnerTeam,
+		Tags:        serv.Tags,
+		Attributes:  serv.Attributes,
+		Links:       serv.Links,
+		X:           serv.X,
+		Y:           serv.Y,
+	}
+}
+
+func relation2Document(rel client.Relation, names map[int]string) DocumentRelation {
+	return DocumentRelation{
+		Name:              rel.Name,
+		Description:       rel.Description,
+		From:              names[rel.FromService],
+		To:                names[rel.ToService],
+		Protocol:          rel.Protocol,
+		Async:             rel.Async,
+		Bidirectional:     rel.Bidirectional,
+		Criticality:       rel.Criticality,
+		ExpectedRPS:       rel.ExpectedRPS,
+		ExpectedLatencyMS: rel.ExpectedLatencyMS,
+	}
+}
+
+func document2Relation(rel DocumentRelation, graph_id int, ids map[string]int) client.Relation {
+	return client.Relation{
+		GraphID:           graph_id,
+		Name:              rel.Name,
+		Description:       rel.Description,
+		FromService:       ids[rel.From],
+		ToService:         ids[rel.To],
+		Protocol:          rel.Protocol,
+		Async:             rel.Async,
+		Bidirectional:     rel.Bidirectional,
+		Criticality:       rel.Criticality,
+		ExpectedRPS:       rel.ExpectedRPS,
+		ExpectedLatencyMS: rel.ExpectedLatencyMS,
+	}
+}
+
+// document2Manifest keeps the document's services and relations in a
+// manifest graph, relations still reference services by name
+func document2Manifest(doc Document) client.ManifestGraph {
+	graph := client.ManifestGraph{
+		Name:      doc.Name,
+		Services:  make([]client.ManifestService, 0, len(doc.Services)),
+		Relations: make([]client.ManifestRelation, 0, len(doc.Relations)),
+	}
+	for _, serv := range doc.Services {
+		graph.Services = append(graph.Services, client.ManifestService{
+			Name:        serv.Name,
+			Description: serv.Description,
+			X:           serv.X,
+			Y:           serv.Y,
+			Kind:        serv.Kind,
+			OwnerTeam:   serv.OwnerTeam,
+			Tags:        serv.Tags,
+			Attributes:  serv.Attributes,
+			Links:       serv.Links,
+		})
+	}
+	for _, rel := range doc.Relations {
+		graph.Relations = append(graph.Relations, client.ManifestRelation(rel))
+	}
+	return graph
+}
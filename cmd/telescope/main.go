@@ -0,0 +1,240 @@
+// Command telescope manages projects and graphs of a core instance from the
+// command line.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hse-telescope/core/pkg/client"
+)
+
+const usage = `Usage: telescope [-server URL] [-timeout DURATION] <command> [arguments]
+
+Commands:
+  projects list
+  projects create <name>
+  graphs list <project-id>
+  graphs create <project-id> <name>
+  graphs show [-format tree|table] <graph-id>
+  graphs export [-o FILE] [-format yaml|json] <graph-id>
+  graphs import <project-id> <file>
+  graphs apply [-dry-run] <graph-id> <file>
+
+The server defaults to $TELESCOPE_URL or http://localhost:8080.
+`
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	err := run(context.Background(), os.Args[1:], os.Stdout)
+	if errors.Is(err, errUsage) {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "telescope:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	server := os.Getenv("TELESCOPE_URL")
+	if server == "" {
+		server = "http://localhost:8080"
+	}
+
+	flags := flag.NewFlagSet("telescope", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&server, "server", server, "core HTTP API URL")
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout of the whole command")
+	err := flags.Parse(args)
+	if err != nil {
+		return errUsage
+	}
+	args = flags.Args()
+	if len(args) < 2 {
+		return errUsage
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	c := client.New(server, client.Config{})
+
+	switch args[0] + " " + args[1] {
+	case "projects list":
+		projects, err := c.GetProjects(ctx)
+		if err != nil {
+			return err
+		}
+		return printProjects(out, projects)
+
+	case "projects create":
+		if len(args) != 3 {
+			return errUsage
+		}
+		project, err := c.CreateProject(ctx, client.Project{Name: args[2]})
+		if err != nil {
+			return err
+		}
+		return printProjects(out, []client.Project{project})
+
+	case "graphs list":
+		ids, err := intArgs(args[2:], 1)
+		if err != nil {
+			return err
+		}
+		graphs, err := c.GetProjectGraphs(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return printGraphs(out, graphs)
+
+	case "graphs create":
+		if len(args) != 4 {
+			return errUsage
+		}
+		ids, err := intArgs(args[2:3], 1)
+		if err != nil {
+			return err
+		}
+		graph, err := c.CreateGraph(ctx, client.Graph{ProjectID: ids[0], Name: args[3]})
+		if err != nil {
+			return err
+		}
+		return printGraphs(out, []client.Graph{graph})
+
+	case "graphs show":
+		sub := flag.NewFlagSet("show", flag.ContinueOnError)
+		sub.SetOutput(io.Discard)
+		format := sub.String("format", "tree", "tree or table")
+		if sub.Parse(args[2:]) != nil {
+			return errUsage
+		}
+		ids, err := intArgs(sub.Args(), 1)
+		if err != nil {
+			return err
+		}
+		return show(ctx, c, ids[0], *format, out)
+
+	case "graphs export":
+		sub := flag.NewFlagSet("export", flag.ContinueOnError)
+		sub.SetOutput(io.Discard)
+		output := sub.String("o", "-", "output file, stdout by default")
+		format := sub.String("format", "", "yaml or json, taken from the file extension by default")
+		if sub.Parse(args[2:]) != nil {
+			return errUsage
+		}
+		ids, err := intArgs(sub.Args(), 1)
+		if err != nil {
+			return err
+		}
+		doc, err := export(ctx, c, ids[0])
+		if err != nil {
+			return err
+		}
+		return writeDocument(out, *output, *format, doc)
+
+	case "graphs import":
+		if len(args) != 4 {
+			return errUsage
+		}
+		ids, err := intArgs(args[2:3], 1)
+		if err != nil {
+			return err
+		}
+		doc, err := readDocument(args[3])
+		if err != nil {
+			return err
+		}
+		graph, err := importDocument(ctx, c, ids[0], doc)
+		if err != nil {
+			return err
+		}
+		return printGraphs(out, []client.Graph{graph})
+
+	case "graphs apply":
+		sub := flag.NewFlagSet("apply", flag.ContinueOnError)
+		sub.SetOutput(io.Discard)
+		dryRun := sub.Bool("dry-run", false, "only print the changes")
+		if sub.Parse(args[2:]) != nil || sub.NArg() != 2 {
+			return errUsage
+		}
+		ids, err := intArgs(sub.Args()[:1], 1)
+		if err != nil {
+			return err
+		}
+		doc, err := readDocument(sub.Arg(1))
+		if err != nil {
+			return err
+		}
+		return apply(ctx, c, ids[0], doc, *dryRun, out)
+	}
+	return errUsage
+}
+
+func intArgs(args []string, n int) ([]int, error) {
+	if len(args) != n {
+		return nil, errUsage
+	}
+	res := make([]int, 0, n)
+	for _, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("ID must be a number: %q", arg)
+		}
+		res = append(res, id)
+	}
+	return res, nil
+}
+
+func export(ctx context.Context, c *client.Client, graph_id int) (Document, error) {
+	graph, err := c.GetGraph(ctx, graph_id)
+	if err != nil {
+		return Document{}, err
+	}
+	services, err := c.GetGraphServices(ctx, graph_id, client.ServiceFilter{})
+	if err != nil {
+		return Document{}, err
+	}
+	relations, err := c.GetGraphRelations(ctx, graph_id)
+	if err != nil {
+		return Document{}, err
+	}
+	return newDocument(graph, services, relations), nil
+}
+
+func show(ctx context.Context, c *client.Client, graph_id int, format string, out io.Writer) error {
+	graph, err := c.GetGraph(ctx, graph_id)
+	if err != nil {
+		return err
+	}
+	services, err := c.GetGraphServices(ctx, graph_id, client.ServiceFilter{})
+	if err != nil {
+		return err
+	}
+	relations, err := c.GetGraphRelations(ctx, graph_id)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "tree":
+		printTree(out, graph, services, relations)
+		return nil
+	case "table":
+		err = printServicesTable(out, services)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+		return printRelationsTable(out, services, relations)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
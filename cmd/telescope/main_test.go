@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/config"
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/providers/catalog"
+	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/providers/group"
+	"github.com/hse-telescope/core/internal/providers/idempotency"
+	"github.com/hse-telescope/core/internal/providers/manifest"
+	"github.com/hse-telescope/core/internal/providers/project"
+	"github.com/hse-telescope/core/internal/providers/relation"
+	"github.com/hse-telescope/core/internal/providers/service"
+	"github.com/hse-telescope/core/internal/providers/webhook"
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/memory"
+	"github.com/hse-telescope/core/internal/server"
+	"github.com/hse-telescope/core/pkg/client"
+)
+
+// newServer serves the REST API over the in-memory storage and returns its
+// URL. before is called ahead of every request when it is set.
+func newServer(t *testing.T, before func(r *http.Request)) string {
+	f := facade.New(memory.New(), events.NewBroker(), nil)
+	api := server.New(config.Config{},
+		project.New(f), graph.New(f), service.New(f), relation.New(f), catalog.New(f),
+		group.New(f), webhook.New(f), manifest.New(f), idempotency.New(f, idempotency.Config{}),
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if before != nil {
+			before(r)
+		}
+		api.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// telescope runs the command against the server and returns its output
+func telescope(t *testing.T, url string, args ...string) (string, error) {
+	t.Helper()
+	var out strings.Builder
+	err := run(context.Background(), append([]string{"-server", url}, args...), &out)
+	return out.String(), err
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const shop = `name: prod
+services:
+  - name: api
+    kind: api
+    owner_team: core
+  - name: db
+    kind: database
+relations:
+  - name: queries
+    from: api
+    to: db
+    protocol: sql
+`
+
+// newProject creates a project and imports the documents as its graphs,
+// returning the project and its graph IDs by name
+func newProject(t *testing.T, url string, docs ...string) (client.Project, map[string]int) {
+	t.Helper()
+	ctx := context.Background()
+	c := client.New(url, client.Config{})
+	proj, err := c.CreateProject(ctx, client.Project{Name: "shop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, doc := range docs {
+		_, err = telescope(t, url, "graphs", "import", strconv.Itoa(proj.ID), writeFile(t, strconv.Itoa(i)+".yaml", doc))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	graphs, err := c.GetProjectGraphs(ctx, proj.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]int, len(graphs))
+	for _, graph := range graphs {
+		ids[graph.Name] = graph.ID
+	}
+	return proj, ids
+}
+
+// exported returns the graph the way export writes it
+func exported(t *testing.T, url string, graph_id int) Document {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "graph.yaml")
+	_, err := telescope(t, url, "graphs", "export", "-o", path, strconv.Itoa(graph_id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := readDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func parse(t *testing.T, content string) Document {
+	t.Helper()
+	doc, err := readDocument(writeFile(t, "doc.yaml", content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestImportExport(t *testing.T) {
+	url := newServer(t, nil)
+	_, ids := newProject(t, url, shop)
+
+	got := exported(t, url, ids["prod"])
+	if want := parse(t, shop); !reflect.DeepEqual(got, want) {
+		t.Fatalf("exported %+v, want %+v", got, want)
+	}
+}
+
+const stage = `name: stage
+services:
+  - name: api
+    kind: api
+`
+
+// changed updates api, deletes db, its relation going away with it, and
+// adds cache
+const changed = `name: prod
+services:
+  - name: api
+    kind: api
+    owner_team: platform
+  - name: cache
+    kind: cache
+relations:
+  - name: reads
+    from: api
+    to: cache
+`
+
+func TestApply(t *testing.T) {
+	url := newServer(t, nil)
+	_, ids := newProject(t, url, shop, stage)
+	path := writeFile(t, "prod.yaml", changed)
+	plan := "~ service api: owner_team\n+ service cache\n- service db\n+ relation reads (api -> cache)\n"
+
+	out, err := telescope(t, url, "graphs", "apply", "-dry-run", strconv.Itoa(ids["prod"]), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != plan {
+		t.Fatalf("dry run printed:\n%s\nwant:\n%s", out, plan)
+	}
+	if got, want := exported(t, url, ids["prod"]), parse(t, shop); !reflect.DeepEqual(got, want) {
+		t.Fatalf("dry run changed the graph to %+v", got)
+	}
+
+	out, err = telescope(t, url, "graphs", "apply", strconv.Itoa(ids["prod"]), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != plan {
+		t.Fatalf("apply printed:\n%s\nwant:\n%s", out, plan)
+	}
+	if got, want := exported(t, url, ids["prod"]), parse(t, changed); !reflect.DeepEqual(got, want) {
+		t.Fatalf("graph is %+v, want %+v", got, want)
+	}
+	if got, want := exported(t, url, ids["stage"]), parse(t, stage); !reflect.DeepEqual(got, want) {
+		t.Fatalf("other graph is changed to %+v", got)
+	}
+
+	out, err = telescope(t, url, "graphs", "apply", strconv.Itoa(ids["prod"]), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "graph is up to date\n" {
+		t.Fatalf("second apply printed:\n%s", out)
+	}
+}
+
+func TestApplyRejectsAnotherGraph(t *testing.T) {
+	url := newServer(t, nil)
+	_, ids := newProject(t, url, shop, stage)
+
+	_, err := telescope(t, url, "graphs", "apply", strconv.Itoa(ids["stage"]), writeFile(t, "prod.yaml", changed))
+	if err == nil || !strings.Contains(err.Error(), `describes graph "prod"`) {
+		t.Fatalf("got %v, want the graph name mismatch", err)
+	}
+	if got, want := exported(t, url, ids["stage"]), parse(t, stage); !reflect.DeepEqual(got, want) {
+		t.Fatalf("graph is changed to %+v", got)
+	}
+}
+
+func TestApplyRefusesToRevertConcurrentChanges(t *testing.T) {
+	var url string
+	var once sync.Once
+	var changed error
+	url = newServer(t, func(r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/plan") {
+			return
+		}
+		// Another client changes the other graph after it has been read
+		once.Do(func() {
+			_, changed = telescope(t, url, "graphs", "import", strings.Split(r.URL.Path, "/")[2], writeFile(t, "stage.yaml", "name: canary\nservices: []\n"))
+		})
+	})
+	_, ids := newProject(t, url, shop)
+
+	_, err := telescope(t, url, "graphs", "apply", strconv.Itoa(ids["prod"]), writeFile(t, "prod.yaml", shop))
+	if changed != nil {
+		t.Fatal(changed)
+	}
+	if err == nil || !strings.Contains(err.Error(), `graph "canary" of the project has changed`) {
+		t.Fatalf("got %v, want the concurrent change", err)
+	}
+}
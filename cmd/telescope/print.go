@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hse-telescope/core/pkg/client"
+)
+
+func describeService(serv client.Service) string {
+	details := make([]string, 0, 2)
+	if serv.Kind != "" {
+		details = append(details, serv.Kind)
+	}
+	if serv.OwnerTeam != "" {
+		details = append(details, "team "+serv.OwnerTeam)
+	}
+	if len(details) == 0 {
+		return serv.Name
+	}
+	return fmt.Sprintf("%s (%s)", serv.Name, strings.Join(details, ", "))
+}
+
+func describeRelation(rel client.Relation, names map[int]string) string {
+	arrow := "->"
+	if rel.Bidirectional {
+		arrow = "<->"
+	}
+	res := fmt.Sprintf("%s %s", arrow, names[rel.ToService])
+	details := make([]string, 0, 3)
+	if rel.Name != "" {
+		details = append(details, rel.Name)
+	}
+	if rel.Protocol != "" {
+		details = append(details, rel.Protocol)
+	}
+	if rel.Async {
+		details = append(details, "async")
+	}
+	if len(details) > 0 {
+		res += " [" + strings.Join(details, ", ") + "]"
+	}
+	return res
+}
+
+// printTree prints every service with its outgoing relations below it
+func printTree(out io.Writer, graph client.Graph, services []client.Service, relations []client.Relation) {
+	names := make(map[int]string, len(services))
+	for _, serv := range services {
+		names[serv.ID] = serv.Name
+	}
+	outgoing := make(map[int][]client.Relation)
+	for _, rel := range relations {
+		outgoing[rel.FromService] = append(outgoing[rel.FromService], rel)
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+
+	fmt.Fprintf(out, "%s (#%d)\n", graph.Name, graph.ID)
+	for i, serv := range services {
+		branch, indent := "├── ", "│   "
+		if i == len(services)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Fprintln(out, branch+describeService(serv))
+		rels := outgoing[serv.ID]
+		for j, rel := range rels {
+			relBranch := "├── "
+			if j == len(rels)-1 {
+				relBranch = "└── "
+			}
+			fmt.Fprintln(out, indent+relBranch+describeRelation(rel, names))
+		}
+	}
+}
+
+func printServicesTable(out io.Writer, services []client.Service) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tKIND\tOWNER\tTAGS")
+	for _, serv := range services {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", serv.ID, serv.Name, serv.Kind, serv.OwnerTeam, strings.Join(serv.Tags, ","))
+	}
+	return w.Flush()
+}
+
+func printRelationsTable(out io.Writer, services []client.Service, relations []client.Relation) error {
+	names := make(map[int]string, len(services))
+	for _, serv := range services {
+		names[serv.ID] = serv.Name
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tFROM\tTO\tPROTOCOL\tCRITICALITY")
+	for _, rel := range relations {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", rel.ID, rel.Name, names[rel.FromService], names[rel.ToService], rel.Protocol, rel.Criticality)
+	}
+	return w.Flush()
+}
+
+func printProjects(out io.Writer, projects []client.Project) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME")
+	for _, project := range projects {
+		fmt.Fprintf(w, "%d\t%s\n", project.ID, project.Name)
+	}
+	return w.Flush()
+}
+
+func printGraphs(out io.Writer, graphs []client.Graph) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tPROJECT\tNAME")
+	for _, graph := range graphs {
+		fmt.Fprintf(w, "%d\t%d\t%s\n", graph.ID, graph.ProjectID, graph.Name)
+	}
+	return w.Flush()
+}
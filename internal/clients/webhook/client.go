@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Telescope-Event"
+	HeaderDelivery  = "X-Telescope-Delivery"
+	HeaderTimestamp = "X-Telescope-Timestamp"
+	HeaderSignature = "X-Telescope-Signature"
+)
+
+type Config struct {
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Validate reports an invalid value, zero stands for the default
+func (c Config) Validate() error {
+	if c.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
+
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID int
+	Payload    []byte
+}
+
+type Client struct {
+	client *http.Client
+}
+
+func New(conf Config) Client {
+	timeout := conf.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	return Client{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Sign returns the value of the signature header: an HMAC-SHA256 of
+// "<timestamp>.<body>" keyed by the webhook secret.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the payload and returns the receiver status code. Any status
+// outside of 2xx is reported as an error.
+func (c Client) Send(ctx context.Context, req Request) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(HeaderEvent, req.Event)
+	httpReq.Header.Set(HeaderDelivery, strconv.Itoa(req.DeliveryID))
+	httpReq.Header.Set(HeaderTimestamp, timestamp)
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Payload))
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook receiver responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
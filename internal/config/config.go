@@ -1,36 +1,287 @@
 package config
 
 import (
-	"os"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
+	webhookclient "github.com/hse-telescope/core/internal/clients/webhook"
+	"github.com/hse-telescope/core/internal/health"
+	"github.com/hse-telescope/core/internal/lifecycle"
+	"github.com/hse-telescope/core/internal/providers/idempotency"
+	"github.com/hse-telescope/core/internal/providers/webhook"
+	"github.com/hse-telescope/core/internal/ratelimit"
+	"github.com/hse-telescope/core/internal/repository/db"
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/sqlite"
+	"github.com/hse-telescope/core/internal/tlsreload"
 	"github.com/hse-telescope/logger"
 	"github.com/hse-telescope/utils/db/psql"
-	"gopkg.in/yaml.v3"
 )
 
-type Clients struct{}
+// Storage backends
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+)
 
-// Config ...
-type Config struct {
-	Port             uint16        `yaml:"port"`
-	DB               psql.DB       `yaml:"db"`
-	Clients          Clients       `yaml:"clients"`
-	Logger           logger.Config `yaml:"logger"`
-	OTELCollectorURL string        `yaml:"otel_collector_url"`
+// Clients configures the clients core calls other services with
+type Clients struct {
+	Webhook webhookclient.Config `yaml:"webhook"`
 }
 
-// Parse ...
-func Parse(path string) (Config, error) {
-	bytes, err := os.ReadFile(path) // nolint:gosec
-	if err != nil {
-		return Config{}, err
+// HTTP configures how the HTTP API meets browsers and the network
+type HTTP struct {
+	CORS CORS `yaml:"cors"`
+	// SecurityHeaders are added to every response on top of the defaults of
+	// the server, an empty value drops a default header
+	SecurityHeaders map[string]string `yaml:"security_headers"`
+	TLS             tlsreload.Config  `yaml:"tls"`
+}
+
+// Validate reports every invalid value at once
+func (h HTTP) Validate() error {
+	errs := section("cors", h.CORS.Validate())
+	for name := range h.SecurityHeaders {
+		if name == "" || strings.ContainsAny(name, " :\r\n") {
+			errs = append(errs, fmt.Errorf("security_headers has an invalid header name %q", name))
+		}
 	}
+	errs = append(errs, section("tls", h.TLS.Validate())...)
+	return errors.Join(errs...)
+}
+
+// CORS lets browser pages of other origins call the API, it is off while no
+// origin is allowed
+type CORS struct {
+	// AllowedOrigins are the origins pages may call from, "*" allows any
+	AllowedOrigins []string `yaml:"allowed_origins"`
+	// AllowedMethods default to the methods of the API
+	AllowedMethods []string `yaml:"allowed_methods"`
+	// AllowedHeaders are the request headers pages may set besides the
+	// simple ones, Content-Type and Idempotency-Key by default
+	AllowedHeaders []string `yaml:"allowed_headers"`
+	// ExposedHeaders are the response headers pages may read besides the
+	// simple ones, Retry-After and Idempotent-Replayed by default
+	ExposedHeaders []string `yaml:"exposed_headers"`
+	// AllowCredentials lets pages send cookies and client certificates
+	AllowCredentials bool `yaml:"allow_credentials"`
+	// MaxAge is how long browsers cache a preflight response, 10m by default
+	MaxAge time.Duration `yaml:"max_age"`
+}
 
-	config := Config{}
-	err = yaml.Unmarshal(bytes, &config)
+// WithDefaults fills the zero values in
+func (c CORS) WithDefaults() CORS {
+	if c.AllowedMethods == nil {
+		c.AllowedMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	}
+	if c.AllowedHeaders == nil {
+		c.AllowedHeaders = []string{"Content-Type", "Idempotency-Key"}
+	}
+	if c.ExposedHeaders == nil {
+		c.ExposedHeaders = []string{"Retry-After", "Idempotent-Replayed"}
+	}
+	if c.MaxAge == 0 {
+		c.MaxAge = 10 * time.Minute
+	}
+	return c
+}
+
+// Validate reports every invalid value at once
+func (c CORS) Validate() error {
+	var errs []error
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			if c.AllowCredentials {
+				errs = append(errs, errors.New("allowed_origins cannot be \"*\" with allow_credentials"))
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			errs = append(errs, fmt.Errorf("allowed_origins has %q, an origin is a scheme and a host", origin))
+		}
+	}
+	if c.MaxAge < 0 {
+		errs = append(errs, errors.New("max_age must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Runtime is the part of the config a running core reloads, see Watcher.
+// Everything else takes a restart.
+type Runtime struct {
+	// LogLevel drops the records below it: debug, info, warn or error, info
+	// by default. It cannot lower the level set by the logger mode.
+	LogLevel string `yaml:"log_level"`
+	// Features toggles features by name, see Enabled
+	Features map[string]bool `yaml:"features"`
+	// Limits bound the requests of the HTTP API
+	Limits Limits `yaml:"limits"`
+}
+
+// Limits bound the requests of the HTTP API, zero values take the defaults
+type Limits struct {
+	RateLimit ratelimit.Config `yaml:"rate_limit"`
+	// MaxBodyBytes is the largest request body accepted, 4 MiB by default
+	MaxBodyBytes int64 `yaml:"max_body_bytes"`
+	// MaxBatchItems is the most entities a bulk request carries, 1000 by
+	// default
+	MaxBatchItems int `yaml:"max_batch_items"`
+}
+
+// WithDefaults fills the zero values in
+func (l Limits) WithDefaults() Limits {
+	if l.MaxBodyBytes == 0 {
+		l.MaxBodyBytes = 4 << 20
+	}
+	if l.MaxBatchItems == 0 {
+		l.MaxBatchItems = 1000
+	}
+	return l
+}
+
+// Validate reports every invalid value at once
+func (l Limits) Validate() error {
+	errs := section("rate_limit", l.RateLimit.Validate())
+	if l.MaxBodyBytes < 0 {
+		errs = append(errs, errors.New("max_body_bytes must not be negative"))
+	}
+	if l.MaxBatchItems < 0 {
+		errs = append(errs, errors.New("max_batch_items must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Enabled tells whether the feature is on, features are off unless toggled
+func (r Runtime) Enabled(feature string) bool {
+	return r.Features[feature]
+}
+
+// Level parses LogLevel, empty is info
+func (r Runtime) Level() (slog.Level, error) {
+	if r.LogLevel == "" {
+		return slog.LevelInfo, nil
+	}
+	var level slog.Level
+	err := level.UnmarshalText([]byte(r.LogLevel))
+	return level, err
+}
+
+// Validate reports every invalid value at once
+func (r Runtime) Validate() error {
+	var errs []error
+	_, err := r.Level()
 	if err != nil {
-		return Config{}, err
+		errs = append(errs, fmt.Errorf("log_level %q is not one of debug, info, warn, error", r.LogLevel))
+	}
+	errs = append(errs, section("limits", r.Limits.Validate())...)
+	return errors.Join(errs...)
+}
+
+// Config of core, see Load for where it comes from
+type Config struct {
+	Port             uint16             `yaml:"port"`
+	GRPCPort         uint16             `yaml:"grpc_port"`
+	Storage          string             `yaml:"storage"`
+	DB               db.Config          `yaml:"db"`
+	SQLite           sqlite.Config      `yaml:"sqlite"`
+	Cache            facade.CacheConfig `yaml:"cache"`
+	Clients          Clients            `yaml:"clients"`
+	Logger           logger.Config      `yaml:"logger"`
+	OTELCollectorURL string             `yaml:"otel_collector_url"`
+	Webhooks         webhook.Config     `yaml:"webhooks"`
+	Idempotency      idempotency.Config `yaml:"idempotency"`
+	Shutdown         lifecycle.Config   `yaml:"shutdown"`
+	Health           health.Config      `yaml:"health"`
+	HTTP             HTTP               `yaml:"http"`
+	Runtime          Runtime            `yaml:"runtime"`
+
+	// path of the YAML file, empty when there is none
+	path string
+}
+
+// Path returns the YAML file the config was loaded from, empty when there is
+// none
+func (c Config) Path() string {
+	return c.path
+}
+
+// Default returns the config Load starts from. Sections left out here
+// apply their own defaults to their zero values.
+func Default() Config {
+	return Config{
+		Port:     8080,
+		GRPCPort: 9090,
+		Storage:  StoragePostgres,
+		DB: db.Config{
+			DB: psql.DB{
+				Schema:         "postgres",
+				IP:             "localhost",
+				Port:           5432,
+				SSL:            "disable",
+				MigrationsPath: "file://migrations",
+			},
+		},
+		SQLite: sqlite.Config{
+			Path: "core.db",
+		},
+	}
+}
+
+// Validate reports every invalid value at once, each prefixed with its key
+func (c Config) Validate() error {
+	var errs []error
+	if c.Port == 0 {
+		errs = append(errs, errors.New("port is required"))
+	}
+	if c.GRPCPort == 0 {
+		errs = append(errs, errors.New("grpc_port is required"))
+	}
+	if c.Port != 0 && c.Port == c.GRPCPort {
+		errs = append(errs, fmt.Errorf("port and grpc_port are both %d", c.Port))
+	}
+
+	switch c.Storage {
+	case StoragePostgres:
+		errs = append(errs, section("db", c.DB.Validate())...)
+	case StorageSQLite:
+		errs = append(errs, section("sqlite", c.SQLite.Validate())...)
+	case StorageMemory:
+	default:
+		errs = append(errs, fmt.Errorf("storage %q is not one of %s, %s, %s", c.Storage, StoragePostgres, StorageSQLite, StorageMemory))
+	}
+	if c.Cache.Size < 0 {
+		errs = append(errs, errors.New("cache.size must not be negative"))
 	}
 
-	return config, nil
+	errs = append(errs, section("clients.webhook", c.Clients.Webhook.Validate())...)
+	errs = append(errs, section("webhooks", c.Webhooks.Validate())...)
+	errs = append(errs, section("idempotency", c.Idempotency.Validate())...)
+	errs = append(errs, section("shutdown", c.Shutdown.Validate())...)
+	errs = append(errs, section("health", c.Health.Validate())...)
+	errs = append(errs, section("http", c.HTTP.Validate())...)
+	errs = append(errs, section("runtime", c.Runtime.Validate())...)
+	return errors.Join(errs...)
+}
+
+// section prefixes the errors of a section validation with the key of the
+// section
+func section(key string, err error) []error {
+	if err == nil {
+		return nil
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for i, err := range errs {
+		errs[i] = fmt.Errorf("%s.%w", key, err)
+	}
+	return errs
 }
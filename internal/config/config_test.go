@@ -0,0 +1,32 @@
+package config_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/config"
+)
+
+func TestRuntimeLevel(t *testing.T) {
+	for _, c := range []struct {
+		logLevel string
+		want     slog.Level
+	}{
+		{"", slog.LevelInfo},
+		{"debug", slog.LevelDebug},
+		{"warn", slog.LevelWarn},
+		{"error", slog.LevelError},
+	} {
+		got, err := config.Runtime{LogLevel: c.logLevel}.Level()
+		if err != nil {
+			t.Fatalf("log_level %q: %v", c.logLevel, err)
+		}
+		if got != c.want {
+			t.Errorf("log_level %q is %s, want %s", c.logLevel, got, c.want)
+		}
+	}
+	err := config.Runtime{LogLevel: "verbose"}.Validate()
+	if err == nil {
+		t.Error("unknown log_level is accepted")
+	}
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EnvPrefix starts the environment variables of every key, db.password is
+	// set by CORE_DB_PASSWORD
+	EnvPrefix = "CORE_"
+	// EnvConfig holds the path of the YAML file when the -config flag is not
+	// given
+	EnvConfig = EnvPrefix + "CONFIG"
+	// FileSuffix reads a key from a file, CORE_DB_PASSWORD_FILE holds the
+	// path of the file with the password
+	FileSuffix = "_FILE"
+)
+
+// Load builds the config in layers, each one overriding the previous:
+// defaults, the YAML file, environment variables and then command line
+// flags. Every key has a flag named after its dotted path, -db.password, and
+// an environment variable, CORE_DB_PASSWORD. The YAML file is given with
+// -config, CORE_CONFIG or as the only argument, it is optional.
+//
+// Unknown YAML keys and CORE_ variables are errors. Load reports the errors
+// of every layer and of the validation at once.
+func Load(args []string, environ []string) (Config, error) {
+	config := Default()
+	keys := configKeys(&config)
+	env := parseEnviron(environ)
+
+	fs := flag.NewFlagSet("core", flag.ContinueOnError)
+	path := fs.String("config", env[EnvConfig], "path of the YAML config file, "+EnvConfig)
+	var flags []assignment
+	for _, k := range keys {
+		fs.Func(k.key, "or "+k.env(), func(raw string) error {
+			flags = append(flags, assignment{key: k, raw: raw})
+			return nil
+		})
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return Config{}, err
+	}
+	switch {
+	case fs.NArg() == 1 && *path == "":
+		*path = fs.Arg(0)
+	case fs.NArg() > 0:
+		return Config{}, fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+
+	var errs []error
+	config.path = *path
+	if *path != "" {
+		err = loadYAML(*path, &config)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	errs = append(errs, loadEnv(keys, env)...)
+	for _, a := range flags {
+		err = a.key.set(a.raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("flag -%s: %w", a.key.key, err))
+		}
+	}
+	errs = append(errs, config.Validate())
+	err = errors.Join(errs...)
+	if err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// loadYAML decodes the file over config, keys missing from the file keep
+// their value and unknown keys are errors
+func loadYAML(path string, config *Config) error {
+	data, err := os.ReadFile(path) // nolint:gosec
+	if err != nil {
+		return err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(config)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// loadEnv sets the keys given by CORE_ variables, directly or through a file
+func loadEnv(keys []key, env map[string]string) []error {
+	var errs []error
+	known := map[string]bool{EnvConfig: true}
+	for _, k := range keys {
+		name := k.env()
+		known[name] = true
+		known[name+FileSuffix] = true
+
+		raw, direct := env[name]
+		file, fromFile := env[name+FileSuffix]
+		switch {
+		case direct && fromFile:
+			errs = append(errs, fmt.Errorf("both %s and %s%s are set", name, name, FileSuffix))
+			continue
+		case fromFile:
+			data, err := os.ReadFile(file) // nolint:gosec
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s%s: %w", name, FileSuffix, err))
+				continue
+			}
+			// Secret files usually end with a newline
+			raw = strings.TrimRight(string(data), "\r\n")
+		case !direct:
+			continue
+		}
+
+		err := k.set(raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	var unknown []string
+	for name := range env {
+		if strings.HasPrefix(name, EnvPrefix) && !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, fmt.Errorf("unknown environment variable %s", name))
+	}
+	return errs
+}
+
+func parseEnviron(environ []string) map[string]string {
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		name, value, _ := strings.Cut(kv, "=")
+		env[name] = value
+	}
+	return env
+}
+
+// key is a settable value of the config, named by its dotted YAML path
+type key struct {
+	key   string
+	value reflect.Value
+}
+
+type assignment struct {
+	key key
+	raw string
+}
+
+func (k key) env() string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(k.key, ".", "_"))
+}
+
+// set parses raw the way the YAML file would, except strings are taken
+// verbatim so secrets need no quoting
+func (k key) set(raw string) error {
+	if k.value.Kind() == reflect.String {
+		k.value.SetString(raw)
+		return nil
+	}
+	err := yaml.Unmarshal([]byte(raw), k.value.Addr().Interface())
+	if err != nil {
+		return fmt.Errorf("%q is not a valid %s", raw, k.value.Type())
+	}
+	return nil
+}
+
+// configKeys lists the leaves of config, nested structs are walked and
+// inline ones share the path of their parent
+func configKeys(config *Config) []key {
+	return structKeys(reflect.ValueOf(config).Elem(), "")
+}
+
+func structKeys(v reflect.Value, prefix string) []key {
+	var keys []key
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if options == "inline" {
+			keys = append(keys, structKeys(v.Field(i), prefix)...)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			keys = append(keys, structKeys(v.Field(i), path)...)
+			continue
+		}
+		keys = append(keys, key{key: path, value: v.Field(i)})
+	}
+	return keys
+}
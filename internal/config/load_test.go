@@ -0,0 +1,256 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/config"
+)
+
+func TestLoad(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "password")
+	err := os.WriteFile(secret, []byte("s3cret\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name string
+		// yaml is written to the file $CONFIG stands for in args and env, the
+		// file is passed with -config unless they mention it
+		yaml string
+		args []string
+		env  []string
+		want func(t *testing.T, conf config.Config)
+		// errs are all reported at once
+		errs []string
+	}{
+		{
+			name: "Defaults",
+			env:  []string{"CORE_STORAGE=memory"},
+			want: func(t *testing.T, conf config.Config) {
+				if conf.Port != 8080 || conf.GRPCPort != 9090 || conf.SQLite.Path != "core.db" {
+					t.Errorf("defaults are not applied: %+v", conf)
+				}
+				if conf.Path() != "" {
+					t.Errorf("config is loaded from %q without a file", conf.Path())
+				}
+			},
+		},
+		{
+			name: "YAMLOverridesDefaults",
+			yaml: "storage: memory\nport: 8000\n",
+			want: func(t *testing.T, conf config.Config) {
+				if conf.Port != 8000 || conf.GRPCPort != 9090 {
+					t.Errorf("port %d, grpc_port %d, want 8000 and the default 9090", conf.Port, conf.GRPCPort)
+				}
+			},
+		},
+		{
+			name: "EnvOverridesYAML",
+			yaml: "storage: memory\nport: 8000\ngrpc_port: 9000\n",
+			env:  []string{"CORE_PORT=8001"},
+			want: func(t *testing.T, conf config.Config) {
+				if conf.Port != 8001 || conf.GRPCPort != 9000 {
+					t.Errorf("port %d, grpc_port %d, want 8001 and 9000", conf.Port, conf.GRPCPort)
+				}
+			},
+		},
+		{
+			name: "FlagsOverrideEnv",
+			yaml: "storage: memory\nport: 8000\n",
+			args: []string{"-config", "$CONFIG", "-port", "8002"},
+			env:  []string{"CORE_PORT=8001"},
+			want: func(t *testing.T, conf config.Config) {
+				if conf.Port != 8002 {
+					t.Errorf("port %d, want 8002", conf.Port)
+				}
+			},
+		},
+		{
+			name: "NestedAndInlineKeys",
+			args: []string{"-db.user", "core", "-http.cors.max_age", "1m"},
+			env:  []string{"CORE_DB_DATABASE=graphs", "CORE_DB_PASSWORD=plain"},
+			want: func(t *testing.T, conf config.Config) {
+				if conf.DB.User != "core" || conf.DB.DataBase != "graphs" || conf.DB.Password != "plain" {
+					t.Errorf("db is %+v", conf.DB)
+				}
+				if conf.HTTP.CORS.MaxAge.Minutes() != 1 {
+					t.Errorf("http.cors.max_age is %s", conf.HTTP.CORS.MaxAge)
+				}
+			},
+		},
+		{
+			name: "SecretFromFile",
+			yaml: "db:\n  user: core\n  database: graphs\n  password: from-yaml\n",
+			env:  []string{"CORE_DB_PASSWORD_FILE=" + secret},
+			want: func(t *testing.T, conf config.Config) {
+				if conf.DB.Password != "s3cret" {
+					t.Errorf("db.password is %q, want the file content without the newline", conf.DB.Password)
+				}
+			},
+		},
+		{
+			name: "ConfigFromEnv",
+			yaml: "storage: memory\nport: 8000\n",
+			env:  []string{"CORE_CONFIG=$CONFIG"},
+			want: func(t *testing.T, conf config.Config) {
+				if conf.Port != 8000 || conf.Path() == "" {
+					t.Errorf("file of CORE_CONFIG is not loaded: %+v", conf)
+				}
+			},
+		},
+		{
+			name: "ConfigAsArgument",
+			yaml: "storage: memory\nport: 8000\n",
+			args: []string{"$CONFIG"},
+			want: func(t *testing.T, conf config.Config) {
+				if conf.Port != 8000 {
+					t.Errorf("file given as the argument is not loaded: %+v", conf)
+				}
+			},
+		},
+		{
+			name: "SecretSetTwice",
+			env:  []string{"CORE_STORAGE=memory", "CORE_DB_PASSWORD=plain", "CORE_DB_PASSWORD_FILE=" + secret},
+			errs: []string{"both CORE_DB_PASSWORD and CORE_DB_PASSWORD_FILE are set"},
+		},
+		{
+			name: "MissingSecretFile",
+			env:  []string{"CORE_STORAGE=memory", "CORE_DB_PASSWORD_FILE=" + secret + ".missing"},
+			errs: []string{"CORE_DB_PASSWORD_FILE: open"},
+		},
+		{
+			name: "UnknownYAMLKey",
+			yaml: "storage: memory\ndb:\n  usr: core\n",
+			errs: []string{"field usr not found"},
+		},
+		{
+			name: "UnknownEnv",
+			env:  []string{"CORE_STORAGE=memory", "CORE_PROT=8000", "HOME=/root"},
+			errs: []string{"unknown environment variable CORE_PROT"},
+		},
+		{
+			name: "InvalidValues",
+			args: []string{"-grpc_port", "grpc"},
+			env:  []string{"CORE_STORAGE=memory", "CORE_PORT=-1"},
+			errs: []string{`CORE_PORT: "-1" is not a valid uint16`, `flag -grpc_port: "grpc" is not a valid uint16`},
+		},
+		{
+			name: "ValidationReportsEveryError",
+			yaml: "port: 9000\ngrpc_port: 9000\ncache:\n  size: -1\nhttp:\n  tls:\n    cert_file: tls.crt\n",
+			errs: []string{
+				"port and grpc_port are both 9000",
+				"db.user is required",
+				"db.database is required",
+				"cache.size must not be negative",
+				"http.tls.cert_file and key_file are set together",
+			},
+		},
+		{
+			name: "ErrorsOfEveryLayer",
+			yaml: "storage: memory\nport: 0\n",
+			env:  []string{"CORE_PROT=8000"},
+			errs: []string{"unknown environment variable CORE_PROT", "port is required"},
+		},
+		{
+			name: "UnexpectedArguments",
+			args: []string{"one.yaml", "two.yaml"},
+			errs: []string{"unexpected arguments"},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			args := replaceConfig(c.args, path)
+			env := replaceConfig(c.env, path)
+			if c.yaml != "" {
+				err := os.WriteFile(path, []byte(c.yaml), 0o600)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(strings.Join(append(c.args, c.env...), " "), "$CONFIG") {
+					args = append([]string{"-config", path}, args...)
+				}
+			}
+
+			conf, err := config.Load(args, env)
+			if len(c.errs) == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				c.want(t, conf)
+				return
+			}
+			if err == nil {
+				t.Fatal("config is loaded")
+			}
+			for _, want := range c.errs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not report %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func replaceConfig(values []string, path string) []string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		res = append(res, strings.ReplaceAll(v, "$CONFIG", path))
+	}
+	return res
+}
+
+func TestWatcherRejectsRestartKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "port: 8000\nruntime:\n  log_level: info\n")
+	args := []string{"-config", path}
+	conf, err := config.Load(args, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := config.NewWatcher(conf, args, nil)
+	var applied []config.Runtime
+	w.Subscribe(func(runtime config.Runtime) {
+		applied = append(applied, runtime)
+	})
+
+	// The runtime change comes with a new port, the reload is rejected as a
+	// whole
+	writeConfig(t, path, "port: 8001\nruntime:\n  log_level: debug\n")
+	err = w.Reload(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "port cannot change without a restart") {
+		t.Fatalf("got %v, want the port rejected", err)
+	}
+	if len(applied) != 1 || w.Current().Port != 8000 || w.Current().Runtime.LogLevel != "info" {
+		t.Fatalf("rejected reload is applied: %+v, %+v", applied, w.Current())
+	}
+
+	// An invalid file is rejected too
+	writeConfig(t, path, "port: 8000\nruntime:\n  log_level: verbose\n")
+	err = w.Reload(context.Background())
+	if err == nil {
+		t.Fatal("invalid log_level is applied")
+	}
+
+	writeConfig(t, path, "port: 8000\nruntime:\n  log_level: debug\n")
+	err = w.Reload(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 2 || applied[1].LogLevel != "debug" || w.Current().Runtime.LogLevel != "debug" {
+		t.Fatalf("valid reload is not applied: %+v", applied)
+	}
+
+	// Reloading an unchanged file does not call the subscribers
+	err = w.Reload(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 2 {
+		t.Fatalf("unchanged reload calls the subscribers: %+v", applied)
+	}
+}
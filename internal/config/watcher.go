@@ -0,0 +1,154 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// watchInterval is how often the watcher reads the config file, polling
+// follows the symlink swaps of mounted Kubernetes config maps
+const watchInterval = 2 * time.Second
+
+// Watcher reloads the config when its file changes or on SIGHUP, loading it
+// with the same arguments and environment as at startup. Only the runtime
+// section is applied: a reload changing any other key is rejected as a whole
+// and the running config is kept.
+type Watcher struct {
+	args    []string
+	environ []string
+
+	current atomic.Pointer[Config]
+
+	// mu serializes reloads and the calls of the subscribers
+	mu          sync.Mutex
+	subscribers []func(Runtime)
+}
+
+// NewWatcher watches conf, which Load returned for args and environ
+func NewWatcher(conf Config, args []string, environ []string) *Watcher {
+	w := &Watcher{
+		args:    args,
+		environ: environ,
+	}
+	w.current.Store(&conf)
+	return w
+}
+
+// Current returns the config with the last applied runtime section
+func (w *Watcher) Current() Config {
+	return *w.current.Load()
+}
+
+// Subscribe calls fn with the current runtime section and then after every
+// reload that changes it. fn runs on the watcher goroutine and must not
+// subscribe.
+func (w *Watcher) Subscribe(fn func(Runtime)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+	fn(w.Current().Runtime)
+}
+
+// Reload loads the config again and applies its runtime section
+func (w *Watcher) Reload(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	next, err := Load(w.args, w.environ)
+	if err != nil {
+		return err
+	}
+	current := w.Current()
+
+	var restart, changed []string
+	for _, key := range changedKeys(current, next) {
+		if strings.HasPrefix(key, "runtime.") {
+			changed = append(changed, key)
+		} else {
+			restart = append(restart, key)
+		}
+	}
+	if len(restart) > 0 {
+		return fmt.Errorf("%s cannot change without a restart", strings.Join(restart, ", "))
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	current.Runtime = next.Runtime
+	w.current.Store(&current)
+	for _, fn := range w.subscribers {
+		fn(current.Runtime)
+	}
+	slog.InfoContext(ctx, "config reloaded", "changed", changed)
+	return nil
+}
+
+// Run reloads the config on SIGHUP and whenever the content of the file
+// changes, until ctx is done
+func (w *Watcher) Run(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	path := w.Current().Path()
+	var tick <-chan time.Time
+	if path != "" {
+		ticker := time.NewTicker(watchInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	digest := fileDigest(path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			slog.InfoContext(ctx, "reloading config on SIGHUP")
+		case <-tick:
+			next := fileDigest(path)
+			if next == digest {
+				continue
+			}
+			digest = next
+			slog.InfoContext(ctx, "reloading changed config file", "path", path)
+		}
+
+		err := w.Reload(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "config reload rejected", "error", err)
+		}
+	}
+}
+
+// fileDigest hashes the content of the file, a file that cannot be read
+// hashes as empty
+func fileDigest(path string) [sha256.Size]byte {
+	data, _ := os.ReadFile(path) // nolint:gosec
+	return sha256.Sum256(data)
+}
+
+// changedKeys lists the keys whose values differ, without the values as
+// some of them are secrets
+func changedKeys(a Config, b Config) []string {
+	before := configKeys(&a)
+	after := configKeys(&b)
+	var changed []string
+	for i := range before {
+		if !reflect.DeepEqual(before[i].value.Interface(), after[i].value.Interface()) {
+			changed = append(changed, before[i].key)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,53 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/config"
+)
+
+// required are the keys without a default
+const required = "db:\n  user: core\n  database: graphs\n"
+
+// writeConfig writes the YAML config file the watcher reloads
+func writeConfig(t *testing.T, path string, content string) {
+	t.Helper()
+	err := os.WriteFile(path, []byte(required+content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatcherReloadsFeatures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "runtime:\n  features: {}\n")
+	args := []string{"-config", path}
+	conf, err := config.Load(args, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := config.NewWatcher(conf, args, nil)
+	var applied []config.Runtime
+	w.Subscribe(func(runtime config.Runtime) {
+		applied = append(applied, runtime)
+	})
+	if len(applied) != 1 || applied[0].Enabled("graphql") {
+		t.Fatalf("unexpected runtime on subscribe: %+v", applied)
+	}
+
+	writeConfig(t, path, "runtime:\n  features:\n    graphql: true\n")
+	err = w.Reload(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 2 || !applied[1].Enabled("graphql") {
+		t.Fatalf("toggle is not applied: %+v", applied)
+	}
+	if !w.Current().Runtime.Enabled("graphql") {
+		t.Fatal("current config keeps the old toggles")
+	}
+}
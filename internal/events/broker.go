@@ -0,0 +1,84 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event describes a committed change of a project or one of its graphs
+type Event struct {
+	Type       string
+	ProjectID  int
+	GraphID    int
+	OccurredAt time.Time
+	Data       any
+}
+
+type subscription struct {
+	events chan Event
+	once   sync.Once
+}
+
+func (s *subscription) close() {
+	s.once.Do(func() { close(s.events) })
+}
+
+// Broker fans events out to in-process subscribers of a graph. Publishing
+// never blocks: a subscriber whose buffer is full is dropped and its channel
+// is closed, so it can tell a gap from the end of the stream.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[int]map[*subscription]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subs: make(map[int]map[*subscription]struct{}),
+	}
+}
+
+// Subscribe returns a channel of the graph events and a function releasing
+// the subscription. The channel is closed once the subscription is released
+// or dropped.
+func (b *Broker) Subscribe(graph_id int, buffer int) (<-chan Event, func()) {
+	sub := &subscription{events: make(chan Event, buffer)}
+
+	b.mu.Lock()
+	if b.subs[graph_id] == nil {
+		b.subs[graph_id] = make(map[*subscription]struct{})
+	}
+	b.subs[graph_id][sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub.events, func() {
+		b.mu.Lock()
+		b.remove(graph_id, sub)
+		b.mu.Unlock()
+	}
+}
+
+// Publish delivers the event to the subscribers of its graph. It is safe to
+// call on a nil Broker.
+func (b *Broker) Publish(event Event) {
+	if b == nil || event.GraphID == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs[event.GraphID] {
+		select {
+		case sub.events <- event:
+		default:
+			b.remove(event.GraphID, sub)
+		}
+	}
+}
+
+func (b *Broker) remove(graph_id int, sub *subscription) {
+	delete(b.subs[graph_id], sub)
+	if len(b.subs[graph_id]) == 0 {
+		delete(b.subs, graph_id)
+	}
+	sub.close()
+}
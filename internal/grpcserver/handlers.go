@@ -0,0 +1,333 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/olegdayo/omniconv"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/providers/relation"
+	"github.com/hse-telescope/core/internal/providers/service"
+	"github.com/hse-telescope/core/internal/repository/models"
+	corev1 "github.com/hse-telescope/core/pkg/api/core/v1"
+)
+
+func validateServices(services ...*corev1.Service) error {
+	for _, serv := range services {
+		if !service.ValidKind(serv.GetKind()) {
+			return status.Errorf(codes.InvalidArgument, "unknown service kind %q", serv.GetKind())
+		}
+	}
+	return nil
+}
+
+func validateRelations(relations ...*corev1.Relation) error {
+	for _, rel := range relations {
+		if !relation.ValidProtocol(rel.GetProtocol()) {
+			return status.Errorf(codes.InvalidArgument, "unknown relation protocol %q", rel.GetProtocol())
+		}
+		if !relation.ValidCriticality(rel.GetCriticality()) {
+			return status.Errorf(codes.InvalidArgument, "unknown relation criticality %q", rel.GetCriticality())
+		}
+		if rel.GetExpectedRps() < 0 || rel.GetExpectedLatencyMs() < 0 {
+			return status.Error(codes.InvalidArgument, "expected rps and latency must not be negative")
+		}
+	}
+	return nil
+}
+
+func (s *Server) ListProjects(ctx context.Context, _ *corev1.ListProjectsRequest) (*corev1.ListProjectsResponse, error) {
+	projects, err := s.providerProject.GetProjects(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &corev1.ListProjectsResponse{
+		Projects: omniconv.ConvertSlice(projects, ProviderProject2PBProject),
+	}, nil
+}
+
+func (s *Server) CreateProject(ctx context.Context, req *corev1.CreateProjectRequest) (*corev1.Project, error) {
+	project, err := s.providerProject.CreateProject(ctx, PBProject2ProviderProject(req.GetProject()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ProviderProject2PBProject(project), nil
+}
+
+func (s *Server) UpdateProject(ctx context.Context, req *corev1.UpdateProjectRequest) (*emptypb.Empty, error) {
+	err := s.providerProject.UpdateProject(ctx, int(req.GetId()), PBProject2ProviderProject(req.GetProject()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteProject(ctx context.Context, req *corev1.DeleteProjectRequest) (*emptypb.Empty, error) {
+	err := s.providerProject.DeleteProject(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListProjectGraphs(ctx context.Context, req *corev1.ListProjectGraphsRequest) (*corev1.ListProjectGraphsResponse, error) {
+	graphs, err := s.providerGraph.GetProjectGraphs(ctx, int(req.GetProjectId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &corev1.ListProjectGraphsResponse{
+		Graphs: omniconv.ConvertSlice(graphs, ProviderGraph2PBGraph),
+	}, nil
+}
+
+func (s *Server) CreateGraph(ctx context.Context, req *corev1.CreateGraphRequest) (*corev1.Graph, error) {
+	graph, err := s.providerGraph.CreateGraph(ctx, PBGraph2ProviderGraph(req.GetGraph()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ProviderGraph2PBGraph(graph), nil
+}
+
+func (s *Server) UpdateGraph(ctx context.Context, req *corev1.UpdateGraphRequest) (*emptypb.Empty, error) {
+	err := s.providerGraph.UpdateGraph(ctx, int(req.GetId()), PBGraph2ProviderGraph(req.GetGraph()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteGraph(ctx context.Context, req *corev1.DeleteGraphRequest) (*emptypb.Empty, error) {
+	err := s.providerGraph.DeleteGraph(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) GetService(ctx context.Context, req *corev1.GetServiceRequest) (*corev1.Service, error) {
+	service, err := s.providerService.GetService(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ProviderService2PBService(service), nil
+}
+
+func (s *Server) ListGraphServices(ctx context.Context, req *corev1.ListGraphServicesRequest) (*corev1.ListGraphServicesResponse, error) {
+	if !service.ValidKind(req.GetFilter().GetKind()) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown service kind %q", req.GetFilter().GetKind())
+	}
+	services, err := s.providerService.GetGraphServices(ctx, int(req.GetGraphId()), PBFilter2ProviderFilter(req.GetFilter()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &corev1.ListGraphServicesResponse{
+		Services: omniconv.ConvertSlice(services, ProviderService2PBService),
+	}, nil
+}
+
+func (s *Server) CreateService(ctx context.Context, req *corev1.CreateServiceRequest) (*corev1.Service, error) {
+	err := validateServices(req.GetService())
+	if err != nil {
+		return nil, err
+	}
+	service, err := s.providerService.CreateService(ctx, PBService2ProviderService(req.GetService()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ProviderService2PBService(service), nil
+}
+
+func (s *Server) CreateServices(ctx context.Context, req *corev1.CreateServicesRequest) (*corev1.CreateServicesResponse, error) {
+	err := s.checkBatch(len(req.GetServices()))
+	if err != nil {
+		return nil, err
+	}
+	err = validateServices(req.GetServices()...)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := s.providerService.CreateServices(ctx, int(req.GetGraphId()), omniconv.ConvertSlice(req.GetServices(), PBService2ProviderService))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &corev1.CreateServicesResponse{
+		Ids: omniconv.ConvertSlice(ids, func(id int) int64 { return int64(id) }),
+	}, nil
+}
+
+func (s *Server) UpdateService(ctx context.Context, req *corev1.UpdateServiceRequest) (*emptypb.Empty, error) {
+	err := validateServices(req.GetService())
+	if err != nil {
+		return nil, err
+	}
+	err = s.providerService.UpdateService(ctx, int(req.GetId()), PBService2ProviderService(req.GetService()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) UpdateGraphServices(ctx context.Context, req *corev1.UpdateGraphServicesRequest) (*emptypb.Empty, error) {
+	err := s.checkBatch(len(req.GetServices()))
+	if err != nil {
+		return nil, err
+	}
+	err = validateServices(req.GetServices()...)
+	if err != nil {
+		return nil, err
+	}
+	err = s.providerService.UpdateGraphServices(ctx, int(req.GetGraphId()), omniconv.ConvertSlice(req.GetServices(), PBService2ProviderService))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteService(ctx context.Context, req *corev1.DeleteServiceRequest) (*emptypb.Empty, error) {
+	err := s.providerService.DeleteService(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) GetRelation(ctx context.Context, req *corev1.GetRelationRequest) (*corev1.Relation, error) {
+	relation, err := s.providerRelation.GetRelation(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ProviderRelation2PBRelation(relation), nil
+}
+
+func (s *Server) ListGraphRelations(ctx context.Context, req *corev1.ListGraphRelationsRequest) (*corev1.ListGraphRelationsResponse, error) {
+	relations, err := s.providerRelation.GetGraphRelations(ctx, int(req.GetGraphId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &corev1.ListGraphRelationsResponse{
+		Relations: omniconv.ConvertSlice(relations, ProviderRelation2PBRelation),
+	}, nil
+}
+
+func (s *Server) CreateRelation(ctx context.Context, req *corev1.CreateRelationRequest) (*corev1.Relation, error) {
+	err := validateRelations(req.GetRelation())
+	if err != nil {
+		return nil, err
+	}
+	relation, err := s.providerRelation.CreateRelation(ctx, PBRelation2ProviderRelation(req.GetRelation()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ProviderRelation2PBRelation(relation), nil
+}
+
+func (s *Server) CreateRelations(ctx context.Context, req *corev1.CreateRelationsRequest) (*emptypb.Empty, error) {
+	err := s.checkBatch(len(req.GetRelations()))
+	if err != nil {
+		return nil, err
+	}
+	err = validateRelations(req.GetRelations()...)
+	if err != nil {
+		return nil, err
+	}
+	err = s.providerRelation.CreateRelations(ctx, int(req.GetGraphId()), omniconv.ConvertSlice(req.GetRelations(), PBRelation2ProviderRelation))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) UpdateRelation(ctx context.Context, req *corev1.UpdateRelationRequest) (*emptypb.Empty, error) {
+	err := validateRelations(req.GetRelation())
+	if err != nil {
+		return nil, err
+	}
+	err = s.providerRelation.UpdateRelation(ctx, int(req.GetId()), PBRelation2ProviderRelation(req.GetRelation()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) UpdateGraphRelations(ctx context.Context, req *corev1.UpdateGraphRelationsRequest) (*emptypb.Empty, error) {
+	err := s.checkBatch(len(req.GetRelations()))
+	if err != nil {
+		return nil, err
+	}
+	err = validateRelations(req.GetRelations()...)
+	if err != nil {
+		return nil, err
+	}
+	err = s.providerRelation.UpdateGraphRelations(ctx, int(req.GetGraphId()), omniconv.ConvertSlice(req.GetRelations(), PBRelation2ProviderRelation))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteRelation(ctx context.Context, req *corev1.DeleteRelationRequest) (*emptypb.Empty, error) {
+	err := s.providerRelation.DeleteRelation(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// SubscribeGraphChanges streams the changes the broker of this instance
+// hears of, which are the writes made through this instance only
+func (s *Server) SubscribeGraphChanges(req *corev1.SubscribeGraphChangesRequest, stream corev1.CoreService_SubscribeGraphChangesServer) error {
+	if req.GetGraphId() <= 0 {
+		return status.Error(codes.InvalidArgument, "graph_id must be positive")
+	}
+
+	changes, unsubscribe := s.broker.Subscribe(int(req.GetGraphId()), subscriptionBuffer)
+	defer unsubscribe()
+	// The headers tell the client the subscription is established
+	err := stream.SendHeader(metadata.MD{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.stopping:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case event, ok := <-changes:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "subscriber is too slow, changes were dropped")
+			}
+			err = stream.Send(Event2PBGraphChange(event))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Event2PBGraphChange converts a broker event carrying a storage model into
+// a graph change message
+func Event2PBGraphChange(event events.Event) *corev1.GraphChange {
+	change := &corev1.GraphChange{
+		Event:      event.Type,
+		ProjectId:  int64(event.ProjectID),
+		GraphId:    int64(event.GraphID),
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	}
+	switch data := event.Data.(type) {
+	case models.Graph:
+		change.Entity = &corev1.GraphChange_Graph{Graph: ProviderGraph2PBGraph(graph.DBGraph2ProviderGraph(data))}
+	case models.Service:
+		change.Entity = &corev1.GraphChange_Service{Service: ProviderService2PBService(service.DBService2ProviderService(data))}
+	case models.Relation:
+		change.Entity = &corev1.GraphChange_Relation{Relation: ProviderRelation2PBRelation(relation.DBRelation2ProviderRelation(data))}
+	}
+	return change
+}
@@ -0,0 +1,54 @@
+package grpcserver_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hse-telescope/core/internal/config"
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/grpcserver"
+	corev1 "github.com/hse-telescope/core/pkg/api/core/v1"
+)
+
+func TestBatchLimit(t *testing.T) {
+	ctx := context.Background()
+	conf := config.Config{Runtime: config.Runtime{Limits: config.Limits{MaxBatchItems: 2}}}
+	s := grpcserver.New(conf, events.NewBroker(), nil, nil, nil, nil)
+
+	services := []*corev1.Service{{Name: "api"}, {Name: "web"}, {Name: "db"}}
+	relations := []*corev1.Relation{{FromService: 1, ToService: 2}, {FromService: 2, ToService: 3}, {FromService: 3, ToService: 1}}
+	calls := map[string]func() error{
+		"CreateServices": func() error {
+			_, err := s.CreateServices(ctx, &corev1.CreateServicesRequest{GraphId: 4, Services: services})
+			return err
+		},
+		"UpdateGraphServices": func() error {
+			_, err := s.UpdateGraphServices(ctx, &corev1.UpdateGraphServicesRequest{GraphId: 4, Services: services})
+			return err
+		},
+		"CreateRelations": func() error {
+			_, err := s.CreateRelations(ctx, &corev1.CreateRelationsRequest{GraphId: 4, Relations: relations})
+			return err
+		},
+		"UpdateGraphRelations": func() error {
+			_, err := s.UpdateGraphRelations(ctx, &corev1.UpdateGraphRelationsRequest{GraphId: 4, Relations: relations})
+			return err
+		},
+	}
+	for name, call := range calls {
+		err := call()
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("%s with 3 items: got %v, want %s", name, err, codes.ResourceExhausted)
+		}
+	}
+
+	// A reload lowering the limit applies to the next calls
+	s.SetLimits(config.Limits{MaxBatchItems: 1})
+	_, err := s.CreateServices(ctx, &corev1.CreateServicesRequest{GraphId: 4, Services: services[:2]})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("got %v, want %s", err, codes.ResourceExhausted)
+	}
+}
@@ -0,0 +1,140 @@
+package grpcserver
+
+import (
+	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/providers/project"
+	"github.com/hse-telescope/core/internal/providers/relation"
+	"github.com/hse-telescope/core/internal/providers/service"
+	corev1 "github.com/hse-telescope/core/pkg/api/core/v1"
+)
+
+func ProviderProject2PBProject(project project.Project) *corev1.Project {
+	return &corev1.Project{
+		Id:   int64(project.ID),
+		Name: project.Name,
+	}
+}
+
+func PBProject2ProviderProject(pb *corev1.Project) project.Project {
+	return project.Project{
+		ID:   int(pb.GetId()),
+		Name: pb.GetName(),
+	}
+}
+
+func ProviderGraph2PBGraph(graph graph.Graph) *corev1.Graph {
+	return &corev1.Graph{
+		Id:        int64(graph.ID),
+		ProjectId: int64(graph.ProjectID),
+		Name:      graph.Name,
+	}
+}
+
+func PBGraph2ProviderGraph(pb *corev1.Graph) graph.Graph {
+	return graph.Graph{
+		ID:        int(pb.GetId()),
+		ProjectID: int(pb.GetProjectId()),
+		Name:      pb.GetName(),
+	}
+}
+
+func ProviderService2PBService(service service.Service) *corev1.Service {
+	return &corev1.Service{
+		Id:          int64(service.ID),
+		GraphId:     int64(service.GraphID),
+		Name:        service.Name,
+		Description: service.Description,
+		X:           service.X,
+		Y:           service.Y,
+		GroupId:     int2PB(service.GroupID),
+		CatalogId:   int2PB(service.CatalogID),
+		Kind:        service.Kind,
+		OwnerTeam:   service.OwnerTeam,
+		Tags:        service.Tags,
+		Attributes:  service.Attributes,
+		Links: &corev1.ServiceLinks{
+			Documentation: service.DocsURL,
+			Runbook:       service.RunbookURL,
+			Repository:    service.RepoURL,
+		},
+	}
+}
+
+func PBService2ProviderService(pb *corev1.Service) service.Service {
+	return service.Service{
+		ID:          int(pb.GetId()),
+		GraphID:     int(pb.GetGraphId()),
+		Name:        pb.GetName(),
+		Description: pb.GetDescription(),
+		X:           pb.GetX(),
+		Y:           pb.GetY(),
+		GroupID:     pb2Int(pb.GroupId),
+		CatalogID:   pb2Int(pb.CatalogId),
+		Kind:        pb.GetKind(),
+		OwnerTeam:   pb.GetOwnerTeam(),
+		Tags:        pb.GetTags(),
+		Attributes:  pb.GetAttributes(),
+		DocsURL:     pb.GetLinks().GetDocumentation(),
+		RunbookURL:  pb.GetLinks().GetRunbook(),
+		RepoURL:     pb.GetLinks().GetRepository(),
+	}
+}
+
+func PBFilter2ProviderFilter(pb *corev1.ServiceFilter) service.Filter {
+	return service.Filter{
+		Kind:       pb.GetKind(),
+		OwnerTeam:  pb.GetOwnerTeam(),
+		Tags:       pb.GetTags(),
+		Attributes: pb.GetAttributes(),
+	}
+}
+
+func ProviderRelation2PBRelation(relation relation.Relation) *corev1.Relation {
+	return &corev1.Relation{
+		Id:                int64(relation.ID),
+		GraphId:           int64(relation.GraphID),
+		Name:              relation.Name,
+		Description:       relation.Description,
+		FromService:       int64(relation.FromService),
+		ToService:         int64(relation.ToService),
+		Protocol:          relation.Protocol,
+		Async:             relation.Async,
+		Bidirectional:     relation.Bidirectional,
+		Criticality:       relation.Criticality,
+		ExpectedRps:       relation.ExpectedRPS,
+		ExpectedLatencyMs: relation.ExpectedLatencyMS,
+	}
+}
+
+func PBRelation2ProviderRelation(pb *corev1.Relation) relation.Relation {
+	return relation.Relation{
+		ID:                int(pb.GetId()),
+		GraphID:           int(pb.GetGraphId()),
+		Name:              pb.GetName(),
+		Description:       pb.GetDescription(),
+		FromService:       int(pb.GetFromService()),
+		ToService:         int(pb.GetToService()),
+		Protocol:          pb.GetProtocol(),
+		Async:             pb.GetAsync(),
+		Bidirectional:     pb.GetBidirectional(),
+		Criticality:       pb.GetCriticality(),
+		ExpectedRPS:       pb.GetExpectedRps(),
+		ExpectedLatencyMS: pb.GetExpectedLatencyMs(),
+	}
+}
+
+func int2PB(value *int) *int64 {
+	if value == nil {
+		return nil
+	}
+	res := int64(*value)
+	return &res
+}
+
+func pb2Int(value *int64) *int {
+	if value == nil {
+		return nil
+	}
+	res := int(*value)
+	return &res
+}
@@ -0,0 +1,150 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/hse-telescope/core/internal/config"
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/providers/group"
+	"github.com/hse-telescope/core/internal/providers/service"
+	"github.com/hse-telescope/core/internal/server"
+	corev1 "github.com/hse-telescope/core/pkg/api/core/v1"
+	"github.com/hse-telescope/tracer"
+)
+
+// subscriptionBuffer is the number of graph changes a subscriber may lag
+// behind before its stream is aborted
+const subscriptionBuffer = 64
+
+type Server struct {
+	corev1.UnimplementedCoreServiceServer
+
+	addr             string
+	server           *grpc.Server
+	broker           *events.Broker
+	stopping         chan struct{}
+	stop             sync.Once
+	providerProject  server.ProviderProject
+	providerGraph    server.ProviderGraph
+	providerService  server.ProviderService
+	providerRelation server.ProviderRelation
+	limits           atomic.Pointer[config.Limits]
+}
+
+func New(conf config.Config, broker *events.Broker, provideProject server.ProviderProject, provideGraph server.ProviderGraph, provideService server.ProviderService, providerRelation server.ProviderRelation) *Server {
+	s := new(Server)
+	s.addr = fmt.Sprintf(":%d", conf.GRPCPort)
+	s.broker = broker
+	s.stopping = make(chan struct{})
+	s.providerProject = provideProject
+	s.providerGraph = provideGraph
+	s.providerService = provideService
+	s.providerRelation = providerRelation
+	s.SetLimits(conf.Runtime.Limits)
+
+	s.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(tracingUnaryInterceptor),
+		grpc.ChainStreamInterceptor(tracingStreamInterceptor),
+	)
+	corev1.RegisterCoreServiceServer(s.server, s)
+	reflection.Register(s.server)
+	return s
+}
+
+// SetLimits applies the limits to the next calls, only the batch size
+// applies to gRPC
+func (s *Server) SetLimits(limits config.Limits) {
+	limits = limits.WithDefaults()
+	s.limits.Store(&limits)
+}
+
+// checkBatch rejects bulk calls carrying more items than the limit, the
+// same way the REST handlers do
+func (s *Server) checkBatch(items int) error {
+	limit := s.limits.Load().MaxBatchItems
+	if items > limit {
+		return status.Errorf(codes.ResourceExhausted, "At most %d items are accepted at once, got %d", limit, items)
+	}
+	return nil
+}
+
+func (s *Server) Start() error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(lis)
+}
+
+// Serve serves the connections accepted by lis until the server is shut down
+func (s *Server) Serve(lis net.Listener) error {
+	return s.server.Serve(lis)
+}
+
+// Shutdown ends the change subscriptions, so their clients reconnect to
+// another instance, and waits for the calls in flight until ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.stop.Do(func() {
+		close(s.stopping)
+	})
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+func tracingUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, span := tracer.Start(ctx, "grpc"+info.FullMethod)
+	defer span.End()
+
+	return handler(ctx, req)
+}
+
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+func tracingStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := tracer.Start(stream.Context(), "grpc"+info.FullMethod)
+	defer span.End()
+
+	return handler(srv, tracedStream{ServerStream: stream, ctx: ctx})
+}
+
+// toStatus maps provider errors to gRPC statuses the same way the REST
+// handlers map them to HTTP codes
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrForeignCatalogService),
+		errors.Is(err, group.ErrParentGraph),
+		errors.Is(err, group.ErrCycle):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, "Something went wrong: "+err.Error())
+}
@@ -0,0 +1,235 @@
+package grpcserver_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/hse-telescope/core/internal/config"
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/grpcserver"
+	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/providers/project"
+	"github.com/hse-telescope/core/internal/providers/relation"
+	"github.com/hse-telescope/core/internal/providers/service"
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/memory"
+	corev1 "github.com/hse-telescope/core/pkg/api/core/v1"
+)
+
+// serve starts the server over the in-memory storage on a buffered
+// connection and returns a client of it
+func serve(t *testing.T) (*grpcserver.Server, corev1.CoreServiceClient) {
+	t.Helper()
+	broker := events.NewBroker()
+	f := facade.New(memory.New(), broker, nil)
+	s := grpcserver.New(config.Config{}, broker, project.New(f), graph.New(f), service.New(f), relation.New(f))
+
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis) // nolint:errcheck
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		s.Shutdown(ctx) // nolint:errcheck
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() }) // nolint:errcheck
+	return s, corev1.NewCoreServiceClient(conn)
+}
+
+func check(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func wantCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Fatalf("got %v, want %s", err, code)
+	}
+}
+
+func TestCRUD(t *testing.T) {
+	ctx := t.Context()
+	_, client := serve(t)
+
+	proj, err := client.CreateProject(ctx, &corev1.CreateProjectRequest{Project: &corev1.Project{Name: "shop"}})
+	check(t, err)
+	_, err = client.UpdateProject(ctx, &corev1.UpdateProjectRequest{Id: proj.GetId(), Project: &corev1.Project{Name: "store"}})
+	check(t, err)
+	projects, err := client.ListProjects(ctx, &corev1.ListProjectsRequest{})
+	check(t, err)
+	if len(projects.GetProjects()) != 1 || projects.GetProjects()[0].GetName() != "store" {
+		t.Fatalf("unexpected projects: %v", projects.GetProjects())
+	}
+	_, err = client.UpdateProject(ctx, &corev1.UpdateProjectRequest{Id: proj.GetId() + 1, Project: &corev1.Project{Name: "none"}})
+	wantCode(t, err, codes.NotFound)
+
+	gr, err := client.CreateGraph(ctx, &corev1.CreateGraphRequest{Graph: &corev1.Graph{ProjectId: proj.GetId(), Name: "prod"}})
+	check(t, err)
+	_, err = client.UpdateGraph(ctx, &corev1.UpdateGraphRequest{Id: gr.GetId(), Graph: &corev1.Graph{ProjectId: proj.GetId(), Name: "production"}})
+	check(t, err)
+	graphs, err := client.ListProjectGraphs(ctx, &corev1.ListProjectGraphsRequest{ProjectId: proj.GetId()})
+	check(t, err)
+	if len(graphs.GetGraphs()) != 1 || graphs.GetGraphs()[0].GetName() != "production" {
+		t.Fatalf("unexpected graphs: %v", graphs.GetGraphs())
+	}
+
+	api, err := client.CreateService(ctx, &corev1.CreateServiceRequest{Service: &corev1.Service{GraphId: gr.GetId(), Name: "api", Kind: "api"}})
+	check(t, err)
+	created, err := client.CreateServices(ctx, &corev1.CreateServicesRequest{GraphId: gr.GetId(), Services: []*corev1.Service{
+		{Name: "db", Kind: "database", OwnerTeam: "storage"},
+		{Name: "queue", Kind: "queue"},
+	}})
+	check(t, err)
+	ids := created.GetIds()
+	if len(ids) != 2 {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+	_, err = client.CreateService(ctx, &corev1.CreateServiceRequest{Service: &corev1.Service{GraphId: gr.GetId(), Name: "cron", Kind: "mainframe"}})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = client.UpdateService(ctx, &corev1.UpdateServiceRequest{Id: api.GetId(), Service: &corev1.Service{GraphId: gr.GetId(), Name: "gateway", Kind: "api"}})
+	check(t, err)
+	got, err := client.GetService(ctx, &corev1.GetServiceRequest{Id: api.GetId()})
+	check(t, err)
+	if got.GetName() != "gateway" {
+		t.Fatalf("service is not updated: %v", got)
+	}
+	services, err := client.ListGraphServices(ctx, &corev1.ListGraphServicesRequest{GraphId: gr.GetId(), Filter: &corev1.ServiceFilter{OwnerTeam: "storage"}})
+	check(t, err)
+	if len(services.GetServices()) != 1 || services.GetServices()[0].GetId() != ids[0] {
+		t.Fatalf("unexpected filtered services: %v", services.GetServices())
+	}
+
+	rel, err := client.CreateRelation(ctx, &corev1.CreateRelationRequest{Relation: &corev1.Relation{
+		GraphId: gr.GetId(), FromService: api.GetId(), ToService: ids[0], Protocol: "sql",
+	}})
+	check(t, err)
+	_, err = client.CreateRelations(ctx, &corev1.CreateRelationsRequest{GraphId: gr.GetId(), Relations: []*corev1.Relation{
+		{FromService: api.GetId(), ToService: ids[1], Async: true},
+	}})
+	check(t, err)
+	_, err = client.CreateRelation(ctx, &corev1.CreateRelationRequest{Relation: &corev1.Relation{
+		GraphId: gr.GetId(), FromService: api.GetId(), ToService: ids[1], ExpectedRps: -1,
+	}})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = client.UpdateRelation(ctx, &corev1.UpdateRelationRequest{Id: rel.GetId(), Relation: &corev1.Relation{
+		GraphId: gr.GetId(), FromService: api.GetId(), ToService: ids[0], Protocol: "sql", Criticality: "high",
+	}})
+	check(t, err)
+	updated, err := client.GetRelation(ctx, &corev1.GetRelationRequest{Id: rel.GetId()})
+	check(t, err)
+	if updated.GetCriticality() != "high" {
+		t.Fatalf("relation is not updated: %v", updated)
+	}
+	relations, err := client.ListGraphRelations(ctx, &corev1.ListGraphRelationsRequest{GraphId: gr.GetId()})
+	check(t, err)
+	if len(relations.GetRelations()) != 2 {
+		t.Fatalf("unexpected relations: %v", relations.GetRelations())
+	}
+
+	_, err = client.DeleteRelation(ctx, &corev1.DeleteRelationRequest{Id: rel.GetId()})
+	check(t, err)
+	_, err = client.GetRelation(ctx, &corev1.GetRelationRequest{Id: rel.GetId()})
+	wantCode(t, err, codes.NotFound)
+	_, err = client.DeleteService(ctx, &corev1.DeleteServiceRequest{Id: api.GetId()})
+	check(t, err)
+	_, err = client.GetService(ctx, &corev1.GetServiceRequest{Id: api.GetId()})
+	wantCode(t, err, codes.NotFound)
+	_, err = client.DeleteGraph(ctx, &corev1.DeleteGraphRequest{Id: gr.GetId()})
+	check(t, err)
+	_, err = client.DeleteProject(ctx, &corev1.DeleteProjectRequest{Id: proj.GetId()})
+	check(t, err)
+	projects, err = client.ListProjects(ctx, &corev1.ListProjectsRequest{})
+	check(t, err)
+	if len(projects.GetProjects()) != 0 {
+		t.Fatalf("project is not deleted: %v", projects.GetProjects())
+	}
+}
+
+// subscribe opens a change stream of the graph and waits until the server
+// has established the subscription
+func subscribe(t *testing.T, client corev1.CoreServiceClient, graph_id int64) corev1.CoreService_SubscribeGraphChangesClient {
+	t.Helper()
+	stream, err := client.SubscribeGraphChanges(t.Context(), &corev1.SubscribeGraphChangesRequest{GraphId: graph_id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Header()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stream
+}
+
+func TestSubscribeGraphChanges(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, client := serve(t)
+
+	proj, err := client.CreateProject(ctx, &corev1.CreateProjectRequest{Project: &corev1.Project{Name: "shop"}})
+	check(t, err)
+	gr, err := client.CreateGraph(ctx, &corev1.CreateGraphRequest{Graph: &corev1.Graph{ProjectId: proj.GetId(), Name: "prod"}})
+	check(t, err)
+	other, err := client.CreateGraph(ctx, &corev1.CreateGraphRequest{Graph: &corev1.Graph{ProjectId: proj.GetId(), Name: "stage"}})
+	check(t, err)
+
+	stream := subscribe(t, client, gr.GetId())
+	_, err = client.CreateService(ctx, &corev1.CreateServiceRequest{Service: &corev1.Service{GraphId: other.GetId(), Name: "stage-api"}})
+	check(t, err)
+	api, err := client.CreateService(ctx, &corev1.CreateServiceRequest{Service: &corev1.Service{GraphId: gr.GetId(), Name: "api"}})
+	check(t, err)
+	_, err = client.DeleteService(ctx, &corev1.DeleteServiceRequest{Id: api.GetId()})
+	check(t, err)
+
+	for _, event := range []string{"service.created", "service.deleted"} {
+		change, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if change.GetEvent() != event || change.GetGraphId() != gr.GetId() || change.GetProjectId() != proj.GetId() {
+			t.Fatalf("got %v, want %s of graph %d", change, event, gr.GetId())
+		}
+		if change.GetService().GetId() != api.GetId() {
+			t.Fatalf("change carries %v, want service %d", change.GetService(), api.GetId())
+		}
+	}
+
+	invalid, err := client.SubscribeGraphChanges(ctx, &corev1.SubscribeGraphChangesRequest{})
+	if err == nil {
+		_, err = invalid.Recv()
+	}
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestShutdownEndsSubscriptions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s, client := serve(t)
+
+	stream := subscribe(t, client, 1)
+	err := s.Shutdown(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	wantCode(t, err, codes.Unavailable)
+}
@@ -0,0 +1,67 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// Pinger is a dependency reachable over a connection pool
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Ping checks the dependency answers
+func Ping(pinger Pinger) Checker {
+	return CheckerFunc(func(ctx context.Context) (string, error) {
+		return "", pinger.Ping(ctx)
+	})
+}
+
+// Telemetry follows the errors OpenTelemetry reports, the trace and log
+// exporters report their failed exports there. It fails while the last error
+// is more recent than window.
+type Telemetry struct {
+	collector string
+	window    time.Duration
+
+	mu      sync.Mutex
+	lastErr error
+	at      time.Time
+}
+
+// WatchTelemetry installs the global OpenTelemetry error handler. The errors
+// are still printed to stderr, not logged: the logs are exported through
+// OpenTelemetry and would fail along with the export they report.
+func WatchTelemetry(collector string, window time.Duration) *Telemetry {
+	t := &Telemetry{
+		collector: collector,
+		window:    window,
+	}
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(t.handle))
+	return t
+}
+
+func (t *Telemetry) handle(err error) {
+	fmt.Fprintln(os.Stderr, "opentelemetry error:", err)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastErr = err
+	t.at = time.Now()
+}
+
+func (t *Telemetry) Check(ctx context.Context) (string, error) {
+	if t.collector == "" {
+		return "no collector configured", nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.lastErr != nil && time.Since(t.at) < t.window {
+		return "exporting to " + t.collector, fmt.Errorf("failed %s ago: %w", time.Since(t.at).Round(time.Second), t.lastErr)
+	}
+	return "exporting to " + t.collector, nil
+}
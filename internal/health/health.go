@@ -0,0 +1,190 @@
+// Package health keeps the checks behind the liveness and readiness probes.
+//
+// Liveness checks tell the orchestrator to restart the instance, so they only
+// cover the process itself. Readiness checks cover the dependencies: when one
+// fails the instance stops getting traffic but keeps running until it
+// recovers. Informational checks are reported by both probes and never fail
+// them, they are for dependencies core works without, like telemetry.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kind tells which probes a check fails
+type Kind int
+
+const (
+	Liveness Kind = iota
+	Readiness
+	Informational
+)
+
+// Statuses of a report and of its checks
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+	// StatusWarn is a failed informational check
+	StatusWarn = "warn"
+)
+
+type Config struct {
+	// Timeout of a single check, the checks of a probe run concurrently
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.Timeout == 0 {
+		c.Timeout = 2 * time.Second
+	}
+	return c
+}
+
+// Validate reports an invalid value, zero stands for the default
+func (c Config) Validate() error {
+	if c.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
+
+// Checker checks a dependency. The detail it returns is reported along with
+// the status, on success as well as on failure.
+type Checker interface {
+	Check(ctx context.Context) (string, error)
+}
+
+type CheckerFunc func(ctx context.Context) (string, error)
+
+func (f CheckerFunc) Check(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// Report is the body of the probes
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type CheckResult struct {
+	Status   string `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type check struct {
+	name    string
+	kind    Kind
+	checker Checker
+}
+
+// Registry holds the checks, they can be registered at any time and are safe
+// to run concurrently
+type Registry struct {
+	conf Config
+
+	mu     sync.RWMutex
+	checks []check
+}
+
+func NewRegistry(conf Config) *Registry {
+	return &Registry{
+		conf: conf.withDefaults(),
+	}
+}
+
+// Register adds a check, a check registered under a taken name replaces it
+func (r *Registry) Register(name string, kind Kind, checker Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.checks {
+		if r.checks[i].name == name {
+			r.checks[i] = check{name: name, kind: kind, checker: checker}
+			return
+		}
+	}
+	r.checks = append(r.checks, check{name: name, kind: kind, checker: checker})
+}
+
+// Run runs the checks of kind along with the informational ones. The report
+// fails when one of the checks of kind fails.
+func (r *Registry) Run(ctx context.Context, kind Kind) Report {
+	r.mu.RLock()
+	var checks []check
+	for _, c := range r.checks {
+		if c.kind == kind || c.kind == Informational {
+			checks = append(checks, c)
+		}
+	}
+	r.mu.RUnlock()
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].name < checks[j].name
+	})
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.run(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusOK,
+		Checks: make(map[string]CheckResult, len(checks)),
+	}
+	for i, c := range checks {
+		report.Checks[c.name] = results[i]
+		if results[i].Status == StatusFail {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, c check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.conf.Timeout)
+	defer cancel()
+
+	type outcome struct {
+		detail string
+		err    error
+	}
+	// A checker ignoring ctx does not hold the probe past the timeout
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		detail, err := c.checker.Check(ctx)
+		done <- outcome{detail: detail, err: err}
+	}()
+	var out outcome
+	select {
+	case out = <-done:
+	case <-ctx.Done():
+		out.err = fmt.Errorf("timed out after %s", r.conf.Timeout)
+	}
+
+	detail, err := out.detail, out.err
+	result := CheckResult{
+		Status:   StatusOK,
+		Detail:   detail,
+		Duration: time.Since(start).Round(time.Microsecond).String(),
+	}
+	if err != nil {
+		result.Status = StatusFail
+		if c.kind == Informational {
+			result.Status = StatusWarn
+		}
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,428 @@
+// Package jsonpatch applies JSON Merge Patch (RFC 7386) and JSON Patch
+// (RFC 6902) documents to JSON documents.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPatch is returned for a patch that is not well formed
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrTestFailed is returned when a test operation does not match
+	ErrTestFailed = errors.New("test operation failed")
+	// ErrUnapplicable is returned for an operation the document does not
+	// allow, like one on a missing member
+	ErrUnapplicable = errors.New("patch does not apply to the document")
+)
+
+// Merge applies a JSON Merge Patch to doc: members of the patch replace the
+// ones of doc, objects are merged recursively and null members are removed.
+func Merge(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch any) any {
+	members, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]any)
+	if !ok {
+		object = make(map[string]any, len(members))
+	}
+	for name, value := range members {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+		object[name] = merge(object[name], value)
+	}
+	return object
+}
+
+// MergePaths returns the paths of the members a merge patch sets or removes,
+// descending into the objects it merges. A patch that is not an object
+// replaces the whole document, its only path is the empty one.
+func MergePaths(patch []byte) ([][]string, error) {
+	p, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+	return mergePaths(p, nil), nil
+}
+
+func mergePaths(patch any, prefix []string) [][]string {
+	members, ok := patch.(map[string]any)
+	if !ok {
+		return [][]string{prefix}
+	}
+	var paths [][]string
+	for _, name := range slices.Sorted(maps.Keys(members)) {
+		path := append(slices.Clip(prefix), name)
+		if _, ok := members[name].(map[string]any); ok {
+			paths = append(paths, mergePaths(members[name], path)...)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+type operation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Apply applies the operations of a JSON Patch to doc in order, the patch
+// applies as a whole or not at all
+func Apply(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	var ops []operation
+	err = json.Unmarshal(patch, &ops)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+	for i, op := range ops {
+		target, err = op.apply(target)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+// PatchPaths returns the paths the operations of a JSON Patch write: the
+// path of every operation but test and the from of a move
+func PatchPaths(patch []byte) ([][]string, error) {
+	var ops []operation
+	err := json.Unmarshal(patch, &ops)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+	var paths [][]string
+	for i, op := range ops {
+		if op.Path == nil {
+			return nil, fmt.Errorf("operation %d: %w: %s without path", i, ErrInvalidPatch, op.Op)
+		}
+		if op.Op == "test" {
+			continue
+		}
+		path, err := parsePointer(*op.Path)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+		paths = append(paths, path)
+		if op.Op == "move" && op.From != nil {
+			from, err := parsePointer(*op.From)
+			if err != nil {
+				return nil, fmt.Errorf("operation %d: %w", i, err)
+			}
+			paths = append(paths, from)
+		}
+	}
+	return paths, nil
+}
+
+func (op operation) apply(doc any) (any, error) {
+	if op.Path == nil {
+		return nil, fmt.Errorf("%w: %s without path", ErrInvalidPatch, op.Op)
+	}
+	path, err := parsePointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: %s without value", ErrInvalidPatch, op.Op)
+		}
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			return replace(doc, path, value)
+		}
+		current, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(current, value) {
+			return nil, fmt.Errorf("%w: %s", ErrTestFailed, *op.Path)
+		}
+		return doc, nil
+	case "remove":
+		return remove(doc, path)
+	case "move", "copy":
+		if op.From == nil {
+			return nil, fmt.Errorf("%w: %s without from", ErrInvalidPatch, op.Op)
+		}
+		from, err := parsePointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			return add(doc, path, clone(value))
+		}
+		if len(path) > len(from) && reflect.DeepEqual(path[:len(from)], from) {
+			return nil, fmt.Errorf("%w: cannot move %s into itself", ErrUnapplicable, *op.From)
+		}
+		doc, err = remove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, op.Op)
+	}
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: pointer %q does not start with /", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func get(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: no member %q", ErrUnapplicable, token)
+			}
+			doc = value
+		case []any:
+			i, err := index(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%w: %q is not in an object or array", ErrUnapplicable, token)
+		}
+	}
+	return doc, nil
+}
+
+func add(doc any, path []string, value any) (any, error) {
+	return edit(doc, path, value, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			if token == "-" {
+				return append(node, value), nil
+			}
+			i, err := index(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("%w: %q is not in an object or array", ErrUnapplicable, token)
+		}
+	})
+}
+
+func remove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: cannot remove the whole document", ErrUnapplicable)
+	}
+	return edit(doc, path, nil, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			if _, ok := node[token]; !ok {
+				return nil, fmt.Errorf("%w: no member %q", ErrUnapplicable, token)
+			}
+			delete(node, token)
+			return node, nil
+		case []any:
+			i, err := index(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:i], node[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: %q is not in an object or array", ErrUnapplicable, token)
+		}
+	})
+}
+
+func replace(doc any, path []string, value any) (any, error) {
+	return edit(doc, path, value, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			if _, ok := node[token]; !ok {
+				return nil, fmt.Errorf("%w: no member %q", ErrUnapplicable, token)
+			}
+			node[token] = value
+			return node, nil
+		case []any:
+			i, err := index(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			node[i] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("%w: %q is not in an object or array", ErrUnapplicable, token)
+		}
+	})
+}
+
+// edit walks path down to the parent of its last token and replaces the
+// parent with what change returns for it. An empty path replaces the whole
+// document with value.
+func edit(doc any, path []string, value any, change func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	if len(path) == 1 {
+		return change(doc, path[0])
+	}
+	child, err := get(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	child, err = edit(child, path[1:], value, change)
+	if err != nil {
+		return nil, err
+	}
+	switch node := doc.(type) {
+	case map[string]any:
+		node[path[0]] = child
+	case []any:
+		i, _ := index(path[0], len(node)-1)
+		node[i] = child
+	}
+	return doc, nil
+}
+
+// index parses an array index up to max, leading zeros are not allowed
+func index(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || token != strconv.Itoa(i) {
+		return 0, fmt.Errorf("%w: %q is not an array index", ErrUnapplicable, token)
+	}
+	if i > max {
+		return 0, fmt.Errorf("%w: index %d is out of range", ErrUnapplicable, i)
+	}
+	return i, nil
+}
+
+// equal compares JSON values, numbers by their value
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, errA := a.Float64()
+		y, errB := b.Float64()
+		return errA == nil && errB == nil && x == y
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for name, value := range a {
+			other, ok := b[name]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func clone(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(value))
+		for name, member := range value {
+			res[name] = clone(member)
+		}
+		return res
+	case []any:
+		res := make([]any, len(value))
+		for i := range value {
+			res[i] = clone(value[i])
+		}
+		return res
+	default:
+		return value
+	}
+}
+
+// decode keeps numbers as written, so integers never pass through float64
+func decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	err := dec.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return value, nil
+}
@@ -0,0 +1,281 @@
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/jsonpatch"
+)
+
+// sameJSON compares documents by value, so member order does not matter
+func sameJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var g, w any
+	err := json.Unmarshal(got, &g)
+	if err != nil {
+		t.Fatalf("result %s is not JSON: %v", got, err)
+	}
+	err = json.Unmarshal([]byte(want), &w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("\n got %s\nwant %s", got, want)
+	}
+}
+
+// The examples of RFC 7386, appendix A
+func TestMerge(t *testing.T) {
+	for _, c := range []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	} {
+		got, err := jsonpatch.Merge([]byte(c.doc), []byte(c.patch))
+		if err != nil {
+			t.Fatalf("%s merged with %s: %v", c.doc, c.patch, err)
+		}
+		sameJSON(t, got, c.want)
+	}
+}
+
+func TestMergeKeepsIntegers(t *testing.T) {
+	got, err := jsonpatch.Merge([]byte(`{"id":9007199254740993}`), []byte(`{"x":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `{"id":9007199254740993,"x":1}` {
+		t.Fatalf("integer is rounded: %s", got)
+	}
+}
+
+func TestMergeInvalidPatch(t *testing.T) {
+	_, err := jsonpatch.Merge([]byte(`{}`), []byte(`{"a":`))
+	if !errors.Is(err, jsonpatch.ErrInvalidPatch) {
+		t.Fatalf("got error %v, want %v", err, jsonpatch.ErrInvalidPatch)
+	}
+}
+
+// The examples of RFC 6902, appendix A, that apply
+func TestApply(t *testing.T) {
+	for _, c := range []struct {
+		name, doc, patch, want string
+	}{
+		{
+			"add an object member",
+			`{"foo":"bar"}`,
+			`[{"op":"add","path":"/baz","value":"qux"}]`,
+			`{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			"add an array element",
+			`{"foo":["bar","baz"]}`,
+			`[{"op":"add","path":"/foo/1","value":"qux"}]`,
+			`{"foo":["bar","qux","baz"]}`,
+		},
+		{
+			"remove an object member",
+			`{"baz":"qux","foo":"bar"}`,
+			`[{"op":"remove","path":"/baz"}]`,
+			`{"foo":"bar"}`,
+		},
+		{
+			"remove an array element",
+			`{"foo":["bar","qux","baz"]}`,
+			`[{"op":"remove","path":"/foo/1"}]`,
+			`{"foo":["bar","baz"]}`,
+		},
+		{
+			"replace a value",
+			`{"baz":"qux","foo":"bar"}`,
+			`[{"op":"replace","path":"/baz","value":"boo"}]`,
+			`{"baz":"boo","foo":"bar"}`,
+		},
+		{
+			"move a value",
+			`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			`[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			"move an array element",
+			`{"foo":["all","grass","cows","eat"]}`,
+			`[{"op":"move","from":"/foo/1","path":"/foo/3"}]`,
+			`{"foo":["all","cows","eat","grass"]}`,
+		},
+		{
+			"test a value",
+			`{"baz":"qux","foo":["a",2,"c"]}`,
+			`[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			`{"baz":"qux","foo":["a",2,"c"]}`,
+		},
+		{
+			"add a nested member object",
+			`{"foo":"bar"}`,
+			`[{"op":"add","path":"/child","value":{"grandchild":{}}}]`,
+			`{"foo":"bar","child":{"grandchild":{}}}`,
+		},
+		{
+			"ignore unrecognized elements",
+			`{"foo":"bar"}`,
+			`[{"op":"add","path":"/baz","value":"qux","xyz":123}]`,
+			`{"foo":"bar","baz":"qux"}`,
+		},
+		{
+			"escape ordering",
+			`{"/":9,"~1":10}`,
+			`[{"op":"test","path":"/~01","value":10}]`,
+			`{"/":9,"~1":10}`,
+		},
+		{
+			"add an array value",
+			`{"foo":["bar"]}`,
+			`[{"op":"add","path":"/foo/-","value":["abc","def"]}]`,
+			`{"foo":["bar",["abc","def"]]}`,
+		},
+		{
+			"copy a value",
+			`{"foo":{"bar":[1]}}`,
+			`[{"op":"copy","from":"/foo","path":"/baz"},{"op":"add","path":"/baz/bar/-","value":2}]`,
+			`{"foo":{"bar":[1]},"baz":{"bar":[1,2]}}`,
+		},
+		{
+			"replace the whole document",
+			`{"foo":"bar"}`,
+			`[{"op":"replace","path":"","value":{"baz":1}}]`,
+			`{"baz":1}`,
+		},
+		{
+			"test numbers by value",
+			`{"x":1}`,
+			`[{"op":"test","path":"/x","value":1.0}]`,
+			`{"x":1}`,
+		},
+		{
+			"add after the last element",
+			`{"foo":[1,2]}`,
+			`[{"op":"add","path":"/foo/2","value":3}]`,
+			`{"foo":[1,2,3]}`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := jsonpatch.Apply([]byte(c.doc), []byte(c.patch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sameJSON(t, got, c.want)
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	for _, c := range []struct {
+		name, doc, patch string
+		want             error
+	}{
+		{"failing test", `{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, jsonpatch.ErrTestFailed},
+		{"test compares strings and numbers", `{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":"10"}]`, jsonpatch.ErrTestFailed},
+		{"test a missing member", `{"baz":"qux"}`, `[{"op":"test","path":"/foo","value":"qux"}]`, jsonpatch.ErrUnapplicable},
+		{"failing test after a change", `{"a":1}`, `[{"op":"replace","path":"/a","value":2},{"op":"test","path":"/a","value":1}]`, jsonpatch.ErrTestFailed},
+		{"add to a nonexistent target", `{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, jsonpatch.ErrUnapplicable},
+		{"remove a missing member", `{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, jsonpatch.ErrUnapplicable},
+		{"replace a missing member", `{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`, jsonpatch.ErrUnapplicable},
+		{"remove the whole document", `{"foo":"bar"}`, `[{"op":"remove","path":""}]`, jsonpatch.ErrUnapplicable},
+		{"move into itself", `{"foo":{"bar":1}}`, `[{"op":"move","from":"/foo","path":"/foo/bar/baz"}]`, jsonpatch.ErrUnapplicable},
+		{"member of a scalar", `{"foo":"bar"}`, `[{"op":"replace","path":"/foo/0","value":1}]`, jsonpatch.ErrUnapplicable},
+
+		{"pointer without a slash", `{"foo":"bar"}`, `[{"op":"replace","path":"foo","value":1}]`, jsonpatch.ErrInvalidPatch},
+		{"from without a slash", `{"foo":"bar"}`, `[{"op":"move","from":"foo","path":"/baz"}]`, jsonpatch.ErrInvalidPatch},
+		{"missing path", `{"foo":"bar"}`, `[{"op":"add","value":1}]`, jsonpatch.ErrInvalidPatch},
+		{"missing value", `{"foo":"bar"}`, `[{"op":"add","path":"/baz"}]`, jsonpatch.ErrInvalidPatch},
+		{"missing from", `{"foo":"bar"}`, `[{"op":"copy","path":"/baz"}]`, jsonpatch.ErrInvalidPatch},
+		{"unknown operation", `{"foo":"bar"}`, `[{"op":"merge","path":"/foo","value":1}]`, jsonpatch.ErrInvalidPatch},
+		{"patch is not an array", `{"foo":"bar"}`, `{"op":"add","path":"/baz","value":1}`, jsonpatch.ErrInvalidPatch},
+
+		{"index past the end", `{"foo":[1,2]}`, `[{"op":"add","path":"/foo/3","value":3}]`, jsonpatch.ErrUnapplicable},
+		{"replace past the end", `{"foo":[1,2]}`, `[{"op":"replace","path":"/foo/2","value":3}]`, jsonpatch.ErrUnapplicable},
+		{"remove past the end", `{"foo":[1,2]}`, `[{"op":"remove","path":"/foo/2"}]`, jsonpatch.ErrUnapplicable},
+		{"remove from an empty array", `{"foo":[]}`, `[{"op":"remove","path":"/foo/0"}]`, jsonpatch.ErrUnapplicable},
+		{"index with a leading zero", `{"foo":[1,2]}`, `[{"op":"replace","path":"/foo/01","value":3}]`, jsonpatch.ErrUnapplicable},
+		{"negative index", `{"foo":[1,2]}`, `[{"op":"remove","path":"/foo/-1"}]`, jsonpatch.ErrUnapplicable},
+		{"index that is not a number", `{"foo":[1,2]}`, `[{"op":"test","path":"/foo/first","value":1}]`, jsonpatch.ErrUnapplicable},
+		{"remove the end of an array", `{"foo":[1,2]}`, `[{"op":"remove","path":"/foo/-"}]`, jsonpatch.ErrUnapplicable},
+		{"replace the end of an array", `{"foo":[1,2]}`, `[{"op":"replace","path":"/foo/-","value":3}]`, jsonpatch.ErrUnapplicable},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := jsonpatch.Apply([]byte(c.doc), []byte(c.patch))
+			if !errors.Is(err, c.want) {
+				t.Fatalf("got %s and error %v, want %v", got, err, c.want)
+			}
+		})
+	}
+}
+
+func TestMergePaths(t *testing.T) {
+	for _, c := range []struct {
+		patch string
+		want  [][]string
+	}{
+		{`{"y":1,"x":null}`, [][]string{{"x"}, {"y"}}},
+		{`{"links":{"runbook":"r","repository":null}}`, [][]string{{"links", "repository"}, {"links", "runbook"}}},
+		{`{"tags":["a"],"links":{}}`, [][]string{{"tags"}}},
+		{`["a"]`, [][]string{nil}},
+		{`{}`, nil},
+	} {
+		got, err := jsonpatch.MergePaths([]byte(c.patch))
+		if err != nil {
+			t.Fatalf("%s: %v", c.patch, err)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s writes %q, want %q", c.patch, got, c.want)
+		}
+	}
+	_, err := jsonpatch.MergePaths([]byte(`{"a":`))
+	if !errors.Is(err, jsonpatch.ErrInvalidPatch) {
+		t.Fatalf("got error %v, want %v", err, jsonpatch.ErrInvalidPatch)
+	}
+}
+
+func TestPatchPaths(t *testing.T) {
+	got, err := jsonpatch.PatchPaths([]byte(`[
+		{"op":"test","path":"/name","value":"api"},
+		{"op":"replace","path":"/links/runbook","value":"r"},
+		{"op":"move","from":"/a~1b","path":"/c"},
+		{"op":"copy","from":"/d","path":"/e/0"},
+		{"op":"remove","path":""}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"links", "runbook"}, {"c"}, {"a/b"}, {"e", "0"}, nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("writes %q, want %q", got, want)
+	}
+
+	for _, patch := range []string{
+		`{"op":"add"}`,
+		`[{"op":"add","value":1}]`,
+		`[{"op":"add","path":"a","value":1}]`,
+	} {
+		_, err = jsonpatch.PatchPaths([]byte(patch))
+		if !errors.Is(err, jsonpatch.ErrInvalidPatch) {
+			t.Errorf("%s: got error %v, want %v", patch, err, jsonpatch.ErrInvalidPatch)
+		}
+	}
+}
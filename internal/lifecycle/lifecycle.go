@@ -0,0 +1,195 @@
+// Package lifecycle runs the long living parts of core and stops them in
+// order when the process is asked to terminate.
+//
+// On SIGINT or SIGTERM, or when a server fails, the manager drains the
+// servers, waits DrainDelay so load balancers see the readiness change,
+// stops the servers letting requests in flight finish, cancels the
+// background jobs and finally runs the closers in reverse order of
+// registration. All of it has to fit into Timeout.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Config of the shutdown. Timeout bounds the whole of it and has to stay
+// below the grace period of the orchestrator.
+type Config struct {
+	Timeout    time.Duration `yaml:"timeout"`
+	DrainDelay time.Duration `yaml:"drain_delay"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.Timeout == 0 {
+		c.Timeout = 25 * time.Second
+	}
+	return c
+}
+
+// Validate reports every invalid value at once, zero values stand for the
+// defaults
+func (c Config) Validate() error {
+	var errs []error
+	if c.Timeout < 0 {
+		errs = append(errs, errors.New("timeout must not be negative"))
+	}
+	if c.DrainDelay < 0 {
+		errs = append(errs, errors.New("drain_delay must not be negative"))
+	}
+	c = c.withDefaults()
+	if c.DrainDelay >= c.Timeout {
+		errs = append(errs, fmt.Errorf("drain_delay (%s) leaves no time of timeout (%s) to stop the servers", c.DrainDelay, c.Timeout))
+	}
+	return errors.Join(errs...)
+}
+
+// Server is a listener started by the manager. Start blocks until the server
+// stops, Shutdown stops accepting connections and waits for the open ones
+// until ctx is done.
+type Server interface {
+	Start() error
+	Shutdown(ctx context.Context) error
+}
+
+// Drainer is a Server that has to know when the shutdown begins, before it
+// is stopped
+type Drainer interface {
+	Drain()
+}
+
+type server struct {
+	name   string
+	server Server
+}
+
+type job struct {
+	name string
+	run  func(ctx context.Context)
+}
+
+type closer struct {
+	name  string
+	close func(ctx context.Context) error
+}
+
+type Manager struct {
+	conf    Config
+	servers []server
+	jobs    []job
+	closers []closer
+}
+
+func New(conf Config) *Manager {
+	return &Manager{
+		conf: conf.withDefaults(),
+	}
+}
+
+// Serve registers a server, servers are stopped in reverse order of
+// registration
+func (m *Manager) Serve(name string, s Server) {
+	m.servers = append(m.servers, server{name: name, server: s})
+}
+
+// Go registers a background job, its context is canceled once the servers
+// are stopped and the manager waits for it to return
+func (m *Manager) Go(name string, run func(ctx context.Context)) {
+	m.jobs = append(m.jobs, job{name: name, run: run})
+}
+
+// OnClose registers a closer run after the servers and jobs are stopped,
+// the last registered is the first to run
+func (m *Manager) OnClose(name string, close func(ctx context.Context) error) {
+	m.closers = append(m.closers, closer{name: name, close: close})
+}
+
+// Run starts everything registered and blocks until the shutdown is over.
+// It returns the error of a failed server along with the errors of the
+// shutdown itself.
+func (m *Manager) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	failed := make(chan error, len(m.servers))
+	for _, s := range m.servers {
+		go func() {
+			err := s.server.Start()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				failed <- fmt.Errorf("%s: %w", s.name, err)
+			}
+		}()
+	}
+
+	jobsCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+	var jobs sync.WaitGroup
+	for _, j := range m.jobs {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			j.run(jobsCtx)
+			slog.Info("background job stopped", "job", j.name)
+		}()
+	}
+
+	var errs []error
+	select {
+	case <-ctx.Done():
+		slog.Info("shutting down", "cause", context.Cause(ctx))
+	case err := <-failed:
+		slog.Error("shutting down", "error", err)
+		errs = append(errs, err)
+	}
+	stop()
+	for _, s := range m.servers {
+		if drainer, ok := s.server.(Drainer); ok {
+			drainer.Drain()
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), m.conf.Timeout)
+	defer cancel()
+
+	select {
+	case <-time.After(m.conf.DrainDelay):
+	case <-shutdownCtx.Done():
+	}
+
+	for i := len(m.servers) - 1; i >= 0; i-- {
+		s := m.servers[i]
+		err := s.server.Shutdown(shutdownCtx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("shutdown %s: %w", s.name, err))
+		}
+	}
+
+	cancelJobs()
+	done := make(chan struct{})
+	go func() {
+		jobs.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+		errs = append(errs, errors.New("background jobs did not stop in time"))
+	}
+
+	for i := len(m.closers) - 1; i >= 0; i-- {
+		c := m.closers[i]
+		err := c.close(shutdownCtx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", c.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,30 @@
+package lifecycle
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+type shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// FlushTraces exports the spans buffered by the global trace provider set up
+// by the tracer package and shuts it down
+func FlushTraces(ctx context.Context) error {
+	if provider, ok := otel.GetTracerProvider().(shutdowner); ok {
+		return provider.Shutdown(ctx)
+	}
+	return nil
+}
+
+// FlushLogs does the same for the global log provider of the logger package.
+// Records logged afterwards are dropped, so it goes last.
+func FlushLogs(ctx context.Context) error {
+	if provider, ok := global.GetLoggerProvider().(shutdowner); ok {
+		return provider.Shutdown(ctx)
+	}
+	return nil
+}
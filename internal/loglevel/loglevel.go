@@ -0,0 +1,51 @@
+// Package loglevel filters the records of a slog handler by a level that can
+// change while the process runs. It only drops records: the handler it wraps
+// keeps its own level.
+package loglevel
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+// initial is the default handler of the standard library, it writes through
+// the log package
+var initial = slog.Default().Handler()
+
+// SetDefault makes the default logger drop the records below level
+func SetDefault(level slog.Leveler) {
+	next := slog.Default().Handler()
+	if next == initial {
+		// The default logger takes over the log package, the initial handler
+		// writing to it would end up calling itself
+		next = slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})
+	}
+	slog.SetDefault(slog.New(Wrap(next, level)))
+}
+
+type handler struct {
+	next  slog.Handler
+	level slog.Leveler
+}
+
+// Wrap returns a handler passing to next the records at level or above
+func Wrap(next slog.Handler, level slog.Leveler) slog.Handler {
+	return handler{next: next, level: level}
+}
+
+func (h handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.next.Enabled(ctx, level)
+}
+
+func (h handler) Handle(ctx context.Context, record slog.Record) error {
+	return h.next.Handle(ctx, record)
+}
+
+func (h handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return handler{next: h.next.WithAttrs(attrs), level: h.level}
+}
+
+func (h handler) WithGroup(name string) slog.Handler {
+	return handler{next: h.next.WithGroup(name), level: h.level}
+}
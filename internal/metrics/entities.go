@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// EntityCounter counts the stored entities
+type EntityCounter interface {
+	CountEntities(ctx context.Context) (models.EntityCounts, error)
+}
+
+// Entities reports the number of stored entities of each kind, it counts them
+// in the storage on every scrape. Every replica reports the same numbers.
+type Entities struct {
+	counter EntityCounter
+	timeout time.Duration
+	desc    *prometheus.Desc
+}
+
+func NewEntities(counter EntityCounter) *Entities {
+	return &Entities{
+		counter: counter,
+		timeout: 5 * time.Second,
+		desc: prometheus.NewDesc(
+			"core_entities",
+			"Number of stored projects, graphs, services and relations.",
+			[]string{"kind"}, nil,
+		),
+	}
+}
+
+func (e *Entities) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.desc
+}
+
+func (e *Entities) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	counts, err := e.counter.CountEntities(ctx)
+	if err != nil {
+		// An invalid metric would fail the whole scrape, the other metrics
+		// matter the most while the storage is failing
+		slog.WarnContext(ctx, "failed to count entities", "error", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(e.desc, prometheus.GaugeValue, float64(counts.Projects), "projects")
+	ch <- prometheus.MustNewConstMetric(e.desc, prometheus.GaugeValue, float64(counts.Graphs), "graphs")
+	ch <- prometheus.MustNewConstMetric(e.desc, prometheus.GaugeValue, float64(counts.Services), "services")
+	ch <- prometheus.MustNewConstMetric(e.desc, prometheus.GaugeValue, float64(counts.Relations), "relations")
+}
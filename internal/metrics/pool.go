@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Pooled is a storage keeping a connection pool
+type Pooled interface {
+	Stats() sql.DBStats
+}
+
+// Pool reports the connection pool of the storage
+type Pool struct {
+	pooled Pooled
+
+	maxOpen      *prometheus.Desc
+	open         *prometheus.Desc
+	inUse        *prometheus.Desc
+	idle         *prometheus.Desc
+	waits        *prometheus.Desc
+	waitDuration *prometheus.Desc
+}
+
+func NewPool(pooled Pooled, backend string) *Pool {
+	labels := prometheus.Labels{"backend": backend}
+	desc := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc("core_storage_pool_"+name, help, nil, labels)
+	}
+	return &Pool{
+		pooled:       pooled,
+		maxOpen:      desc("max_open_connections", "Maximum number of open connections, 0 is unlimited."),
+		open:         desc("open_connections", "Open connections, in use or idle."),
+		inUse:        desc("in_use_connections", "Connections in use."),
+		idle:         desc("idle_connections", "Idle connections."),
+		waits:        desc("waits_total", "Times a query waited for a connection."),
+		waitDuration: desc("wait_duration_seconds_total", "Time queries spent waiting for a connection."),
+	}
+}
+
+func (p *Pool) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.maxOpen
+	ch <- p.open
+	ch <- p.inUse
+	ch <- p.idle
+	ch <- p.waits
+	ch <- p.waitDuration
+}
+
+func (p *Pool) Collect(ch chan<- prometheus.Metric) {
+	stats := p.pooled.Stats()
+	ch <- prometheus.MustNewConstMetric(p.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(p.open, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(p.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(p.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(p.waits, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(p.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+}
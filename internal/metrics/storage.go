@@ -0,0 +1,44 @@
+// Package metrics holds the Prometheus metrics shared by several packages of
+// core. Metrics of a single package are declared next to the code they
+// measure.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/hse-telescope/tracer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var storageDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "core",
+	Subsystem: "storage",
+	Name:      "duration_seconds",
+	Help:      "Latency of storage methods, labeled with their span name.",
+	Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 15),
+}, []string{"backend", "operation"})
+
+// StartStorageSpan starts the span of a storage method, ending the span
+// observes the duration of the method under the span name
+func StartStorageSpan(ctx context.Context, backend string, name string) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, name)
+	return ctx, timedSpan{
+		Span:     span,
+		start:    time.Now(),
+		observer: storageDuration.WithLabelValues(backend, name),
+	}
+}
+
+type timedSpan struct {
+	trace.Span
+	start    time.Time
+	observer prometheus.Observer
+}
+
+func (s timedSpan) End(options ...trace.SpanEndOption) {
+	s.observer.Observe(time.Since(s.start).Seconds())
+	s.Span.End(options...)
+}
@@ -0,0 +1,49 @@
+package catalog
+
+import "github.com/hse-telescope/core/internal/repository/models"
+
+type Service struct {
+	ID          int
+	ProjectID   int
+	Name        string
+	Description string
+	Kind        string
+	OwnerTeam   string
+	Tags        []string
+	Attributes  map[string]string
+	DocsURL     string
+	RunbookURL  string
+	RepoURL     string
+}
+
+func ProviderService2DBService(service Service) models.CatalogService {
+	return models.CatalogService{
+		ID:          service.ID,
+		ProjectID:   service.ProjectID,
+		Name:        service.Name,
+		Description: service.Description,
+		Kind:        service.Kind,
+		OwnerTeam:   service.OwnerTeam,
+		Tags:        service.Tags,
+		Attributes:  service.Attributes,
+		DocsURL:     service.DocsURL,
+		RunbookURL:  service.RunbookURL,
+		RepoURL:     service.RepoURL,
+	}
+}
+
+func DBService2ProviderService(service models.CatalogService) Service {
+	return Service{
+		ID:          service.ID,
+		ProjectID:   service.ProjectID,
+		Name:        service.Name,
+		Description: service.Description,
+		Kind:        service.Kind,
+		OwnerTeam:   service.OwnerTeam,
+		Tags:        service.Tags,
+		Attributes:  service.Attributes,
+		DocsURL:     service.DocsURL,
+		RunbookURL:  service.RunbookURL,
+		RepoURL:     service.RepoURL,
+	}
+}
@@ -0,0 +1,86 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/repository/models"
+	"github.com/hse-telescope/tracer"
+	"github.com/olegdayo/omniconv"
+)
+
+type Repository interface {
+	GetCatalogService(ctx context.Context, catalog_id int) (models.CatalogService, error)
+	GetProjectCatalog(ctx context.Context, project_id int) ([]models.CatalogService, error)
+	CreateCatalogService(ctx context.Context, service models.CatalogService) (models.CatalogService, error)
+	UpdateCatalogService(ctx context.Context, catalog_id int, service models.CatalogService) error
+	DeleteCatalogService(ctx context.Context, catalog_id int) error
+	GetCatalogServiceGraphs(ctx context.Context, catalog_id int) ([]models.Graph, error)
+}
+
+type Provider struct {
+	repository Repository
+}
+
+func New(repository Repository) Provider {
+	return Provider{
+		repository: repository,
+	}
+}
+
+func (p Provider) GetCatalogService(ctx context.Context, catalog_id int) (Service, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetCatalogService")
+	defer span.End()
+
+	service, err := p.repository.GetCatalogService(ctx, catalog_id)
+	if err != nil {
+		return Service{}, err
+	}
+	return DBService2ProviderService(service), nil
+}
+
+func (p Provider) GetProjectCatalog(ctx context.Context, project_id int) ([]Service, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetProjectCatalog")
+	defer span.End()
+
+	services, err := p.repository.GetProjectCatalog(ctx, project_id)
+	if err != nil {
+		return nil, err
+	}
+	return omniconv.ConvertSlice(services, DBService2ProviderService), nil
+}
+
+func (p Provider) CreateCatalogService(ctx context.Context, service Service) (Service, error) {
+	ctx, span := tracer.Start(ctx, "provider/CreateCatalogService")
+	defer span.End()
+
+	newservice, err := p.repository.CreateCatalogService(ctx, ProviderService2DBService(service))
+	return DBService2ProviderService(newservice), err
+}
+
+func (p Provider) UpdateCatalogService(ctx context.Context, catalog_id int, service Service) error {
+	ctx, span := tracer.Start(ctx, "provider/UpdateCatalogService")
+	defer span.End()
+
+	err := p.repository.UpdateCatalogService(ctx, catalog_id, ProviderService2DBService(service))
+	return err
+}
+
+func (p Provider) DeleteCatalogService(ctx context.Context, catalog_id int) error {
+	ctx, span := tracer.Start(ctx, "provider/DeleteCatalogService")
+	defer span.End()
+
+	err := p.repository.DeleteCatalogService(ctx, catalog_id)
+	return err
+}
+
+func (p Provider) GetCatalogServiceGraphs(ctx context.Context, catalog_id int) ([]graph.Graph, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetCatalogServiceGraphs")
+	defer span.End()
+
+	graphs, err := p.repository.GetCatalogServiceGraphs(ctx, catalog_id)
+	if err != nil {
+		return nil, err
+	}
+	return omniconv.ConvertSlice(graphs, graph.DBGraph2ProviderGraph), nil
+}
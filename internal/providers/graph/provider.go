@@ -12,7 +12,10 @@ type Repository interface {
 	CreateGraph(ctx context.Context, graph models.Graph) (models.Graph, error)
 	DeleteGraph(ctx context.Context, graph_id int) error
 	UpdateGraph(ctx context.Context, graph_id int, graph models.Graph) error
+	PatchGraph(ctx context.Context, graph_id int, patch func(models.Graph) (models.Graph, []string, error)) (models.Graph, error)
+	GetGraph(ctx context.Context, graph_id int) (models.Graph, error)
 	GetProjectGraphs(ctx context.Context, project_id int) ([]models.Graph, error)
+	GetProjectsGraphs(ctx context.Context, project_ids []int) ([]models.Graph, error)
 }
 
 type Provider struct {
@@ -49,6 +52,33 @@ func (p Provider) UpdateGraph(ctx context.Context, graph_id int, graph Graph) er
 	return err
 }
 
+// PatchGraph hands the stored graph to patch and writes the fields patch
+// returns, both in one transaction
+func (p Provider) PatchGraph(ctx context.Context, graph_id int, patch func(Graph) (Graph, []string, error)) (Graph, error) {
+	ctx, span := tracer.Start(ctx, "provider/PatchGraph")
+	defer span.End()
+
+	patched, err := p.repository.PatchGraph(ctx, graph_id, func(stored models.Graph) (models.Graph, []string, error) {
+		graph, fields, err := patch(DBGraph2ProviderGraph(stored))
+		return ProviderGraph2DBGraph(graph), fields, err
+	})
+	if err != nil {
+		return Graph{}, err
+	}
+	return DBGraph2ProviderGraph(patched), nil
+}
+
+func (p Provider) GetGraph(ctx context.Context, graph_id int) (Graph, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetGraph")
+	defer span.End()
+
+	graph, err := p.repository.GetGraph(ctx, graph_id)
+	if err != nil {
+		return Graph{}, err
+	}
+	return DBGraph2ProviderGraph(graph), nil
+}
+
 func (p Provider) GetProjectGraphs(ctx context.Context, project_id int) ([]Graph, error) {
 	ctx, span := tracer.Start(ctx, "provider/GetProjectGraphs")
 	defer span.End()
@@ -59,3 +89,14 @@ func (p Provider) GetProjectGraphs(ctx context.Context, project_id int) ([]Graph
 	}
 	return omniconv.ConvertSlice(graphs, DBGraph2ProviderGraph), nil
 }
+
+func (p Provider) GetProjectsGraphs(ctx context.Context, project_ids []int) ([]Graph, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetProjectsGraphs")
+	defer span.End()
+
+	graphs, err := p.repository.GetProjectsGraphs(ctx, project_ids)
+	if err != nil {
+		return nil, err
+	}
+	return omniconv.ConvertSlice(graphs, DBGraph2ProviderGraph), nil
+}
@@ -0,0 +1,85 @@
+package group
+
+import (
+	"fmt"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+type Group struct {
+	ID          int
+	GraphID     int
+	ParentID    *int
+	Name        string
+	Description string
+	Kind        string
+	X           float32
+	Y           float32
+	Width       float32
+	Height      float32
+}
+
+const (
+	NodeService = "service"
+	NodeGroup   = "group"
+)
+
+// Node is a vertex of a collapsed graph: either a service or a collapsed
+// group standing in for every service inside of it.
+type Node struct {
+	Key      string
+	Type     string
+	ID       int
+	Name     string
+	X        float32
+	Y        float32
+	Width    float32
+	Height   float32
+	Services int
+}
+
+// Edge aggregates every relation between two nodes of a collapsed graph
+type Edge struct {
+	From      string
+	To        string
+	Relations []int
+}
+
+type CollapsedGraph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+func NodeKey(nodeType string, id int) string {
+	return fmt.Sprintf("%s:%d", nodeType, id)
+}
+
+func ProviderGroup2DBGroup(group Group) models.Group {
+	return models.Group{
+		ID:          group.ID,
+		GraphID:     group.GraphID,
+		ParentID:    group.ParentID,
+		Name:        group.Name,
+		Description: group.Description,
+		Kind:        group.Kind,
+		X:           group.X,
+		Y:           group.Y,
+		Width:       group.Width,
+		Height:      group.Height,
+	}
+}
+
+func DBGroup2ProviderGroup(group models.Group) Group {
+	return Group{
+		ID:          group.ID,
+		GraphID:     group.GraphID,
+		ParentID:    group.ParentID,
+		Name:        group.Name,
+		Description: group.Description,
+		Kind:        group.Kind,
+		X:           group.X,
+		Y:           group.Y,
+		Width:       group.Width,
+		Height:      group.Height,
+	}
+}
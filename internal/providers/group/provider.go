@@ -0,0 +1,235 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+	"github.com/hse-telescope/tracer"
+	"github.com/olegdayo/omniconv"
+)
+
+var (
+	ErrParentGraph = errors.New("parent group belongs to another graph")
+	ErrCycle       = errors.New("group cannot be nested into itself")
+)
+
+type Repository interface {
+	GetGroup(ctx context.Context, group_id int) (models.Group, error)
+	GetGraphGroups(ctx context.Context, graph_id int) ([]models.Group, error)
+	CreateGroup(ctx context.Context, group models.Group) (models.Group, error)
+	UpdateGroup(ctx context.Context, group_id int, group models.Group) error
+	DeleteGroup(ctx context.Context, group_id int) error
+	GetGraphServices(ctx context.Context, graph_id int, filter models.ServiceFilter) ([]models.Service, error)
+	GetGraphRelations(ctx context.Context, graph_id int) ([]models.Relation, error)
+}
+
+type Provider struct {
+	repository Repository
+}
+
+func New(repository Repository) Provider {
+	return Provider{
+		repository: repository,
+	}
+}
+
+func (p Provider) GetGroup(ctx context.Context, group_id int) (Group, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetGroup")
+	defer span.End()
+
+	group, err := p.repository.GetGroup(ctx, group_id)
+	if err != nil {
+		return Group{}, err
+	}
+	return DBGroup2ProviderGroup(group), nil
+}
+
+func (p Provider) GetGraphGroups(ctx context.Context, graph_id int) ([]Group, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetGraphGroups")
+	defer span.End()
+
+	groups, err := p.repository.GetGraphGroups(ctx, graph_id)
+	if err != nil {
+		return nil, err
+	}
+	return omniconv.ConvertSlice(groups, DBGroup2ProviderGroup), nil
+}
+
+func (p Provider) CreateGroup(ctx context.Context, group Group) (Group, error) {
+	ctx, span := tracer.Start(ctx, "provider/CreateGroup")
+	defer span.End()
+
+	if group.ParentID != nil {
+		parent, err := p.repository.GetGroup(ctx, *group.ParentID)
+		if err != nil {
+			return Group{}, err
+		}
+		if parent.GraphID != group.GraphID {
+			return Group{}, ErrParentGraph
+		}
+	}
+	newgroup, err := p.repository.CreateGroup(ctx, ProviderGroup2DBGroup(group))
+	return DBGroup2ProviderGroup(newgroup), err
+}
+
+func (p Provider) UpdateGroup(ctx context.Context, group_id int, group Group) error {
+	ctx, span := tracer.Start(ctx, "provider/UpdateGroup")
+	defer span.End()
+
+	old, err := p.repository.GetGroup(ctx, group_id)
+	if err != nil {
+		return err
+	}
+	group.GraphID = old.GraphID
+
+	if group.ParentID != nil {
+		groups, err := p.repository.GetGraphGroups(ctx, group.GraphID)
+		if err != nil {
+			return err
+		}
+		parents := make(map[int]*int, len(groups))
+		for _, g := range groups {
+			parents[g.ID] = g.ParentID
+		}
+		if _, ok := parents[*group.ParentID]; !ok {
+			return ErrParentGraph
+		}
+		// Walk up from the new parent: meeting the group itself means a cycle
+		for id, steps := group.ParentID, 0; id != nil && steps <= len(groups); id, steps = parents[*id], steps+1 {
+			if *id == group_id {
+				return ErrCycle
+			}
+		}
+	}
+
+	err = p.repository.UpdateGroup(ctx, group_id, ProviderGroup2DBGroup(group))
+	return err
+}
+
+func (p Provider) DeleteGroup(ctx context.Context, group_id int) error {
+	ctx, span := tracer.Start(ctx, "provider/DeleteGroup")
+	defer span.End()
+
+	err := p.repository.DeleteGroup(ctx, group_id)
+	return err
+}
+
+// GetCollapsedGraph renders the graph with the given groups collapsed into
+// single nodes, top level groups are collapsed when none are given. Services
+// nested into several collapsed groups go to the outermost one, relations
+// between the same pair of nodes are merged and relations inside of a
+// collapsed group are dropped.
+func (p Provider) GetCollapsedGraph(ctx context.Context, graph_id int, collapse []int) (CollapsedGraph, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetCollapsedGraph")
+	defer span.End()
+
+	groups, err := p.repository.GetGraphGroups(ctx, graph_id)
+	if err != nil {
+		return CollapsedGraph{}, err
+	}
+	services, err := p.repository.GetGraphServices(ctx, graph_id, models.ServiceFilter{})
+	if err != nil {
+		return CollapsedGraph{}, err
+	}
+	relations, err := p.repository.GetGraphRelations(ctx, graph_id)
+	if err != nil {
+		return CollapsedGraph{}, err
+	}
+
+	byID := make(map[int]models.Group, len(groups))
+	for _, g := range groups {
+		byID[g.ID] = g
+	}
+	collapsed := make(map[int]bool)
+	for _, id := range collapse {
+		collapsed[id] = true
+	}
+	if len(collapse) == 0 {
+		for _, g := range groups {
+			if g.ParentID == nil {
+				collapsed[g.ID] = true
+			}
+		}
+	}
+
+	// outermost returns the outermost collapsed group containing group_id, or 0
+	outermost := func(group_id int) int {
+		res := 0
+		for id, steps := &group_id, 0; id != nil && steps <= len(groups); steps++ {
+			g, ok := byID[*id]
+			if !ok {
+				break
+			}
+			if collapsed[g.ID] {
+				res = g.ID
+			}
+			id = g.ParentID
+		}
+		return res
+	}
+
+	graph := CollapsedGraph{
+		Nodes: make([]Node, 0),
+		Edges: make([]Edge, 0),
+	}
+	groupNodes := make(map[int]int)
+	for _, g := range groups {
+		if outermost(g.ID) != g.ID {
+			continue
+		}
+		groupNodes[g.ID] = len(graph.Nodes)
+		graph.Nodes = append(graph.Nodes, Node{
+			Key:    NodeKey(NodeGroup, g.ID),
+			Type:   NodeGroup,
+			ID:     g.ID,
+			Name:   g.Name,
+			X:      g.X,
+			Y:      g.Y,
+			Width:  g.Width,
+			Height: g.Height,
+		})
+	}
+
+	nodeOf := make(map[int]string, len(services))
+	for _, serv := range services {
+		if serv.GroupID != nil {
+			if id := outermost(*serv.GroupID); id != 0 {
+				idx := groupNodes[id]
+				nodeOf[serv.ID] = graph.Nodes[idx].Key
+				graph.Nodes[idx].Services++
+				continue
+			}
+		}
+		key := NodeKey(NodeService, serv.ID)
+		nodeOf[serv.ID] = key
+		graph.Nodes = append(graph.Nodes, Node{
+			Key:  key,
+			Type: NodeService,
+			ID:   serv.ID,
+			Name: serv.Name,
+			X:    serv.X,
+			Y:    serv.Y,
+		})
+	}
+
+	edges := make(map[[2]string]int)
+	for _, rel := range relations {
+		from, to := nodeOf[rel.FromService], nodeOf[rel.ToService]
+		if from == "" || to == "" || from == to {
+			continue
+		}
+		idx, ok := edges[[2]string{from, to}]
+		if !ok {
+			idx = len(graph.Edges)
+			edges[[2]string{from, to}] = idx
+			graph.Edges = append(graph.Edges, Edge{From: from, To: to})
+		}
+		graph.Edges[idx].Relations = append(graph.Edges[idx].Relations, rel.ID)
+	}
+	for _, edge := range graph.Edges {
+		sort.Ints(edge.Relations)
+	}
+	return graph, nil
+}
@@ -0,0 +1,172 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+	"github.com/hse-telescope/tracer"
+)
+
+var (
+	// ErrKeyReused is returned when a key comes back with another request
+	ErrKeyReused = errors.New("idempotency key is reused with a different request")
+	// ErrInProgress is returned while the request holding the key runs
+	ErrInProgress = errors.New("a request with the idempotency key is in progress")
+)
+
+type Config struct {
+	// Window is how long a key and its response are kept
+	Window time.Duration `yaml:"window"`
+	// LockTimeout is how long a request in progress holds its key, a retry
+	// after that runs the request again
+	LockTimeout time.Duration `yaml:"lock_timeout"`
+	// SweepInterval is how often expired keys are deleted
+	SweepInterval time.Duration `yaml:"sweep_interval"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.Window == 0 {
+		c.Window = 24 * time.Hour
+	}
+	if c.LockTimeout == 0 {
+		c.LockTimeout = time.Minute
+	}
+	if c.SweepInterval == 0 {
+		c.SweepInterval = 10 * time.Minute
+	}
+	return c
+}
+
+// Validate reports every invalid value at once, zero values stand for the
+// defaults
+func (c Config) Validate() error {
+	var errs []error
+	for _, d := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"window", c.Window},
+		{"lock_timeout", c.LockTimeout},
+		{"sweep_interval", c.SweepInterval},
+	} {
+		if d.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", d.key))
+		}
+	}
+	c = c.withDefaults()
+	if c.LockTimeout > c.Window {
+		errs = append(errs, fmt.Errorf("lock_timeout (%s) exceeds window (%s)", c.LockTimeout, c.Window))
+	}
+	return errors.Join(errs...)
+}
+
+type Repository interface {
+	ClaimIdempotencyKey(ctx context.Context, key models.IdempotencyKey, stale time.Time) (models.IdempotencyKey, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error
+	ReleaseIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int, error)
+}
+
+// Response is what a request answered, replayed to its retries
+type Response struct {
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// Provider keeps the responses of requests by their idempotency key. A key
+// belongs to a single request, told apart by the fingerprint of the caller.
+type Provider struct {
+	repository Repository
+	conf       Config
+}
+
+func New(repository Repository, conf Config) Provider {
+	return Provider{
+		repository: repository,
+		conf:       conf.withDefaults(),
+	}
+}
+
+// Begin claims the key for the request. It returns the response stored for
+// the key, nil when the caller has to run the request and then Complete or
+// Release the key.
+func (p Provider) Begin(ctx context.Context, key string, fingerprint string) (*Response, error) {
+	ctx, span := tracer.Start(ctx, "provider/BeginIdempotentRequest")
+	defer span.End()
+
+	now := time.Now()
+	held, claimed, err := p.repository.ClaimIdempotencyKey(ctx, models.IdempotencyKey{
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(p.conf.Window),
+	}, now.Add(-p.conf.LockTimeout))
+	switch {
+	case err != nil:
+		return nil, err
+	case claimed:
+		return nil, nil
+	case held.Fingerprint != fingerprint:
+		return nil, ErrKeyReused
+	case held.Status == 0:
+		return nil, ErrInProgress
+	}
+	return &Response{
+		Status:      held.Status,
+		ContentType: held.ContentType,
+		Body:        held.Body,
+	}, nil
+}
+
+// Complete stores the response of the request holding the key
+func (p Provider) Complete(ctx context.Context, key string, fingerprint string, res Response) error {
+	ctx, span := tracer.Start(ctx, "provider/CompleteIdempotentRequest")
+	defer span.End()
+
+	return p.repository.CompleteIdempotencyKey(ctx, models.IdempotencyKey{
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      res.Status,
+		ContentType: res.ContentType,
+		Body:        res.Body,
+	})
+}
+
+// Release gives the key up without a response, a retry runs the request
+// again
+func (p Provider) Release(ctx context.Context, key string, fingerprint string) error {
+	ctx, span := tracer.Start(ctx, "provider/ReleaseIdempotentRequest")
+	defer span.End()
+
+	return p.repository.ReleaseIdempotencyKey(ctx, models.IdempotencyKey{
+		Key:         key,
+		Fingerprint: fingerprint,
+	})
+}
+
+// Run deletes the expired keys every SweepInterval until ctx is cancelled
+func (p Provider) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.conf.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		deleted, err := p.repository.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to delete expired idempotency keys", "error", err)
+			continue
+		}
+		if deleted > 0 {
+			slog.DebugContext(ctx, "deleted expired idempotency keys", "count", deleted)
+		}
+	}
+}
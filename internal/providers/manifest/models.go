@@ -0,0 +1,128 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+var (
+	// ErrInvalidManifest is returned for manifests that cannot describe a
+	// project: duplicate names, dangling relations or unknown enum values.
+	ErrInvalidManifest = errors.New("invalid manifest")
+	// ErrAmbiguousKey is returned when existing graphs, services or
+	// relations share the key a manifest matches them by.
+	ErrAmbiguousKey = errors.New("ambiguous key")
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+const (
+	TypeGraph    = "graph"
+	TypeService  = "service"
+	TypeRelation = "relation"
+)
+
+// Manifest is the declared state of a project. Graphs are matched by name,
+// services by name within a graph and relations by name and endpoints.
+type Manifest struct {
+	Graphs []Graph
+}
+
+type Graph struct {
+	Name      string
+	Services  []Service
+	Relations []Relation
+}
+
+type Service struct {
+	Name        string
+	Description string
+	X           float32
+	Y           float32
+	Kind        string
+	OwnerTeam   string
+	Tags        []string
+	Attributes  map[string]string
+	DocsURL     string
+	RunbookURL  string
+	RepoURL     string
+}
+
+type Relation struct {
+	Name              string
+	Description       string
+	From              string
+	To                string
+	Protocol          string
+	Async             bool
+	Bidirectional     bool
+	Criticality       string
+	ExpectedRPS       float64
+	ExpectedLatencyMS float64
+}
+
+// Change is a single step of a plan. Key names the entity within its graph,
+// Fields lists the fields an update changes.
+type Change struct {
+	Action string
+	Type   string
+	Graph  string
+	Key    string
+	Fields []string
+}
+
+type Plan struct {
+	Changes []Change
+}
+
+// relationKey identifies a relation within a graph
+type relationKey struct {
+	Name string
+	From string
+	To   string
+}
+
+func (k relationKey) String() string {
+	return fmt.Sprintf("%s (%s -> %s)", k.Name, k.From, k.To)
+}
+
+func (r Relation) key() relationKey {
+	return relationKey{Name: r.Name, From: r.From, To: r.To}
+}
+
+func Service2DBService(service Service) models.Service {
+	return models.Service{
+		Name:        service.Name,
+		Description: service.Description,
+		X:           service.X,
+		Y:           service.Y,
+		Kind:        service.Kind,
+		OwnerTeam:   service.OwnerTeam,
+		Tags:        service.Tags,
+		Attributes:  service.Attributes,
+		DocsURL:     service.DocsURL,
+		RunbookURL:  service.RunbookURL,
+		RepoURL:     service.RepoURL,
+	}
+}
+
+// Relation2DBRelation leaves the graph and the endpoints to be resolved by
+// name when the plan is applied
+func Relation2DBRelation(relation Relation) models.Relation {
+	return models.Relation{
+		Name:              relation.Name,
+		Description:       relation.Description,
+		Protocol:          relation.Protocol,
+		Async:             relation.Async,
+		Bidirectional:     relation.Bidirectional,
+		Criticality:       relation.Criticality,
+		ExpectedRPS:       relation.ExpectedRPS,
+		ExpectedLatencyMS: relation.ExpectedLatencyMS,
+	}
+}
@@ -0,0 +1,337 @@
+package manifest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hse-telescope/core/internal/providers/relation"
+	"github.com/hse-telescope/core/internal/providers/service"
+	"github.com/hse-telescope/core/internal/repository/models"
+	"github.com/hse-telescope/tracer"
+)
+
+type Repository interface {
+	GetProjectGraphs(ctx context.Context, project_id int) ([]models.Graph, error)
+	GetGraphsServices(ctx context.Context, graph_ids []int) ([]models.Service, error)
+	GetServicesRelations(ctx context.Context, service_ids []int) ([]models.Relation, error)
+	ApplyProjectPlan(ctx context.Context, project_id int, plan func(ctx context.Context) (models.ProjectPlan, error)) (models.ProjectPlan, error)
+}
+
+type Provider struct {
+	repository Repository
+}
+
+func New(repository Repository) Provider {
+	return Provider{
+		repository: repository,
+	}
+}
+
+// Plan lists the changes Apply would make to the project
+func (p Provider) Plan(ctx context.Context, project_id int, manifest Manifest) (Plan, error) {
+	ctx, span := tracer.Start(ctx, "provider/Plan")
+	defer span.End()
+
+	plan, _, err := p.plan(ctx, project_id, manifest)
+	return plan, err
+}
+
+// Apply brings the project to the state of the manifest in one transaction.
+// Graphs, services and relations missing from the manifest are deleted. The
+// plan is made in the transaction holding the lock of the project, so
+// concurrent applies do not plan against the same state.
+func (p Provider) Apply(ctx context.Context, project_id int, manifest Manifest) (Plan, error) {
+	ctx, span := tracer.Start(ctx, "provider/Apply")
+	defer span.End()
+
+	err := manifest.validate()
+	if err != nil {
+		return Plan{}, err
+	}
+	var plan Plan
+	_, err = p.repository.ApplyProjectPlan(ctx, project_id, func(ctx context.Context) (models.ProjectPlan, error) {
+		var dbplan models.ProjectPlan
+		var err error
+		plan, dbplan, err = p.plan(ctx, project_id, manifest)
+		return dbplan, err
+	})
+	if err != nil {
+		return Plan{}, err
+	}
+	return plan, nil
+}
+
+// state is the current content of a project keyed the way manifests are
+type state struct {
+	graphs    map[string]models.Graph
+	services  map[int]map[string]models.Service
+	relations map[int]map[relationKey]models.Relation
+}
+
+func (p Provider) state(ctx context.Context, project_id int) (state, error) {
+	graphs, err := p.repository.GetProjectGraphs(ctx, project_id)
+	if err != nil {
+		return state{}, err
+	}
+	res := state{
+		graphs:    make(map[string]models.Graph, len(graphs)),
+		services:  make(map[int]map[string]models.Service, len(graphs)),
+		relations: make(map[int]map[relationKey]models.Relation, len(graphs)),
+	}
+	graph_ids := make([]int, 0, len(graphs))
+	for _, graph := range graphs {
+		if _, ok := res.graphs[graph.Name]; ok {
+			return state{}, fmt.Errorf("%w: there are several graphs named %q", ErrAmbiguousKey, graph.Name)
+		}
+		res.graphs[graph.Name] = graph
+		res.services[graph.ID] = make(map[string]models.Service)
+		res.relations[graph.ID] = make(map[relationKey]models.Relation)
+		graph_ids = append(graph_ids, graph.ID)
+	}
+
+	services, err := p.repository.GetGraphsServices(ctx, graph_ids)
+	if err != nil {
+		return state{}, err
+	}
+	names := make(map[int]string, len(services))
+	service_ids := make([]int, 0, len(services))
+	for _, serv := range services {
+		if _, ok := res.services[serv.GraphID][serv.Name]; ok {
+			return state{}, fmt.Errorf("%w: there are several services named %q in graph %d", ErrAmbiguousKey, serv.Name, serv.GraphID)
+		}
+		res.services[serv.GraphID][serv.Name] = serv
+		names[serv.ID] = serv.Name
+		service_ids = append(service_ids, serv.ID)
+	}
+
+	relations, err := p.repository.GetServicesRelations(ctx, service_ids)
+	if err != nil {
+		return state{}, err
+	}
+	for _, rel := range relations {
+		key := relationKey{Name: rel.Name, From: names[rel.FromService], To: names[rel.ToService]}
+		if _, ok := res.relations[rel.GraphID][key]; ok {
+			return state{}, fmt.Errorf("%w: there are several relations %s in graph %d", ErrAmbiguousKey, key, rel.GraphID)
+		}
+		res.relations[rel.GraphID][key] = rel
+	}
+	return res, nil
+}
+
+func (p Provider) plan(ctx context.Context, project_id int, manifest Manifest) (Plan, models.ProjectPlan, error) {
+	err := manifest.validate()
+	if err != nil {
+		return Plan{}, models.ProjectPlan{}, err
+	}
+	current, err := p.state(ctx, project_id)
+	if err != nil {
+		return Plan{}, models.ProjectPlan{}, err
+	}
+
+	plan := Plan{Changes: make([]Change, 0)}
+	dbplan := models.ProjectPlan{}
+	declared := make(map[string]bool, len(manifest.Graphs))
+	for _, graph := range manifest.Graphs {
+		declared[graph.Name] = true
+		old, ok := current.graphs[graph.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Action: ActionCreate, Type: TypeGraph, Graph: graph.Name, Key: graph.Name})
+			dbplan.CreateGraphs = append(dbplan.CreateGraphs, models.Graph{ProjectID: project_id, Name: graph.Name})
+		}
+		planGraph(&plan, &dbplan, graph, current.services[old.ID], current.relations[old.ID])
+	}
+
+	// Services and relations of deleted graphs go away together with them
+	for _, name := range slices.Sorted(maps.Keys(current.graphs)) {
+		if declared[name] {
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{Action: ActionDelete, Type: TypeGraph, Graph: name, Key: name})
+		dbplan.DeleteGraphs = append(dbplan.DeleteGraphs, current.graphs[name])
+	}
+	return plan, dbplan, nil
+}
+
+// planGraph adds the changes of a declared graph. services and relations are
+// the current ones, they are empty for graphs the plan creates.
+func planGraph(plan *Plan, dbplan *models.ProjectPlan, graph Graph, services map[string]models.Service, relations map[relationKey]models.Relation) {
+	declared := make(map[string]bool, len(graph.Services))
+	for _, serv := range graph.Services {
+		declared[serv.Name] = true
+		old, ok := services[serv.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Action: ActionCreate, Type: TypeService, Graph: graph.Name, Key: serv.Name})
+			dbplan.CreateServices = append(dbplan.CreateServices, models.PlannedService{
+				GraphName: graph.Name,
+				Service:   Service2DBService(serv),
+			})
+			continue
+		}
+		updated, fields := updateService(old, serv)
+		if len(fields) > 0 {
+			plan.Changes = append(plan.Changes, Change{Action: ActionUpdate, Type: TypeService, Graph: graph.Name, Key: serv.Name, Fields: fields})
+			dbplan.UpdateServices = append(dbplan.UpdateServices, updated)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(services)) {
+		if declared[name] {
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{Action: ActionDelete, Type: TypeService, Graph: graph.Name, Key: name})
+		dbplan.DeleteServices = append(dbplan.DeleteServices, services[name])
+	}
+
+	declaredRelations := make(map[relationKey]bool, len(graph.Relations))
+	for _, rel := range graph.Relations {
+		declaredRelations[rel.key()] = true
+		old, ok := relations[rel.key()]
+		planned := models.PlannedRelation{
+			GraphName: graph.Name,
+			From:      rel.From,
+			To:        rel.To,
+			Relation:  Relation2DBRelation(rel),
+		}
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Action: ActionCreate, Type: TypeRelation, Graph: graph.Name, Key: rel.key().String()})
+			dbplan.CreateRelations = append(dbplan.CreateRelations, planned)
+			continue
+		}
+		fields := relationFields(old, planned.Relation)
+		if len(fields) > 0 {
+			planned.Relation.ID = old.ID
+			plan.Changes = append(plan.Changes, Change{Action: ActionUpdate, Type: TypeRelation, Graph: graph.Name, Key: rel.key().String(), Fields: fields})
+			dbplan.UpdateRelations = append(dbplan.UpdateRelations, planned)
+		}
+	}
+	keys := slices.SortedFunc(maps.Keys(relations), func(a, b relationKey) int {
+		return cmp.Compare(a.String(), b.String())
+	})
+	for _, key := range keys {
+		// Relations of deleted services go away together with them
+		if declaredRelations[key] || !declared[key.From] || !declared[key.To] {
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{Action: ActionDelete, Type: TypeRelation, Graph: graph.Name, Key: key.String()})
+		dbplan.DeleteRelations = append(dbplan.DeleteRelations, relations[key])
+	}
+}
+
+// updateService returns old with the declared fields applied and the names
+// of the fields that changed. Services linked to the catalog share
+// everything but their position with the catalog entry, so only the
+// position is taken from the manifest.
+func updateService(old models.Service, serv Service) (models.Service, []string) {
+	fields := make([]string, 0)
+	updated := old
+	if old.X != serv.X || old.Y != serv.Y {
+		fields = append(fields, "position")
+		updated.X, updated.Y = serv.X, serv.Y
+	}
+	if old.CatalogID != nil {
+		return updated, fields
+	}
+
+	declared := Service2DBService(serv)
+	if old.Description != declared.Description {
+		fields = append(fields, "description")
+	}
+	if old.Kind != declared.Kind {
+		fields = append(fields, "kind")
+	}
+	if old.OwnerTeam != declared.OwnerTeam {
+		fields = append(fields, "owner_team")
+	}
+	if !slices.Equal(old.Tags, declared.Tags) {
+		fields = append(fields, "tags")
+	}
+	if !maps.Equal(old.Attributes, declared.Attributes) {
+		fields = append(fields, "attributes")
+	}
+	if old.DocsURL != declared.DocsURL || old.RunbookURL != declared.RunbookURL || old.RepoURL != declared.RepoURL {
+		fields = append(fields, "links")
+	}
+	declared.ID = old.ID
+	declared.GraphID = old.GraphID
+	declared.GroupID = old.GroupID
+	declared.X, declared.Y = updated.X, updated.Y
+	return declared, fields
+}
+
+func relationFields(old models.Relation, rel models.Relation) []string {
+	fields := make([]string, 0)
+	if old.Description != rel.Description {
+		fields = append(fields, "description")
+	}
+	if old.Protocol != rel.Protocol {
+		fields = append(fields, "protocol")
+	}
+	if old.Async != rel.Async {
+		fields = append(fields, "async")
+	}
+	if old.Bidirectional != rel.Bidirectional {
+		fields = append(fields, "bidirectional")
+	}
+	if old.Criticality != rel.Criticality {
+		fields = append(fields, "criticality")
+	}
+	if old.ExpectedRPS != rel.ExpectedRPS {
+		fields = append(fields, "expected_rps")
+	}
+	if old.ExpectedLatencyMS != rel.ExpectedLatencyMS {
+		fields = append(fields, "expected_latency_ms")
+	}
+	return fields
+}
+
+// validate checks that names are unique, relations reference services of
+// their graph and enum values are known
+func (m Manifest) validate() error {
+	graphs := make(map[string]bool, len(m.Graphs))
+	for _, graph := range m.Graphs {
+		if graph.Name == "" {
+			return fmt.Errorf("%w: graph name must not be empty", ErrInvalidManifest)
+		}
+		if graphs[graph.Name] {
+			return fmt.Errorf("%w: graph %q is declared twice", ErrInvalidManifest, graph.Name)
+		}
+		graphs[graph.Name] = true
+
+		services := make(map[string]bool, len(graph.Services))
+		for _, serv := range graph.Services {
+			if serv.Name == "" {
+				return fmt.Errorf("%w: service name in graph %q must not be empty", ErrInvalidManifest, graph.Name)
+			}
+			if services[serv.Name] {
+				return fmt.Errorf("%w: service %q is declared twice in graph %q", ErrInvalidManifest, serv.Name, graph.Name)
+			}
+			if !service.ValidKind(serv.Kind) {
+				return fmt.Errorf("%w: unknown service kind %q", ErrInvalidManifest, serv.Kind)
+			}
+			services[serv.Name] = true
+		}
+
+		relations := make(map[relationKey]bool, len(graph.Relations))
+		for _, rel := range graph.Relations {
+			if !services[rel.From] || !services[rel.To] {
+				return fmt.Errorf("%w: relation %s references unknown service in graph %q", ErrInvalidManifest, rel.key(), graph.Name)
+			}
+			if relations[rel.key()] {
+				return fmt.Errorf("%w: relation %s is declared twice in graph %q", ErrInvalidManifest, rel.key(), graph.Name)
+			}
+			if !relation.ValidProtocol(rel.Protocol) {
+				return fmt.Errorf("%w: unknown relation protocol %q", ErrInvalidManifest, rel.Protocol)
+			}
+			if !relation.ValidCriticality(rel.Criticality) {
+				return fmt.Errorf("%w: unknown relation criticality %q", ErrInvalidManifest, rel.Criticality)
+			}
+			if rel.ExpectedRPS < 0 || rel.ExpectedLatencyMS < 0 {
+				return fmt.Errorf("%w: expected rps and latency must not be negative", ErrInvalidManifest)
+			}
+			relations[rel.key()] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package manifest_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/providers/manifest"
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/memory"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func TestConcurrentAppliesPlanAgainstEachOther(t *testing.T) {
+	ctx := context.Background()
+	f := facade.New(memory.New(), events.NewBroker(), nil)
+	project, err := f.CreateProject(ctx, models.Project{Name: "manifest"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := manifest.New(f)
+	declared := manifest.Manifest{Graphs: []manifest.Graph{{
+		Name:      "prod",
+		Services:  []manifest.Service{{Name: "api"}, {Name: "db"}},
+		Relations: []manifest.Relation{{From: "api", To: "db"}},
+	}}}
+
+	const applies = 8
+	plans := make([]manifest.Plan, applies)
+	var wg sync.WaitGroup
+	for i := range applies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			plan, err := p.Apply(ctx, project.ID, declared)
+			if err != nil {
+				t.Error(err)
+			}
+			plans[i] = plan
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for _, plan := range plans {
+		if len(plan.Changes) > 0 {
+			created++
+		}
+	}
+	if created != 1 {
+		t.Fatalf("%d applies planned changes, want 1: %+v", created, plans)
+	}
+	graphs, err := f.GetProjectGraphs(ctx, project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graphs) != 1 {
+		t.Fatalf("manifest graph is created %d times", len(graphs))
+	}
+	services, err := f.GetGraphServices(ctx, graphs[0].ID, models.ServiceFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 2 {
+		t.Fatalf("unexpected services: %+v", services)
+	}
+}
@@ -10,8 +10,10 @@ import (
 
 type Repository interface {
 	GetProjects(ctx context.Context) ([]models.Project, error)
+	GetProject(ctx context.Context, project_id int) (models.Project, error)
 	CreateProject(ctx context.Context, project models.Project) (models.Project, error)
 	UpdateProject(ctx context.Context, project_id int, project models.Project) error
+	PatchProject(ctx context.Context, project_id int, patch func(models.Project) (models.Project, []string, error)) (models.Project, error)
 	DeleteProject(ctx context.Context, project_id int) error
 }
 
@@ -36,6 +38,17 @@ func (p Provider) GetProjects(ctx context.Context) ([]Project, error) {
 	return omniconv.ConvertSlice(projects, DBProject2ProviderProject), nil
 }
 
+func (p Provider) GetProject(ctx context.Context, project_id int) (Project, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetProject")
+	defer span.End()
+
+	project, err := p.repository.GetProject(ctx, project_id)
+	if err != nil {
+		return Project{}, err
+	}
+	return DBProject2ProviderProject(project), nil
+}
+
 func (p Provider) CreateProject(ctx context.Context, project Project) (Project, error) {
 	ctx, span := tracer.Start(ctx, "provider/CreateProject")
 	defer span.End()
@@ -52,6 +65,22 @@ func (p Provider) UpdateProject(ctx context.Context, project_id int, project Pro
 	return err
 }
 
+// PatchProject hands the stored project to patch and writes the fields patch
+// returns, both in one transaction
+func (p Provider) PatchProject(ctx context.Context, project_id int, patch func(Project) (Project, []string, error)) (Project, error) {
+	ctx, span := tracer.Start(ctx, "provider/PatchProject")
+	defer span.End()
+
+	patched, err := p.repository.PatchProject(ctx, project_id, func(stored models.Project) (models.Project, []string, error) {
+		project, fields, err := patch(DBProject2ProviderProject(stored))
+		return ProviderProject2DBProject(project), fields, err
+	})
+	if err != nil {
+		return Project{}, err
+	}
+	return DBProject2ProviderProject(patched), nil
+}
+
 func (p Provider) DeleteProject(ctx context.Context, project_id int) error {
 	ctx, span := tracer.Start(ctx, "provider/DeleteProject")
 	defer span.End()
@@ -2,33 +2,83 @@ package relation
 
 import "github.com/hse-telescope/core/internal/repository/models"
 
+const (
+	ProtocolHTTP  = "http"
+	ProtocolGRPC  = "grpc"
+	ProtocolKafka = "kafka"
+	ProtocolSQL   = "sql"
+)
+
+const (
+	CriticalityLow      = "low"
+	CriticalityMedium   = "medium"
+	CriticalityHigh     = "high"
+	CriticalityCritical = "critical"
+)
+
+// ValidProtocol reports whether protocol is known, empty means unspecified
+func ValidProtocol(protocol string) bool {
+	switch protocol {
+	case "", ProtocolHTTP, ProtocolGRPC, ProtocolKafka, ProtocolSQL:
+		return true
+	}
+	return false
+}
+
+// ValidCriticality reports whether criticality is known, empty means unspecified
+func ValidCriticality(criticality string) bool {
+	switch criticality {
+	case "", CriticalityLow, CriticalityMedium, CriticalityHigh, CriticalityCritical:
+		return true
+	}
+	return false
+}
+
 type Relation struct {
-	ID          int
-	GraphID     int
-	Name        string
-	Description string
-	FromService int
-	ToService   int
+	ID                int
+	GraphID           int
+	Name              string
+	Description       string
+	FromService       int
+	ToService         int
+	Protocol          string
+	Async             bool
+	Bidirectional     bool
+	Criticality       string
+	ExpectedRPS       float64
+	ExpectedLatencyMS float64
 }
 
 func ProviderRelation2DBRelation(relation Relation) models.Relation {
 	return models.Relation{
-		ID:          relation.ID,
-		GraphID:     relation.GraphID,
-		Name:        relation.Name,
-		Description: relation.Description,
-		FromService: relation.FromService,
-		ToService:   relation.ToService,
+		ID:                relation.ID,
+		GraphID:           relation.GraphID,
+		Name:              relation.Name,
+		Description:       relation.Description,
+		FromService:       relation.FromService,
+		ToService:         relation.ToService,
+		Protocol:          relation.Protocol,
+		Async:             relation.Async,
+		Bidirectional:     relation.Bidirectional,
+		Criticality:       relation.Criticality,
+		ExpectedRPS:       relation.ExpectedRPS,
+		ExpectedLatencyMS: relation.ExpectedLatencyMS,
 	}
 }
 
 func DBRelation2ProviderRelation(relation models.Relation) Relation {
 	return Relation{
-		ID:          relation.ID,
-		GraphID:     relation.GraphID,
-		Name:        relation.Name,
-		Description: relation.Description,
-		FromService: relation.FromService,
-		ToService:   relation.ToService,
+		ID:                relation.ID,
+		GraphID:           relation.GraphID,
+		Name:              relation.Name,
+		Description:       relation.Description,
+		FromService:       relation.FromService,
+		ToService:         relation.ToService,
+		Protocol:          relation.Protocol,
+		Async:             relation.Async,
+		Bidirectional:     relation.Bidirectional,
+		Criticality:       relation.Criticality,
+		ExpectedRPS:       relation.ExpectedRPS,
+		ExpectedLatencyMS: relation.ExpectedLatencyMS,
 	}
 }
@@ -11,9 +11,11 @@ import (
 type Repository interface {
 	GetRelation(ctx context.Context, relation_id int) (models.Relation, error)
 	GetGraphRelations(ctx context.Context, graph_id int) ([]models.Relation, error)
+	GetServicesRelations(ctx context.Context, service_ids []int) ([]models.Relation, error)
 	CreateRelation(ctx context.Context, relation models.Relation) (models.Relation, error)
 	CreateRelations(ctx context.Context, graph_id int, relations []models.Relation) error
 	UpdateRelation(ctx context.Context, relation_id int, relation models.Relation) error
+	PatchRelation(ctx context.Context, relation_id int, patch func(models.Relation) (models.Relation, []string, error)) (models.Relation, error)
 	UpdateGraphRelations(ctx context.Context, graph_id int, relations []models.Relation) error
 	DeleteRelation(ctx context.Context, relation_id int) error
 }
@@ -50,6 +52,17 @@ func (p Provider) GetGraphRelations(ctx context.Context, graph_id int) ([]Relati
 	return omniconv.ConvertSlice(relations, DBRelation2ProviderRelation), nil
 }
 
+func (p Provider) GetServicesRelations(ctx context.Context, service_ids []int) ([]Relation, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetServicesRelations")
+	defer span.End()
+
+	relations, err := p.repository.GetServicesRelations(ctx, service_ids)
+	if err != nil {
+		return nil, err
+	}
+	return omniconv.ConvertSlice(relations, DBRelation2ProviderRelation), nil
+}
+
 func (p Provider) CreateRelation(ctx context.Context, relation Relation) (Relation, error) {
 	ctx, span := tracer.Start(ctx, "provider/CreateRelation")
 	defer span.End()
@@ -74,6 +87,22 @@ func (p Provider) UpdateRelation(ctx context.Context, relation_id int, relation
 	return err
 }
 
+// PatchRelation hands the stored relation to patch and writes the fields patch
+// returns, both in one transaction
+func (p Provider) PatchRelation(ctx context.Context, relation_id int, patch func(Relation) (Relation, []string, error)) (Relation, error) {
+	ctx, span := tracer.Start(ctx, "provider/PatchRelation")
+	defer span.End()
+
+	patched, err := p.repository.PatchRelation(ctx, relation_id, func(stored models.Relation) (models.Relation, []string, error) {
+		relation, fields, err := patch(DBRelation2ProviderRelation(stored))
+		return ProviderRelation2DBRelation(relation), fields, err
+	})
+	if err != nil {
+		return Relation{}, err
+	}
+	return DBRelation2ProviderRelation(patched), nil
+}
+
 func (p Provider) UpdateGraphRelations(ctx context.Context, graph_id int, relations []Relation) error {
 	ctx, span := tracer.Start(ctx, "provider/UpdateGraphRelations")
 	defer span.End()
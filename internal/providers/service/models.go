@@ -4,6 +4,29 @@ import (
 	"github.com/hse-telescope/core/internal/repository/models"
 )
 
+// ErrForeignCatalogService is returned when a service references a catalog
+// entry of another project.
+var ErrForeignCatalogService = models.ErrForeignCatalogService
+
+const (
+	KindAPI      = "api"
+	KindDatabase = "database"
+	KindQueue    = "queue"
+	KindCache    = "cache"
+	KindExternal = "external"
+	KindFrontend = "frontend"
+)
+
+// ValidKind reports whether kind is one of the known service kinds. An empty
+// kind is allowed and means the kind is not specified.
+func ValidKind(kind string) bool {
+	switch kind {
+	case "", KindAPI, KindDatabase, KindQueue, KindCache, KindExternal, KindFrontend:
+		return true
+	}
+	return false
+}
+
 type Service struct {
 	ID          int
 	GraphID     int
@@ -11,6 +34,22 @@ type Service struct {
 	Description string
 	X           float32
 	Y           float32
+	GroupID     *int
+	CatalogID   *int
+	Kind        string
+	OwnerTeam   string
+	Tags        []string
+	Attributes  map[string]string
+	DocsURL     string
+	RunbookURL  string
+	RepoURL     string
+}
+
+type Filter struct {
+	Kind       string
+	OwnerTeam  string
+	Tags       []string
+	Attributes map[string]string
 }
 
 func ProviderService2DBService(service Service) models.Service {
@@ -21,6 +60,15 @@ func ProviderService2DBService(service Service) models.Service {
 		Description: service.Description,
 		X:           service.X,
 		Y:           service.Y,
+		GroupID:     service.GroupID,
+		CatalogID:   service.CatalogID,
+		Kind:        service.Kind,
+		OwnerTeam:   service.OwnerTeam,
+		Tags:        service.Tags,
+		Attributes:  service.Attributes,
+		DocsURL:     service.DocsURL,
+		RunbookURL:  service.RunbookURL,
+		RepoURL:     service.RepoURL,
 	}
 }
 
@@ -32,5 +80,23 @@ func DBService2ProviderService(service models.Service) Service {
 		Description: service.Description,
 		X:           service.X,
 		Y:           service.Y,
+		GroupID:     service.GroupID,
+		CatalogID:   service.CatalogID,
+		Kind:        service.Kind,
+		OwnerTeam:   service.OwnerTeam,
+		Tags:        service.Tags,
+		Attributes:  service.Attributes,
+		DocsURL:     service.DocsURL,
+		RunbookURL:  service.RunbookURL,
+		RepoURL:     service.RepoURL,
+	}
+}
+
+func ProviderFilter2DBFilter(filter Filter) models.ServiceFilter {
+	return models.ServiceFilter{
+		Kind:       filter.Kind,
+		OwnerTeam:  filter.OwnerTeam,
+		Tags:       filter.Tags,
+		Attributes: filter.Attributes,
 	}
 }
@@ -10,10 +10,12 @@ import (
 
 type Repository interface {
 	GetService(ctx context.Context, service_id int) (models.Service, error)
-	GetGraphServices(ctx context.Context, graph_id int) ([]models.Service, error)
+	GetGraphServices(ctx context.Context, graph_id int, filter models.ServiceFilter) ([]models.Service, error)
+	GetGraphsServices(ctx context.Context, graph_ids []int) ([]models.Service, error)
 	CreateService(ctx context.Context, service models.Service) (models.Service, error)
 	CreateServices(ctx context.Context, graph_id int, services []models.Service) ([]int, error)
 	UpdateService(ctx context.Context, service_id int, service models.Service) error
+	PatchService(ctx context.Context, service_id int, patch func(models.Service) (models.Service, []string, error)) (models.Service, error)
 	UpdateGraphServices(ctx context.Context, graph_id int, services []models.Service) error
 	DeleteService(ctx context.Context, service_id int) error
 }
@@ -39,11 +41,22 @@ func (p Provider) GetService(ctx context.Context, service_id int) (Service, erro
 	return DBService2ProviderService(service), nil
 }
 
-func (p Provider) GetGraphServices(ctx context.Context, graph_id int) ([]Service, error) {
+func (p Provider) GetGraphServices(ctx context.Context, graph_id int, filter Filter) ([]Service, error) {
 	ctx, span := tracer.Start(ctx, "provider/GetGraphServices")
 	defer span.End()
 
-	services, err := p.repository.GetGraphServices(ctx, graph_id)
+	services, err := p.repository.GetGraphServices(ctx, graph_id, ProviderFilter2DBFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+	return omniconv.ConvertSlice(services, DBService2ProviderService), nil
+}
+
+func (p Provider) GetGraphsServices(ctx context.Context, graph_ids []int) ([]Service, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetGraphsServices")
+	defer span.End()
+
+	services, err := p.repository.GetGraphsServices(ctx, graph_ids)
 	if err != nil {
 		return nil, err
 	}
@@ -74,6 +87,22 @@ func (p Provider) UpdateService(ctx context.Context, service_id int, service Ser
 	return err
 }
 
+// PatchService hands the stored service to patch and writes the fields patch
+// returns, both in one transaction
+func (p Provider) PatchService(ctx context.Context, service_id int, patch func(Service) (Service, []string, error)) (Service, error) {
+	ctx, span := tracer.Start(ctx, "provider/PatchService")
+	defer span.End()
+
+	patched, err := p.repository.PatchService(ctx, service_id, func(stored models.Service) (models.Service, []string, error) {
+		service, fields, err := patch(DBService2ProviderService(stored))
+		return ProviderService2DBService(service), fields, err
+	})
+	if err != nil {
+		return Service{}, err
+	}
+	return DBService2ProviderService(patched), nil
+}
+
 func (p Provider) UpdateGraphServices(ctx context.Context, graph_id int, services []Service) error {
 	ctx, span := tracer.Start(ctx, "provider/UpdateGraphServices")
 	defer span.End()
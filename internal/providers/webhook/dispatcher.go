@@ -0,0 +1,206 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	webhookclient "github.com/hse-telescope/core/internal/clients/webhook"
+	"github.com/hse-telescope/core/internal/repository/models"
+	"github.com/hse-telescope/tracer"
+)
+
+type Config struct {
+	PollInterval time.Duration `yaml:"poll_interval"`
+	BatchSize    int           `yaml:"batch_size"`
+	Lease        time.Duration `yaml:"lease"`
+	MaxAttempts  int           `yaml:"max_attempts"`
+	MinBackoff   time.Duration `yaml:"min_backoff"`
+	MaxBackoff   time.Duration `yaml:"max_backoff"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.PollInterval == 0 {
+		c.PollInterval = time.Second
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = 50
+	}
+	if c.Lease == 0 {
+		c.Lease = time.Minute
+	}
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = 10
+	}
+	if c.MinBackoff == 0 {
+		c.MinBackoff = 5 * time.Second
+	}
+	if c.MaxBackoff == 0 {
+		c.MaxBackoff = time.Hour
+	}
+	return c
+}
+
+// Validate reports every invalid value at once, zero values stand for the
+// defaults
+func (c Config) Validate() error {
+	var errs []error
+	for _, d := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"poll_interval", c.PollInterval},
+		{"lease", c.Lease},
+		{"min_backoff", c.MinBackoff},
+		{"max_backoff", c.MaxBackoff},
+	} {
+		if d.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", d.key))
+		}
+	}
+	if c.BatchSize < 0 {
+		errs = append(errs, errors.New("batch_size must not be negative"))
+	}
+	if c.MaxAttempts < 0 {
+		errs = append(errs, errors.New("max_attempts must not be negative"))
+	}
+	c = c.withDefaults()
+	if c.MinBackoff > c.MaxBackoff {
+		errs = append(errs, fmt.Errorf("min_backoff (%s) exceeds max_backoff (%s)", c.MinBackoff, c.MaxBackoff))
+	}
+	return errors.Join(errs...)
+}
+
+// Backoff returns the delay before the next attempt after attempt failures:
+// MinBackoff doubled on every failure and capped by MaxBackoff.
+func (c Config) Backoff(attempt int) time.Duration {
+	backoff := c.MinBackoff
+	for i := 1; i < attempt && backoff < c.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, c.MaxBackoff)
+}
+
+type Sender interface {
+	Send(ctx context.Context, req webhookclient.Request) (int, error)
+}
+
+// Dispatcher delivers events from the webhook outbox, retrying failed
+// deliveries with exponential backoff until MaxAttempts is reached.
+type Dispatcher struct {
+	repository Repository
+	sender     Sender
+	conf       Config
+}
+
+func NewDispatcher(repository Repository, sender Sender, conf Config) Dispatcher {
+	return Dispatcher{
+		repository: repository,
+		sender:     sender,
+		conf:       conf.withDefaults(),
+	}
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (d Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.conf.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.Dispatch(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch delivers a single batch of due events.
+func (d Dispatcher) Dispatch(ctx context.Context) {
+	ctx, span := tracer.Start(ctx, "provider/DispatchWebhooks")
+	defer span.End()
+
+	events, err := d.repository.ClaimWebhookEvents(ctx, d.conf.BatchSize, d.conf.Lease)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to claim webhook events", "error", err)
+		return
+	}
+
+	webhooks := make(map[int]models.Webhook)
+	for _, event := range events {
+		webhook, ok := webhooks[event.WebhookID]
+		if !ok {
+			webhook, err = d.repository.GetWebhook(ctx, event.WebhookID)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to get webhook", "webhook_id", event.WebhookID, "error", err)
+				// A claimed event comes back after its lease, so it has to be
+				// settled even though nothing was sent
+				event.Attempts++
+				if errors.Is(err, sql.ErrNoRows) {
+					event.Status = models.WebhookEventFailed
+				} else {
+					d.retry(&event)
+				}
+				d.updateEvent(ctx, event)
+				continue
+			}
+			webhooks[event.WebhookID] = webhook
+		}
+		d.deliver(ctx, webhook, event)
+	}
+}
+
+func (d Dispatcher) deliver(ctx context.Context, webhook models.Webhook, event models.WebhookEvent) {
+	start := time.Now()
+	code, sendErr := d.sender.Send(ctx, webhookclient.Request{
+		URL:        webhook.URL,
+		Secret:     webhook.Secret,
+		Event:      event.Event,
+		DeliveryID: event.ID,
+		Payload:    event.Payload,
+	})
+	event.Attempts++
+
+	delivery := models.WebhookDelivery{
+		WebhookID:  webhook.ID,
+		EventID:    event.ID,
+		Event:      event.Event,
+		Attempt:    event.Attempts,
+		StatusCode: code,
+		DurationMS: int(time.Since(start).Milliseconds()),
+	}
+	if sendErr == nil {
+		event.Status = models.WebhookEventDelivered
+	} else {
+		delivery.Error = sendErr.Error()
+		d.retry(&event)
+	}
+
+	err := d.repository.CreateWebhookDelivery(ctx, delivery)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to log webhook delivery", "event_id", event.ID, "error", err)
+	}
+	d.updateEvent(ctx, event)
+}
+
+// retry schedules the next attempt of a failed event with backoff, or fails
+// it for good once MaxAttempts is reached
+func (d Dispatcher) retry(event *models.WebhookEvent) {
+	if event.Attempts >= d.conf.MaxAttempts {
+		event.Status = models.WebhookEventFailed
+		return
+	}
+	event.Status = models.WebhookEventPending
+	event.NextAttemptAt = time.Now().Add(d.conf.Backoff(event.Attempts))
+}
+
+func (d Dispatcher) updateEvent(ctx context.Context, event models.WebhookEvent) {
+	err := d.repository.UpdateWebhookEvent(ctx, event)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to update webhook event", "event_id", event.ID, "error", err)
+	}
+}
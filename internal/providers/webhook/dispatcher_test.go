@@ -0,0 +1,181 @@
+package webhook_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	webhookclient "github.com/hse-telescope/core/internal/clients/webhook"
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/providers/webhook"
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/memory"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver answers every delivery with status and hands it to the test
+func receiver(t *testing.T, status int) (*httptest.Server, <-chan received) {
+	deliveries := make(chan received, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read delivery: %v", err)
+		}
+		deliveries <- received{header: r.Header, body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, deliveries
+}
+
+// newService creates a service in a new project with a webhook to url and
+// returns the webhook, the outbox holds the service.created event
+func newService(t *testing.T, f facade.Facade, url string) models.Webhook {
+	ctx := context.Background()
+	project, err := f.CreateProject(ctx, models.Project{Name: "webhooks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph, err := f.CreateGraph(ctx, models.Graph{ProjectID: project.ID, Name: "webhooks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook, err := f.CreateWebhook(ctx, models.Webhook{
+		ProjectID: project.ID,
+		URL:       url,
+		Secret:    "secret",
+		Events:    []string{"service.*"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.CreateService(ctx, models.Service{GraphID: graph.ID, Name: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hook
+}
+
+func TestDispatchSignsDeliveries(t *testing.T) {
+	ctx := context.Background()
+	srv, deliveries := receiver(t, http.StatusNoContent)
+	f := facade.New(memory.New(), events.NewBroker(), nil)
+	hook := newService(t, f, srv.URL)
+
+	d := webhook.NewDispatcher(f, webhookclient.New(webhookclient.Config{}), webhook.Config{})
+	d.Dispatch(ctx)
+
+	var got received
+	select {
+	case got = <-deliveries:
+	default:
+		t.Fatal("nothing is delivered")
+	}
+	if event := got.header.Get(webhookclient.HeaderEvent); event != facade.EventServiceCreated {
+		t.Fatalf("delivered event %q, want %q", event, facade.EventServiceCreated)
+	}
+	signature := webhookclient.Sign("secret", got.header.Get(webhookclient.HeaderTimestamp), got.body)
+	if got.header.Get(webhookclient.HeaderSignature) != signature {
+		t.Fatalf("signature %q does not match the body, want %q", got.header.Get(webhookclient.HeaderSignature), signature)
+	}
+	var payload struct {
+		Event string         `json:"event"`
+		Data  models.Service `json:"data"`
+	}
+	err := json.Unmarshal(got.body, &payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload.Event != facade.EventServiceCreated || payload.Data.Name != "api" {
+		t.Fatalf("unexpected payload: %s", got.body)
+	}
+
+	history, err := f.GetWebhookDeliveries(ctx, hook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].StatusCode != http.StatusNoContent || history[0].Error != "" {
+		t.Fatalf("unexpected deliveries: %+v", history)
+	}
+	pending, err := f.ClaimWebhookEvents(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("delivered events are pending: %+v", pending)
+	}
+}
+
+// recorder keeps the events the dispatcher settles
+type recorder struct {
+	webhook.Repository
+	missing bool
+	updated []models.WebhookEvent
+}
+
+func (r *recorder) GetWebhook(ctx context.Context, webhook_id int) (models.Webhook, error) {
+	if r.missing {
+		return models.Webhook{}, sql.ErrNoRows
+	}
+	return r.Repository.GetWebhook(ctx, webhook_id)
+}
+
+func (r *recorder) UpdateWebhookEvent(ctx context.Context, event models.WebhookEvent) error {
+	r.updated = append(r.updated, event)
+	return r.Repository.UpdateWebhookEvent(ctx, event)
+}
+
+func TestDispatchRetriesFailedDeliveries(t *testing.T) {
+	ctx := context.Background()
+	srv, deliveries := receiver(t, http.StatusBadGateway)
+	f := facade.New(memory.New(), events.NewBroker(), nil)
+	hook := newService(t, f, srv.URL)
+
+	repository := &recorder{Repository: f}
+	d := webhook.NewDispatcher(repository, webhookclient.New(webhookclient.Config{}), webhook.Config{MaxAttempts: 2})
+	d.Dispatch(ctx)
+	if len(deliveries) != 1 {
+		t.Fatalf("%d deliveries are sent, want 1", len(deliveries))
+	}
+	if len(repository.updated) != 1 {
+		t.Fatalf("%d events are settled, want 1", len(repository.updated))
+	}
+	event := repository.updated[0]
+	if event.Status != models.WebhookEventPending || event.Attempts != 1 || !event.NextAttemptAt.After(time.Now()) {
+		t.Fatalf("failed event is not retried later: %+v", event)
+	}
+	history, err := f.GetWebhookDeliveries(ctx, hook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].StatusCode != http.StatusBadGateway || history[0].Error == "" {
+		t.Fatalf("unexpected deliveries: %+v", history)
+	}
+}
+
+func TestDispatchFailsEventsOfMissingWebhooks(t *testing.T) {
+	ctx := context.Background()
+	srv, deliveries := receiver(t, http.StatusOK)
+	f := facade.New(memory.New(), events.NewBroker(), nil)
+	newService(t, f, srv.URL)
+
+	repository := &recorder{Repository: f, missing: true}
+	d := webhook.NewDispatcher(repository, webhookclient.New(webhookclient.Config{}), webhook.Config{})
+	d.Dispatch(ctx)
+	if len(deliveries) != 0 {
+		t.Fatalf("%d deliveries are sent without a webhook", len(deliveries))
+	}
+	if len(repository.updated) != 1 || repository.updated[0].Status != models.WebhookEventFailed {
+		t.Fatalf("event of a missing webhook is not failed: %+v", repository.updated)
+	}
+}
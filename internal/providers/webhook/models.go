@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+type Webhook struct {
+	ID        int
+	ProjectID int
+	URL       string
+	Secret    string
+	Events    []string
+}
+
+type Delivery struct {
+	ID         int
+	WebhookID  int
+	EventID    int
+	Event      string
+	Attempt    int
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
+
+func ProviderWebhook2DBWebhook(webhook Webhook) models.Webhook {
+	return models.Webhook{
+		ID:        webhook.ID,
+		ProjectID: webhook.ProjectID,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		Events:    webhook.Events,
+	}
+}
+
+func DBWebhook2ProviderWebhook(webhook models.Webhook) Webhook {
+	return Webhook{
+		ID:        webhook.ID,
+		ProjectID: webhook.ProjectID,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		Events:    webhook.Events,
+	}
+}
+
+func DBDelivery2ProviderDelivery(delivery models.WebhookDelivery) Delivery {
+	return Delivery{
+		ID:         delivery.ID,
+		WebhookID:  delivery.WebhookID,
+		EventID:    delivery.EventID,
+		Event:      delivery.Event,
+		Attempt:    delivery.Attempt,
+		StatusCode: delivery.StatusCode,
+		Error:      delivery.Error,
+		Duration:   time.Duration(delivery.DurationMS) * time.Millisecond,
+		CreatedAt:  delivery.CreatedAt,
+	}
+}
@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+	"github.com/hse-telescope/tracer"
+	"github.com/olegdayo/omniconv"
+)
+
+type Repository interface {
+	GetWebhook(ctx context.Context, webhook_id int) (models.Webhook, error)
+	GetProjectWebhooks(ctx context.Context, project_id int) ([]models.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook models.Webhook) (models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook_id int, webhook models.Webhook) error
+	DeleteWebhook(ctx context.Context, webhook_id int) error
+	ClaimWebhookEvents(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookEvent, error)
+	UpdateWebhookEvent(ctx context.Context, event models.WebhookEvent) error
+	CreateWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, webhook_id int) ([]models.WebhookDelivery, error)
+}
+
+type Provider struct {
+	repository Repository
+}
+
+func New(repository Repository) Provider {
+	return Provider{
+		repository: repository,
+	}
+}
+
+func (p Provider) GetWebhook(ctx context.Context, webhook_id int) (Webhook, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetWebhook")
+	defer span.End()
+
+	webhook, err := p.repository.GetWebhook(ctx, webhook_id)
+	if err != nil {
+		return Webhook{}, err
+	}
+	return DBWebhook2ProviderWebhook(webhook), nil
+}
+
+func (p Provider) GetProjectWebhooks(ctx context.Context, project_id int) ([]Webhook, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetProjectWebhooks")
+	defer span.End()
+
+	webhooks, err := p.repository.GetProjectWebhooks(ctx, project_id)
+	if err != nil {
+		return nil, err
+	}
+	return omniconv.ConvertSlice(webhooks, DBWebhook2ProviderWebhook), nil
+}
+
+func (p Provider) CreateWebhook(ctx context.Context, webhook Webhook) (Webhook, error) {
+	ctx, span := tracer.Start(ctx, "provider/CreateWebhook")
+	defer span.End()
+
+	newwebhook, err := p.repository.CreateWebhook(ctx, ProviderWebhook2DBWebhook(webhook))
+	return DBWebhook2ProviderWebhook(newwebhook), err
+}
+
+func (p Provider) UpdateWebhook(ctx context.Context, webhook_id int, webhook Webhook) error {
+	ctx, span := tracer.Start(ctx, "provider/UpdateWebhook")
+	defer span.End()
+
+	// Secrets are never returned to clients, so the storage keeps the current
+	// secret for an empty one
+	err := p.repository.UpdateWebhook(ctx, webhook_id, ProviderWebhook2DBWebhook(webhook))
+	return err
+}
+
+func (p Provider) DeleteWebhook(ctx context.Context, webhook_id int) error {
+	ctx, span := tracer.Start(ctx, "provider/DeleteWebhook")
+	defer span.End()
+
+	err := p.repository.DeleteWebhook(ctx, webhook_id)
+	return err
+}
+
+func (p Provider) GetWebhookDeliveries(ctx context.Context, webhook_id int) ([]Delivery, error) {
+	ctx, span := tracer.Start(ctx, "provider/GetWebhookDeliveries")
+	defer span.End()
+
+	deliveries, err := p.repository.GetWebhookDeliveries(ctx, webhook_id)
+	if err != nil {
+		return nil, err
+	}
+	return omniconv.ConvertSlice(deliveries, DBDelivery2ProviderDelivery), nil
+}
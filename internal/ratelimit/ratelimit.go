@@ -0,0 +1,133 @@
+// Package ratelimit keeps a token bucket per client. A bucket holds up to
+// Burst tokens and refills at Rate tokens per second, every request takes a
+// token and is refused when there is none left.
+package ratelimit
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Config struct {
+	// Rate of requests per second a client keeps up, 0 turns rate limiting
+	// off
+	Rate float64 `yaml:"rate"`
+	// Burst is how many requests a client makes at once, Rate rounded up
+	// when 0
+	Burst int `yaml:"burst"`
+	// ClientHeader identifies clients by a header set by a trusted proxy,
+	// such as X-Forwarded-For, instead of the remote address
+	ClientHeader string `yaml:"client_header"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.Burst == 0 {
+		c.Burst = max(1, int(c.Rate+0.999))
+	}
+	return c
+}
+
+// Validate reports every invalid value at once
+func (c Config) Validate() error {
+	var errs []error
+	if c.Rate < 0 {
+		errs = append(errs, errors.New("rate must not be negative"))
+	}
+	if c.Burst < 0 {
+		errs = append(errs, errors.New("burst must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Client identifies the client of r: the first address of ClientHeader when
+// it is set, the remote address otherwise
+func (c Config) Client(r *http.Request) string {
+	if c.ClientHeader != "" {
+		client, _, _ := strings.Cut(r.Header.Get(c.ClientHeader), ",")
+		if client = strings.TrimSpace(client); client != "" {
+			return client
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// sweepInterval is how often idle buckets are dropped
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Limiter is safe for concurrent use
+type Limiter struct {
+	mu      sync.Mutex
+	conf    Config
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+func New(conf Config) *Limiter {
+	return &Limiter{
+		conf:    conf.withDefaults(),
+		buckets: make(map[string]*bucket),
+		swept:   time.Now(),
+	}
+}
+
+// SetConfig changes the limits, the buckets of the clients are kept
+func (l *Limiter) SetConfig(conf Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.conf = conf.withDefaults()
+}
+
+// Allow takes a token of the client. When there is none it returns false
+// along with the time until the next one.
+func (l *Limiter) Allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conf.Rate <= 0 {
+		return true, 0
+	}
+
+	now := time.Now()
+	l.sweep(now)
+	burst := float64(l.conf.Burst)
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: burst, updated: now}
+		l.buckets[client] = b
+	}
+	b.tokens = min(burst, b.tokens+now.Sub(b.updated).Seconds()*l.conf.Rate)
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := (1 - b.tokens) / l.conf.Rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// sweep drops the buckets that have refilled, a new bucket is full as well
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < sweepInterval {
+		return
+	}
+	l.swept = now
+	refill := time.Duration(float64(l.conf.Burst) / l.conf.Rate * float64(time.Second))
+	for client, b := range l.buckets {
+		if now.Sub(b.updated) >= refill {
+			delete(l.buckets, client)
+		}
+	}
+}
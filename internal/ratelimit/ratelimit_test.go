@@ -0,0 +1,129 @@
+package ratelimit_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hse-telescope/core/internal/ratelimit"
+)
+
+func TestAllowTakesTokensUpToBurst(t *testing.T) {
+	l := ratelimit.New(ratelimit.Config{Rate: 1, Burst: 3})
+	for i := range 3 {
+		ok, _ := l.Allow("a")
+		if !ok {
+			t.Fatalf("request %d of the burst is refused", i+1)
+		}
+	}
+	ok, retryAfter := l.Allow("a")
+	if ok {
+		t.Fatal("request over the burst is allowed")
+	}
+	if retryAfter <= 0 || retryAfter > time.Second {
+		t.Fatalf("retry after %s, want up to 1s", retryAfter)
+	}
+
+	// Buckets are per client
+	ok, _ = l.Allow("b")
+	if !ok {
+		t.Fatal("another client is refused")
+	}
+}
+
+func TestAllowRefills(t *testing.T) {
+	l := ratelimit.New(ratelimit.Config{Rate: 100, Burst: 1})
+	ok, _ := l.Allow("a")
+	if !ok {
+		t.Fatal("first request is refused")
+	}
+	ok, retryAfter := l.Allow("a")
+	if ok {
+		t.Fatal("request over the burst is allowed")
+	}
+	if retryAfter > 10*time.Millisecond {
+		t.Fatalf("retry after %s, want up to 10ms", retryAfter)
+	}
+	time.Sleep(50 * time.Millisecond)
+	ok, _ = l.Allow("a")
+	if !ok {
+		t.Fatal("refilled bucket is refused")
+	}
+}
+
+func TestBurstDefaultsToRate(t *testing.T) {
+	l := ratelimit.New(ratelimit.Config{Rate: 2.5})
+	for i := range 3 {
+		ok, _ := l.Allow("a")
+		if !ok {
+			t.Fatalf("request %d of the default burst is refused", i+1)
+		}
+	}
+	ok, _ := l.Allow("a")
+	if ok {
+		t.Fatal("request over the default burst of 3 is allowed")
+	}
+}
+
+func TestZeroRateIsUnlimited(t *testing.T) {
+	l := ratelimit.New(ratelimit.Config{})
+	for range 1000 {
+		ok, _ := l.Allow("a")
+		if !ok {
+			t.Fatal("request is refused without a rate")
+		}
+	}
+}
+
+func TestSetConfig(t *testing.T) {
+	l := ratelimit.New(ratelimit.Config{Rate: 1, Burst: 1})
+	l.Allow("a")
+	ok, _ := l.Allow("a")
+	if ok {
+		t.Fatal("request over the burst is allowed")
+	}
+
+	// The empty bucket is kept by a reload
+	l.SetConfig(ratelimit.Config{Rate: 1, Burst: 5})
+	ok, _ = l.Allow("a")
+	if ok {
+		t.Fatal("reload refills the bucket")
+	}
+
+	l.SetConfig(ratelimit.Config{})
+	ok, _ = l.Allow("a")
+	if !ok {
+		t.Fatal("request is refused after rate limiting is turned off")
+	}
+}
+
+func TestClient(t *testing.T) {
+	r := httptest.NewRequest("GET", "/projects", nil)
+	r.RemoteAddr = "192.0.2.1:4711"
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+
+	for _, c := range []struct {
+		header string
+		want   string
+	}{
+		{"", "192.0.2.1"},
+		{"X-Forwarded-For", "203.0.113.7"},
+		{"X-Real-IP", "192.0.2.1"},
+	} {
+		got := ratelimit.Config{ClientHeader: c.header}.Client(r)
+		if got != c.want {
+			t.Errorf("client header %q: client %q, want %q", c.header, got, c.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	err := ratelimit.Config{Rate: -1, Burst: -1}.Validate()
+	if err == nil {
+		t.Fatal("negative rate and burst are accepted")
+	}
+	err = ratelimit.Config{Rate: 10, Burst: 20}.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// Batch getters load the children of several parents in a single query, they
+// back the batching loaders of the GraphQL endpoint.
+
+func (s DB) GetProjectsGraphs(ctx context.Context, project_ids []int) ([]models.Graph, error) {
+	ctx, span := s.startSpan(ctx, "storage/GetProjectsGraphs")
+	defer span.End()
+
+	q := `
+		SELECT
+			id,
+			project_id,
+			name
+		FROM graphs WHERE project_id = ANY($1)
+		ORDER BY id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, intArray(project_ids))
+	if err != nil {
+		return nil, err
+	}
+	graphs := make([]models.Graph, 0)
+	err = sqlx.StructScan(rows, &graphs)
+	if err != nil {
+		return nil, err
+	}
+	return graphs, nil
+}
+
+func (s DB) GetGraphsServices(ctx context.Context, graph_ids []int) ([]models.Service, error) {
+	ctx, span := s.startSpan(ctx, "storage/GetGraphsServices")
+	defer span.End()
+
+	q := `
+		SELECT ` + serviceColumns + `
+		FROM services WHERE graph_id = ANY($1)
+		ORDER BY id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, intArray(graph_ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	services := make([]models.Service, 0)
+	for rows.Next() {
+		service, err := scanService(rows)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, service)
+	}
+	return services, rows.Err()
+}
+
+// GetServicesRelations returns the relations going out of the given services
+func (s DB) GetServicesRelations(ctx context.Context, service_ids []int) ([]models.Relation, error) {
+	ctx, span := s.startSpan(ctx, "storage/GetServicesRelations")
+	defer span.End()
+
+	q := `
+		SELECT
+			id,
+			graph_id,
+			name,
+			description,
+			from_service,
+			to_service,
+			protocol,
+			async,
+			bidirectional,
+			criticality,
+			expected_rps,
+			expected_latency_ms
+		FROM relations WHERE from_service = ANY($1)
+		ORDER BY id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, intArray(service_ids))
+	if err != nil {
+		return nil, err
+	}
+	relations := make([]models.Relation, 0)
+	err = sqlx.StructScan(rows, &relations)
+	if err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
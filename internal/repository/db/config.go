@@ -0,0 +1,131 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hse-telescope/utils/db/psql"
+)
+
+// Config of the Postgres storage: the connection settings followed by the
+// pool, the timeouts and the retries
+type Config struct {
+	psql.DB `yaml:",inline"`
+
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+
+	// QueryTimeout bounds every storage method, transactions included
+	QueryTimeout time.Duration `yaml:"query_timeout"`
+
+	// ConnectTimeout is how long New waits for Postgres to come up, retrying
+	// with a backoff from ConnectMinBackoff doubling up to ConnectMaxBackoff
+	ConnectTimeout    time.Duration `yaml:"connect_timeout"`
+	ConnectMinBackoff time.Duration `yaml:"connect_min_backoff"`
+	ConnectMaxBackoff time.Duration `yaml:"connect_max_backoff"`
+
+	// SerializationRetries is how many times a transaction aborted by a
+	// serialization failure or a deadlock is run again
+	SerializationRetries int `yaml:"serialization_retries"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxOpenConns == 0 {
+		c.MaxOpenConns = 20
+	}
+	if c.MaxIdleConns == 0 {
+		c.MaxIdleConns = 10
+	}
+	if c.ConnMaxLifetime == 0 {
+		c.ConnMaxLifetime = 30 * time.Minute
+	}
+	if c.ConnMaxIdleTime == 0 {
+		c.ConnMaxIdleTime = 5 * time.Minute
+	}
+	if c.QueryTimeout == 0 {
+		c.QueryTimeout = 30 * time.Second
+	}
+	if c.ConnectTimeout == 0 {
+		c.ConnectTimeout = time.Minute
+	}
+	if c.ConnectMinBackoff == 0 {
+		c.ConnectMinBackoff = 500 * time.Millisecond
+	}
+	if c.ConnectMaxBackoff == 0 {
+		c.ConnectMaxBackoff = 10 * time.Second
+	}
+	if c.SerializationRetries == 0 {
+		c.SerializationRetries = 3
+	}
+	return c
+}
+
+// Validate reports every invalid value at once, zero values stand for the
+// defaults
+func (c Config) Validate() error {
+	var errs []error
+	for _, s := range []struct {
+		key   string
+		value string
+	}{
+		{"schema", c.Schema},
+		{"user", c.User},
+		{"ip", c.IP},
+		{"database", c.DataBase},
+		{"migrations_path", c.MigrationsPath},
+	} {
+		if s.value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", s.key))
+		}
+	}
+	if c.Port == 0 {
+		errs = append(errs, errors.New("port is required"))
+	}
+
+	for _, d := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"conn_max_lifetime", c.ConnMaxLifetime},
+		{"conn_max_idle_time", c.ConnMaxIdleTime},
+		{"query_timeout", c.QueryTimeout},
+		{"connect_timeout", c.ConnectTimeout},
+		{"connect_min_backoff", c.ConnectMinBackoff},
+		{"connect_max_backoff", c.ConnectMaxBackoff},
+	} {
+		if d.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", d.key))
+		}
+	}
+	if c.MaxOpenConns < 0 {
+		errs = append(errs, errors.New("max_open_conns must not be negative"))
+	}
+	if c.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("max_idle_conns must not be negative"))
+	}
+	if c.SerializationRetries < 0 {
+		errs = append(errs, errors.New("serialization_retries must not be negative"))
+	}
+
+	c = c.withDefaults()
+	if c.MaxIdleConns > c.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("max_idle_conns (%d) exceeds max_open_conns (%d)", c.MaxIdleConns, c.MaxOpenConns))
+	}
+	if c.ConnectMinBackoff > c.ConnectMaxBackoff {
+		errs = append(errs, fmt.Errorf("connect_min_backoff (%s) exceeds connect_max_backoff (%s)", c.ConnectMinBackoff, c.ConnectMaxBackoff))
+	}
+	return errors.Join(errs...)
+}
+
+// connectBackoff returns the delay before the next connection attempt after
+// attempt failures
+func (c Config) connectBackoff(attempt int) time.Duration {
+	backoff := c.ConnectMinBackoff
+	for i := 1; i < attempt && backoff < c.ConnectMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, c.ConnectMaxBackoff)
+}
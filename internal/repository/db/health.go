@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Ping checks the database answers
+func (s DB) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// CheckMigrations reports the schema version left by golang-migrate. It fails
+// when the last migration broke halfway or when the schema is behind the
+// migrations core was shipped with.
+func (s DB) CheckMigrations(ctx context.Context) (string, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := s.db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the schema version: %w", err)
+	}
+	detail := fmt.Sprintf("version %d", version)
+	if dirty {
+		return detail, errors.New("the last migration did not complete")
+	}
+
+	latest, ok, err := latestMigration(s.conf.MigrationsPath)
+	if err != nil {
+		return detail, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	if ok && version < latest {
+		return detail, fmt.Errorf("schema is behind migration %d", latest)
+	}
+	return detail, nil
+}
+
+// latestMigration returns the version of the last migration under a file://
+// path, ok is false for other sources
+func latestMigration(path string) (latest int64, ok bool, err error) {
+	dir, ok := strings.CutPrefix(path, "file://")
+	if !ok {
+		return 0, false, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, entry := range entries {
+		prefix, _, found := strings.Cut(entry.Name(), "_")
+		if !found || !strings.HasSuffix(entry.Name(), ".up.sql") {
+			continue
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err == nil && version > latest {
+			latest = version
+		}
+	}
+	return latest, true, nil
+}
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+const idempotencyKeyColumns = `
+	key,
+	fingerprint,
+	status,
+	content_type,
+	body,
+	created_at,
+	expires_at
+`
+
+func scanIdempotencyKey(row scanner) (models.IdempotencyKey, error) {
+	var key models.IdempotencyKey
+	err := row.Scan(
+		&key.Key, &key.Fingerprint, &key.Status, &key.ContentType, &key.Body,
+		&key.CreatedAt, &key.ExpiresAt,
+	)
+	return key, err
+}
+
+// ClaimIdempotencyKey stores the key as in progress unless another request
+// holds it. A key that expired or stayed in progress since before stale is
+// taken over. It returns the stored key and whether it is the claimed one.
+func (s DB) ClaimIdempotencyKey(ctx context.Context, key models.IdempotencyKey, stale time.Time) (models.IdempotencyKey, bool, error) {
+	ctx, span := s.startSpan(ctx, "storage/ClaimIdempotencyKey")
+	defer span.End()
+
+	claim := `
+		INSERT INTO idempotency_keys (key, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status = 0, content_type = '', body = '',
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+			OR (idempotency_keys.status = 0 AND idempotency_keys.created_at < $5)
+		RETURNING ` + idempotencyKeyColumns
+	get := `SELECT ` + idempotencyKeyColumns + ` FROM idempotency_keys WHERE key = $1`
+
+	// The key held by another request can expire and be swept between the
+	// two queries, the claim is tried again then
+	for {
+		claimed, err := scanIdempotencyKey(s.conn(ctx).QueryRowContext(ctx, claim, key.Key, key.Fingerprint, key.CreatedAt, key.ExpiresAt, stale))
+		if err == nil {
+			return claimed, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return models.IdempotencyKey{}, false, err
+		}
+		held, err := scanIdempotencyKey(s.conn(ctx).QueryRowContext(ctx, get, key.Key))
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return models.IdempotencyKey{}, false, err
+		}
+		return held, false, nil
+	}
+}
+
+// CompleteIdempotencyKey stores the response of the request holding the key
+func (s DB) CompleteIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error {
+	ctx, span := s.startSpan(ctx, "storage/CompleteIdempotencyKey")
+	defer span.End()
+
+	q := `
+		UPDATE idempotency_keys
+		SET status = $1, content_type = $2, body = $3
+		WHERE key = $4 AND fingerprint = $5 AND status = 0
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, key.Status, key.ContentType, key.Body, key.Key, key.Fingerprint)
+	return err
+}
+
+// ReleaseIdempotencyKey deletes the key of a request that is still in
+// progress, so a retry runs it again
+func (s DB) ReleaseIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error {
+	ctx, span := s.startSpan(ctx, "storage/ReleaseIdempotencyKey")
+	defer span.End()
+
+	q := `
+		DELETE FROM idempotency_keys WHERE key = $1 AND fingerprint = $2 AND status = 0
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, key.Key, key.Fingerprint)
+	return err
+}
+
+func (s DB) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := s.startSpan(ctx, "storage/DeleteExpiredIdempotencyKeys")
+	defer span.End()
+
+	q := `
+		DELETE FROM idempotency_keys WHERE expires_at <= $1
+	`
+	res, err := s.conn(ctx).ExecContext(ctx, q, now)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := res.RowsAffected()
+	return int(deleted), err
+}
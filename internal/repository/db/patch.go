@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+// The Patch methods write only the named columns of the entity and return the
+// row as stored, so concurrent writes of other columns are kept. Without
+// columns they only read the row, locking it until the transaction ends.
+
+func (s DB) PatchProject(ctx context.Context, project_id int, project models.Project, fields []string) (models.Project, error) {
+	ctx, span := s.startSpan(ctx, "storage/PatchProject")
+	defer span.End()
+
+	q, args, err := patchQuery("projects", project_id, project, fields, "id, name")
+	if err != nil {
+		return models.Project{}, err
+	}
+	var patched models.Project
+	err = s.conn(ctx).QueryRowContext(ctx, q, args...).Scan(&patched.ID, &patched.Name)
+	if err != nil {
+		return models.Project{}, err
+	}
+	return patched, nil
+}
+
+func (s DB) PatchGraph(ctx context.Context, graph_id int, graph models.Graph, fields []string) (models.Graph, error) {
+	ctx, span := s.startSpan(ctx, "storage/PatchGraph")
+	defer span.End()
+
+	q, args, err := patchQuery("graphs", graph_id, graph, fields, "id, project_id, name")
+	if err != nil {
+		return models.Graph{}, err
+	}
+	var patched models.Graph
+	err = s.conn(ctx).QueryRowContext(ctx, q, args...).Scan(&patched.ID, &patched.ProjectID, &patched.Name)
+	if err != nil {
+		return models.Graph{}, err
+	}
+	return patched, nil
+}
+
+// PatchService copies the catalog entry of the service again when the patch
+// links it to an entry, moves it or touches the fields the entry owns
+func (s DB) PatchService(ctx context.Context, service_id int, service models.Service, fields []string) (models.Service, error) {
+	ctx, span := s.startSpan(ctx, "storage/PatchService")
+	defer span.End()
+
+	if models.InheritsCatalog(service, fields) {
+		var err error
+		service, err = s.inheritCatalogService(ctx, service)
+		if err != nil {
+			return models.Service{}, err
+		}
+		fields = append(fields, models.CatalogFields...)
+	}
+	q, args, err := patchQuery("services", service_id, service, fields, serviceColumns)
+	if err != nil {
+		return models.Service{}, err
+	}
+	return scanService(s.conn(ctx).QueryRowContext(ctx, q, args...))
+}
+
+func (s DB) PatchRelation(ctx context.Context, relation_id int, relation models.Relation, fields []string) (models.Relation, error) {
+	ctx, span := s.startSpan(ctx, "storage/PatchRelation")
+	defer span.End()
+
+	q, args, err := patchQuery("relations", relation_id, relation, fields, relationColumns)
+	if err != nil {
+		return models.Relation{}, err
+	}
+	return scanRelation(s.conn(ctx).QueryRowContext(ctx, q, args...))
+}
+
+// patchQuery builds the update of the named columns of a row returning the
+// columns, or a select of them locking the row when there is nothing to
+// update. Only
+// columns of the entity are accepted, so they are safe to put in the query.
+func patchQuery[T any](table string, id int, entity T, fields []string, columns string) (string, []any, error) {
+	var unique []string
+	for _, field := range fields {
+		if !slices.Contains(unique, field) {
+			unique = append(unique, field)
+		}
+	}
+	if len(unique) == 0 {
+		return fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 FOR UPDATE", columns, table), []any{id}, nil
+	}
+
+	values, err := models.FieldValues(entity, unique)
+	if err != nil {
+		return "", nil, err
+	}
+	set := make([]string, len(unique))
+	for i, field := range unique {
+		switch value := values[i].(type) {
+		case []string:
+			values[i] = stringArray(value)
+		case map[string]string:
+			values[i], err = marshalAttributes(value)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+		set[i] = fmt.Sprintf("%s = $%d", field, i+1)
+	}
+	q := fmt.Sprintf("UPDATE %s SET %s WHERE id = $%d RETURNING %s", table, strings.Join(set, ", "), len(unique)+1, columns)
+	return q, append(values, id), nil
+}
@@ -0,0 +1,262 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+// ApplyProjectPlan locks the project row, computes the plan with the context
+// of the transaction holding the lock and applies it there, so concurrent
+// applies of a project run one after another and each plans against the
+// state the other left. Deletions go first, then graphs, services and
+// relations are created or updated; entities created by the plan get their
+// IDs filled in the returned plan. plan may run more than once, see inTx.
+func (s DB) ApplyProjectPlan(ctx context.Context, project_id int, plan func(ctx context.Context) (models.ProjectPlan, error)) (models.ProjectPlan, error) {
+	ctx, span := s.startSpan(ctx, "storage/ApplyProjectPlan")
+	defer span.End()
+
+	var applied models.ProjectPlan
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `SELECT id FROM projects WHERE id = $1 FOR UPDATE`, project_id).Scan(&project_id)
+		if err != nil {
+			return err
+		}
+		planned, err := plan(context.WithValue(ctx, txKey{}, tx))
+		if err != nil {
+			return err
+		}
+		applied, err = applyProjectPlan(ctx, tx, project_id, planned)
+		return err
+	})
+	if err != nil {
+		return models.ProjectPlan{}, err
+	}
+	return applied, nil
+}
+
+func applyProjectPlan(ctx context.Context, tx *sql.Tx, project_id int, plan models.ProjectPlan) (models.ProjectPlan, error) {
+	// Deletions and updates are limited to the project, so a plan cannot
+	// touch foreign entities by ID
+	_, err := tx.ExecContext(ctx, `
+		DELETE FROM relations
+		WHERE id = ANY($1) AND graph_id IN (SELECT id FROM graphs WHERE project_id = $2)
+	`, intArray(relationIDs(plan.DeleteRelations)), project_id)
+	if err != nil {
+		return models.ProjectPlan{}, err
+	}
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM services
+		WHERE id = ANY($1) AND graph_id IN (SELECT id FROM graphs WHERE project_id = $2)
+	`, intArray(serviceIDs(plan.DeleteServices)), project_id)
+	if err != nil {
+		return models.ProjectPlan{}, err
+	}
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM graphs WHERE id = ANY($1) AND project_id = $2
+	`, intArray(graphIDs(plan.DeleteGraphs)), project_id)
+	if err != nil {
+		return models.ProjectPlan{}, err
+	}
+
+	for i, graph := range plan.CreateGraphs {
+		graph.ProjectID = project_id
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO graphs (project_id, name) VALUES ($1, $2) RETURNING id
+		`, graph.ProjectID, graph.Name).Scan(&graph.ID)
+		if err != nil {
+			return models.ProjectPlan{}, err
+		}
+		plan.CreateGraphs[i] = graph
+	}
+	graphs, err := projectGraphIDs(ctx, tx, project_id)
+	if err != nil {
+		return models.ProjectPlan{}, err
+	}
+
+	for i, planned := range plan.CreateServices {
+		service := planned.Service
+		graph_id, ok := graphs[planned.GraphName]
+		if !ok {
+			return models.ProjectPlan{}, fmt.Errorf("graph %q of service %q not found", planned.GraphName, service.Name)
+		}
+		service.GraphID = graph_id
+		attributes, err := marshalAttributes(service.Attributes)
+		if err != nil {
+			return models.ProjectPlan{}, err
+		}
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO services (
+				graph_id, name, description, x, y,
+				kind, owner_team, tags, attributes,
+				docs_url, runbook_url, repo_url
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id
+		`,
+			service.GraphID, service.Name, service.Description, service.X, service.Y,
+			service.Kind, service.OwnerTeam, stringArray(service.Tags), attributes,
+			service.DocsURL, service.RunbookURL, service.RepoURL,
+		).Scan(&service.ID)
+		if err != nil {
+			return models.ProjectPlan{}, err
+		}
+		plan.CreateServices[i].Service = service
+	}
+	for _, service := range plan.UpdateServices {
+		attributes, err := marshalAttributes(service.Attributes)
+		if err != nil {
+			return models.ProjectPlan{}, err
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE services
+			SET name = $1, description = $2, x = $3, y = $4,
+				kind = $5, owner_team = $6, tags = $7, attributes = $8,
+				docs_url = $9, runbook_url = $10, repo_url = $11
+			WHERE id = $12 AND graph_id IN (SELECT id FROM graphs WHERE project_id = $13)
+		`,
+			service.Name, service.Description, service.X, service.Y,
+			service.Kind, service.OwnerTeam, stringArray(service.Tags), attributes,
+			service.DocsURL, service.RunbookURL, service.RepoURL, service.ID, project_id,
+		)
+		if err != nil {
+			return models.ProjectPlan{}, err
+		}
+	}
+	services, err := projectServiceIDs(ctx, tx, project_id)
+	if err != nil {
+		return models.ProjectPlan{}, err
+	}
+
+	resolve := func(planned models.PlannedRelation) (models.Relation, error) {
+		relation := planned.Relation
+		var ok bool
+		relation.GraphID, ok = graphs[planned.GraphName]
+		if !ok {
+			return models.Relation{}, fmt.Errorf("graph %q of relation %q not found", planned.GraphName, relation.Name)
+		}
+		relation.FromService, ok = services[[2]string{planned.GraphName, planned.From}]
+		if !ok {
+			return models.Relation{}, fmt.Errorf("service %q of relation %q not found", planned.From, relation.Name)
+		}
+		relation.ToService, ok = services[[2]string{planned.GraphName, planned.To}]
+		if !ok {
+			return models.Relation{}, fmt.Errorf("service %q of relation %q not found", planned.To, relation.Name)
+		}
+		return relation, nil
+	}
+	for i, planned := range plan.CreateRelations {
+		relation, err := resolve(planned)
+		if err != nil {
+			return models.ProjectPlan{}, err
+		}
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO relations (
+				graph_id, name, description, from_service, to_service,
+				protocol, async, bidirectional, criticality, expected_rps, expected_latency_ms
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id
+		`,
+			relation.GraphID, relation.Name, relation.Description, relation.FromService, relation.ToService,
+			relation.Protocol, relation.Async, relation.Bidirectional, relation.Criticality,
+			relation.ExpectedRPS, relation.ExpectedLatencyMS,
+		).Scan(&relation.ID)
+		if err != nil {
+			return models.ProjectPlan{}, err
+		}
+		plan.CreateRelations[i].Relation = relation
+	}
+	for i, planned := range plan.UpdateRelations {
+		relation, err := resolve(planned)
+		if err != nil {
+			return models.ProjectPlan{}, err
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE relations
+			SET name = $1, description = $2, from_service = $3, to_service = $4,
+				protocol = $5, async = $6, bidirectional = $7, criticality = $8,
+				expected_rps = $9, expected_latency_ms = $10
+			WHERE id = $11 AND graph_id IN (SELECT id FROM graphs WHERE project_id = $12)
+		`,
+			relation.Name, relation.Description, relation.FromService, relation.ToService,
+			relation.Protocol, relation.Async, relation.Bidirectional, relation.Criticality,
+			relation.ExpectedRPS, relation.ExpectedLatencyMS, relation.ID, project_id,
+		)
+		if err != nil {
+			return models.ProjectPlan{}, err
+		}
+		plan.UpdateRelations[i].Relation = relation
+	}
+
+	return plan, nil
+}
+
+// projectGraphIDs maps graph names of the project to their IDs
+func projectGraphIDs(ctx context.Context, tx *sql.Tx, project_id int) (map[string]int, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id, name FROM graphs WHERE project_id = $1`, project_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]int)
+	for rows.Next() {
+		var id int
+		var name string
+		err = rows.Scan(&id, &name)
+		if err != nil {
+			return nil, err
+		}
+		res[name] = id
+	}
+	return res, rows.Err()
+}
+
+// projectServiceIDs maps graph and service names of the project to service IDs
+func projectServiceIDs(ctx context.Context, tx *sql.Tx, project_id int) (map[[2]string]int, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT s.id, g.name, s.name
+		FROM services s
+		JOIN graphs g ON g.id = s.graph_id
+		WHERE g.project_id = $1
+	`, project_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[[2]string]int)
+	for rows.Next() {
+		var id int
+		var graph, service string
+		err = rows.Scan(&id, &graph, &service)
+		if err != nil {
+			return nil, err
+		}
+		res[[2]string{graph, service}] = id
+	}
+	return res, rows.Err()
+}
+
+func graphIDs(graphs []models.Graph) []int {
+	ids := make([]int, 0, len(graphs))
+	for _, graph := range graphs {
+		ids = append(ids, graph.ID)
+	}
+	return ids
+}
+
+func serviceIDs(services []models.Service) []int {
+	ids := make([]int, 0, len(services))
+	for _, service := range services {
+		ids = append(ids, service.ID)
+	}
+	return ids
+}
+
+func relationIDs(relations []models.Relation) []int {
+	ids := make([]int, 0, len(relations))
+	for _, relation := range relations {
+		ids = append(ids, relation.ID)
+	}
+	return ids
+}
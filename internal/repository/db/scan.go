@@ -0,0 +1,130 @@
+package db
+
+import (
+	"encoding/json"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+	"github.com/lib/pq"
+)
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+const serviceColumns = `
+	id,
+	graph_id,
+	name,
+	description,
+	x,
+	y,
+	group_id,
+	catalog_id,
+	kind,
+	owner_team,
+	tags,
+	attributes,
+	docs_url,
+	runbook_url,
+	repo_url
+`
+
+func scanService(row scanner) (models.Service, error) {
+	var service models.Service
+	var attributes []byte
+	err := row.Scan(
+		&service.ID, &service.GraphID, &service.Name, &service.Description, &service.X, &service.Y,
+		&service.GroupID, &service.CatalogID, &service.Kind, &service.OwnerTeam, pq.Array(&service.Tags), &attributes,
+		&service.DocsURL, &service.RunbookURL, &service.RepoURL,
+	)
+	if err != nil {
+		return models.Service{}, err
+	}
+	err = json.Unmarshal(attributes, &service.Attributes)
+	if err != nil {
+		return models.Service{}, err
+	}
+	return service, nil
+}
+
+const relationColumns = `
+	id,
+	graph_id,
+	name,
+	description,
+	from_service,
+	to_service,
+	protocol,
+	async,
+	bidirectional,
+	criticality,
+	expected_rps,
+	expected_latency_ms
+`
+
+func scanRelation(row scanner) (models.Relation, error) {
+	var relation models.Relation
+	err := row.Scan(
+		&relation.ID, &relation.GraphID, &relation.Name, &relation.Description, &relation.FromService, &relation.ToService,
+		&relation.Protocol, &relation.Async, &relation.Bidirectional, &relation.Criticality,
+		&relation.ExpectedRPS, &relation.ExpectedLatencyMS,
+	)
+	return relation, err
+}
+
+const catalogServiceColumns = `
+	id,
+	project_id,
+	name,
+	description,
+	kind,
+	owner_team,
+	tags,
+	attributes,
+	docs_url,
+	runbook_url,
+	repo_url
+`
+
+func scanCatalogService(row scanner) (models.CatalogService, error) {
+	var service models.CatalogService
+	var attributes []byte
+	err := row.Scan(
+		&service.ID, &service.ProjectID, &service.Name, &service.Description,
+		&service.Kind, &service.OwnerTeam, pq.Array(&service.Tags), &attributes,
+		&service.DocsURL, &service.RunbookURL, &service.RepoURL,
+	)
+	if err != nil {
+		return models.CatalogService{}, err
+	}
+	err = json.Unmarshal(attributes, &service.Attributes)
+	if err != nil {
+		return models.CatalogService{}, err
+	}
+	return service, nil
+}
+
+// stringArray keeps nil slices from turning into NULL arrays
+func stringArray(values []string) any {
+	if values == nil {
+		values = []string{}
+	}
+	return pq.Array(values)
+}
+
+func intArray(values []int) any {
+	res := make(pq.Int64Array, 0, len(values))
+	for _, v := range values {
+		res = append(res, int64(v))
+	}
+	return res
+}
+
+// marshalAttributes returns a string, lib/pq would send []byte as bytea
+func marshalAttributes(attributes map[string]string) (string, error) {
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	bytes, err := json.Marshal(attributes)
+	return string(bytes), err
+}
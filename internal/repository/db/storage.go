@@ -3,34 +3,96 @@ package db
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
 
+	"github.com/hse-telescope/core/internal/metrics"
 	"github.com/hse-telescope/core/internal/repository/models"
-	"github.com/hse-telescope/tracer"
 	"github.com/hse-telescope/utils/db/psql"
 	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type DB struct {
-	db *sql.DB
+	db   *sql.DB
+	conf Config
 }
 
-func New(dbURL string, migrationsPath string) (DB, error) {
-	db, err := sql.Open("postgres", dbURL)
+// startSpan starts the span of a storage method, times the method and bounds
+// it by the query timeout, which is released when the span ends
+func (s DB) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	ctx, cancel := context.WithTimeout(ctx, s.conf.QueryTimeout)
+	ctx, span := metrics.StartStorageSpan(ctx, "postgres", name)
+	return ctx, cancelSpan{Span: span, cancel: cancel}
+}
+
+type cancelSpan struct {
+	trace.Span
+	cancel context.CancelFunc
+}
+
+func (s cancelSpan) End(options ...trace.SpanEndOption) {
+	s.Span.End(options...)
+	s.cancel()
+}
+
+// New connects to Postgres and migrates the schema. Postgres may still be
+// starting along with core, so failed connections are retried until the
+// connect timeout.
+func New(conf Config) (DB, error) {
+	conf = conf.withDefaults()
+	db, err := sql.Open("postgres", conf.GetDBURL())
 	if err != nil {
 		return DB{}, err
 	}
-	err = db.Ping()
+	db.SetMaxOpenConns(conf.MaxOpenConns)
+	db.SetMaxIdleConns(conf.MaxIdleConns)
+	db.SetConnMaxLifetime(conf.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(conf.ConnMaxIdleTime)
+
+	err = connect(db, conf)
 	if err != nil {
+		db.Close() // nolint:errcheck
 		return DB{}, err
 	}
-	psql.MigrateDB(db, migrationsPath, psql.PGDriver)
+	psql.MigrateDB(db, conf.MigrationsPath, psql.PGDriver)
 	return DB{
-		db: db,
+		db:   db,
+		conf: conf,
 	}, nil
 }
 
+func connect(db *sql.DB, conf Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), conf.ConnectTimeout)
+	defer cancel()
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+		backoff := conf.connectBackoff(attempt)
+		slog.Warn("failed to connect to postgres", "attempt", attempt, "retry_in", backoff, "error", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return fmt.Errorf("postgres is unreachable after %s: %w", conf.ConnectTimeout, err)
+		}
+	}
+}
+
+// Stats of the connection pool
+func (s DB) Stats() sql.DBStats {
+	return s.db.Stats()
+}
+
+// Close closes the connection pool once the queries in flight are done
+func (s DB) Close() error {
+	return s.db.Close()
+}
+
 func (s DB) GetProjects(ctx context.Context) ([]models.Project, error) {
-	ctx, span := tracer.Start(ctx, "storage/GetProjects")
+	ctx, span := s.startSpan(ctx, "storage/GetProjects")
 	defer span.End()
 
 	q := `
@@ -39,7 +101,7 @@ func (s DB) GetProjects(ctx context.Context) ([]models.Project, error) {
 			name
 		FROM projects
 	`
-	rows, err := s.db.QueryContext(ctx, q)
+	rows, err := s.conn(ctx).QueryContext(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -51,21 +113,36 @@ func (s DB) GetProjects(ctx context.Context) ([]models.Project, error) {
 	return projects, nil
 }
 
+func (s DB) GetProject(ctx context.Context, project_id int) (models.Project, error) {
+	ctx, span := s.startSpan(ctx, "storage/GetProject")
+	defer span.End()
+
+	q := `
+		SELECT id, name FROM projects WHERE id = $1
+	`
+	var project models.Project
+	err := s.conn(ctx).QueryRowContext(ctx, q, project_id).Scan(&project.ID, &project.Name)
+	if err != nil {
+		return models.Project{}, err
+	}
+	return project, nil
+}
+
 func (s DB) CreateProject(ctx context.Context, project models.Project) (models.Project, error) {
-	ctx, span := tracer.Start(ctx, "storage/CreateProject")
+	ctx, span := s.startSpan(ctx, "storage/CreateProject")
 	defer span.End()
 
 	q := `
 		INSERT INTO projects (name) VALUES ($1) RETURNING id
 	`
 	var newID int
-	err := s.db.QueryRowContext(ctx, q, project.Name).Scan(&newID)
+	err := s.conn(ctx).QueryRowContext(ctx, q, project.Name).Scan(&newID)
 	project.ID = newID
 	return project, err
 }
 
 func (s DB) UpdateProject(ctx context.Context, project_id int, project models.Project) error {
-	ctx, span := tracer.Start(ctx, "storage/UpdateProject")
+	ctx, span := s.startSpan(ctx, "storage/UpdateProject")
 	defer span.End()
 
 	q := `
@@ -74,12 +151,11 @@ func (s DB) UpdateProject(ctx context.Context, project_id int, project models.Pr
         WHERE id = $2
     `
 
-	_, err := s.db.ExecContext(ctx, q, project.Name, project_id)
-	return err
+	return updated(s.conn(ctx).ExecContext(ctx, q, project.Name, project_id))
 }
 
 func (s DB) DeleteProject(ctx context.Context, project_id int) error {
-	ctx, span := tracer.Start(ctx, "storage/DeleteProject")
+	ctx, span := s.startSpan(ctx, "storage/DeleteProject")
 	defer span.End()
 
 	q := `
@@ -87,36 +163,36 @@ func (s DB) DeleteProject(ctx context.Context, project_id int) error {
         WHERE id = $1
     `
 
-	_, err := s.db.ExecContext(ctx, q, project_id)
+	_, err := s.conn(ctx).ExecContext(ctx, q, project_id)
 	return err
 }
 
 func (s DB) CreateGraph(ctx context.Context, graph models.Graph) (models.Graph, error) {
-	ctx, span := tracer.Start(ctx, "storage/CreateGraph")
+	ctx, span := s.startSpan(ctx, "storage/CreateGraph")
 	defer span.End()
 
 	q := `
 		INSERT INTO graphs (project_id, name) VALUES ($1, $2) RETURNING id
 	`
 	var newID int
-	err := s.db.QueryRowContext(ctx, q, graph.ProjectID, graph.Name).Scan(&newID)
+	err := s.conn(ctx).QueryRowContext(ctx, q, graph.ProjectID, graph.Name).Scan(&newID)
 	graph.ID = newID
 	return graph, err
 }
 
 func (s DB) DeleteGraph(ctx context.Context, graph_id int) error {
-	ctx, span := tracer.Start(ctx, "storage/DeleteGraph")
+	ctx, span := s.startSpan(ctx, "storage/DeleteGraph")
 	defer span.End()
 
 	q := `
 		DELETE FROM graphs WHERE id = $1
 	`
-	_, err := s.db.ExecContext(ctx, q, graph_id)
+	_, err := s.conn(ctx).ExecContext(ctx, q, graph_id)
 	return err
 }
 
 func (s DB) UpdateGraphServices(ctx context.Context, graph_id int, services []models.Service) error {
-	ctx, span := tracer.Start(ctx, "storage/UpdateGraphServices")
+	ctx, span := s.startSpan(ctx, "storage/UpdateGraphServices")
 	defer span.End()
 
 	for _, service := range services {
@@ -130,7 +206,7 @@ func (s DB) UpdateGraphServices(ctx context.Context, graph_id int, services []mo
 }
 
 func (s DB) UpdateGraphRelations(ctx context.Context, graph_id int, relations []models.Relation) error {
-	ctx, span := tracer.Start(ctx, "storage/UpdateGraphRelations")
+	ctx, span := s.startSpan(ctx, "storage/UpdateGraphRelations")
 	defer span.End()
 
 	for _, relation := range relations {
@@ -144,7 +220,7 @@ func (s DB) UpdateGraphRelations(ctx context.Context, graph_id int, relations []
 }
 
 func (s DB) UpdateGraph(ctx context.Context, graph_id int, graph models.Graph) error {
-	ctx, span := tracer.Start(ctx, "storage/UpdateGraph")
+	ctx, span := s.startSpan(ctx, "storage/UpdateGraph")
 	defer span.End()
 
 	q := `
@@ -152,12 +228,26 @@ func (s DB) UpdateGraph(ctx context.Context, graph_id int, graph models.Graph) e
         SET project_id = $1, name = $2
         WHERE id = $3
 	`
-	_, err := s.db.ExecContext(ctx, q, graph.ProjectID, graph.Name, graph_id)
-	return err
+	return updated(s.conn(ctx).ExecContext(ctx, q, graph.ProjectID, graph.Name, graph_id))
+}
+
+func (s DB) GetGraph(ctx context.Context, graph_id int) (models.Graph, error) {
+	ctx, span := s.startSpan(ctx, "storage/GetGraph")
+	defer span.End()
+
+	q := `
+		SELECT id, project_id, name FROM graphs WHERE id = $1
+	`
+	var graph models.Graph
+	err := s.conn(ctx).QueryRowContext(ctx, q, graph_id).Scan(&graph.ID, &graph.ProjectID, &graph.Name)
+	if err != nil {
+		return models.Graph{}, err
+	}
+	return graph, nil
 }
 
 func (s DB) GetProjectGraphs(ctx context.Context, project_id int) ([]models.Graph, error) {
-	ctx, span := tracer.Start(ctx, "storage/GetProjectGraphs")
+	ctx, span := s.startSpan(ctx, "storage/GetProjectGraphs")
 	defer span.End()
 
 	q := `
@@ -167,7 +257,7 @@ func (s DB) GetProjectGraphs(ctx context.Context, project_id int) ([]models.Grap
 			name
 		FROM graphs WHERE project_id = $1
 	`
-	rows, err := s.db.QueryContext(ctx, q, project_id)
+	rows, err := s.conn(ctx).QueryContext(ctx, q, project_id)
 	if err != nil {
 		return nil, err
 	}
@@ -180,14 +270,14 @@ func (s DB) GetProjectGraphs(ctx context.Context, project_id int) ([]models.Grap
 }
 
 func (s DB) GetService(ctx context.Context, service_id int) (models.Service, error) {
-	ctx, span := tracer.Start(ctx, "storage/GetService")
+	ctx, span := s.startSpan(ctx, "storage/GetService")
 	defer span.End()
 
 	q := `
-		SELECT id, graph_id, name, description, X, Y FROM services WHERE id = $1
+		SELECT ` + serviceColumns + ` FROM services WHERE id = $1
 	`
-	var service models.Service
-	if err := s.db.QueryRowContext(ctx, q, service_id).Scan(&service); err != nil {
+	service, err := scanService(s.conn(ctx).QueryRowContext(ctx, q, service_id))
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return models.Service{}, err
 		}
@@ -196,71 +286,108 @@ func (s DB) GetService(ctx context.Context, service_id int) (models.Service, err
 	return service, nil
 }
 
-func (s DB) GetGraphServices(ctx context.Context, graph_id int) ([]models.Service, error) {
-	ctx, span := tracer.Start(ctx, "storage/GetGraphServices")
+func (s DB) GetGraphServices(ctx context.Context, graph_id int, filter models.ServiceFilter) ([]models.Service, error) {
+	ctx, span := s.startSpan(ctx, "storage/GetGraphServices")
 	defer span.End()
 
 	q := `
-		SELECT
-			id,
-			graph_id,
-			name,
-			description,
-			x,
-			y
-		FROM services WHERE graph_id = $1
+		SELECT ` + serviceColumns + `
+		FROM services
+		WHERE graph_id = $1
+			AND ($2::text = '' OR kind = $2)
+			AND ($3::text = '' OR owner_team = $3)
+			AND tags @> $4
+			AND attributes @> $5
 	`
-	rows, err := s.db.QueryContext(ctx, q, graph_id)
+	attributes, err := marshalAttributes(filter.Attributes)
 	if err != nil {
 		return nil, err
 	}
-	services := make([]models.Service, 0)
-	err = sqlx.StructScan(rows, &services)
+	rows, err := s.conn(ctx).QueryContext(ctx, q, graph_id, filter.Kind, filter.OwnerTeam, stringArray(filter.Tags), attributes)
 	if err != nil {
 		return nil, err
 	}
-	return services, nil
+	defer rows.Close()
+
+	services := make([]models.Service, 0)
+	for rows.Next() {
+		service, err := scanService(rows)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, service)
+	}
+	return services, rows.Err()
 }
 
 func (s DB) UpdateService(ctx context.Context, service_id int, service models.Service) error {
-	ctx, span := tracer.Start(ctx, "storage/UpdateService")
+	ctx, span := s.startSpan(ctx, "storage/UpdateService")
 	defer span.End()
 
 	q := `
 		UPDATE services
-        SET graph_id = $1, name = $2, description = $3, x = $4, y = $5
-        WHERE id = $6
+        SET graph_id = $1, name = $2, description = $3, x = $4, y = $5,
+            kind = $6, owner_team = $7, tags = $8, attributes = $9,
+            docs_url = $10, runbook_url = $11, repo_url = $12, group_id = $13, catalog_id = $14
+        WHERE id = $15
 	`
-	_, err := s.db.ExecContext(ctx, q, service.GraphID, service.Name, service.Description, service.X, service.Y, service_id)
-	return err
+	service, err := s.inheritCatalogService(ctx, service)
+	if err != nil {
+		return err
+	}
+	attributes, err := marshalAttributes(service.Attributes)
+	if err != nil {
+		return err
+	}
+	return updated(s.conn(ctx).ExecContext(ctx, q,
+		service.GraphID, service.Name, service.Description, service.X, service.Y,
+		service.Kind, service.OwnerTeam, stringArray(service.Tags), attributes,
+		service.DocsURL, service.RunbookURL, service.RepoURL, service.GroupID, service.CatalogID, service_id,
+	))
 }
 
 func (s DB) DeleteService(ctx context.Context, service_id int) error {
-	ctx, span := tracer.Start(ctx, "storage/DeleteService")
+	ctx, span := s.startSpan(ctx, "storage/DeleteService")
 	defer span.End()
 
 	q := `
 		DELETE FROM services WHERE id = $1
 	`
-	_, err := s.db.ExecContext(ctx, q, service_id)
+	_, err := s.conn(ctx).ExecContext(ctx, q, service_id)
 	return err
 }
 
 func (s DB) CreateService(ctx context.Context, service models.Service) (models.Service, error) {
-	ctx, span := tracer.Start(ctx, "storage/CreateService")
+	ctx, span := s.startSpan(ctx, "storage/CreateService")
 	defer span.End()
 
 	q := `
-		INSERT INTO services (graph_id, name, description, x, y) VALUES ($1, $2, $3, $4, $5) RETURNING id
+		INSERT INTO services (
+			graph_id, name, description, x, y,
+			kind, owner_team, tags, attributes,
+			docs_url, runbook_url, repo_url, group_id, catalog_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id
 	`
+	service, err := s.inheritCatalogService(ctx, service)
+	if err != nil {
+		return models.Service{}, err
+	}
+	attributes, err := marshalAttributes(service.Attributes)
+	if err != nil {
+		return models.Service{}, err
+	}
 	var newID int
-	err := s.db.QueryRowContext(ctx, q, service.GraphID, service.Name, service.Description, service.X, service.Y).Scan(&newID)
+	err = s.conn(ctx).QueryRowContext(ctx, q,
+		service.GraphID, service.Name, service.Description, service.X, service.Y,
+		service.Kind, service.OwnerTeam, stringArray(service.Tags), attributes,
+		service.DocsURL, service.RunbookURL, service.RepoURL, service.GroupID, service.CatalogID,
+	).Scan(&newID)
 	service.ID = newID
 	return service, err
 }
 
 func (s DB) CreateServices(ctx context.Context, graph_id int, services []models.Service) ([]int, error) {
-	ctx, span := tracer.Start(ctx, "storage/CreateServices")
+	ctx, span := s.startSpan(ctx, "storage/CreateServices")
 	defer span.End()
 
 	var res []int
@@ -276,14 +403,32 @@ func (s DB) CreateServices(ctx context.Context, graph_id int, services []models.
 }
 
 func (s DB) GetRelation(ctx context.Context, relation_id int) (models.Relation, error) {
-	ctx, span := tracer.Start(ctx, "storage/GetRelation")
+	ctx, span := s.startSpan(ctx, "storage/GetRelation")
 	defer span.End()
 
 	q := `
-		SELECT id, graph_id, name, description, from_service, to_service FROM relations WHERE id = $1
+		SELECT
+			id,
+			graph_id,
+			name,
+			description,
+			from_service,
+			to_service,
+			protocol,
+			async,
+			bidirectional,
+			criticality,
+			expected_rps,
+			expected_latency_ms
+		FROM relations WHERE id = $1
 	`
 	var relation models.Relation
-	if err := s.db.QueryRowContext(ctx, q, relation_id).Scan(&relation); err != nil {
+	err := s.conn(ctx).QueryRowContext(ctx, q, relation_id).Scan(
+		&relation.ID, &relation.GraphID, &relation.Name, &relation.Description, &relation.FromService, &relation.ToService,
+		&relation.Protocol, &relation.Async, &relation.Bidirectional, &relation.Criticality,
+		&relation.ExpectedRPS, &relation.ExpectedLatencyMS,
+	)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return models.Relation{}, err
 		}
@@ -293,7 +438,7 @@ func (s DB) GetRelation(ctx context.Context, relation_id int) (models.Relation,
 }
 
 func (s DB) GetGraphRelations(ctx context.Context, graph_id int) ([]models.Relation, error) {
-	ctx, span := tracer.Start(ctx, "storage/GetGraphRelations")
+	ctx, span := s.startSpan(ctx, "storage/GetGraphRelations")
 	defer span.End()
 
 	q := `
@@ -303,10 +448,16 @@ func (s DB) GetGraphRelations(ctx context.Context, graph_id int) ([]models.Relat
 			name,
 			description,
 			from_service,
-			to_service
+			to_service,
+			protocol,
+			async,
+			bidirectional,
+			criticality,
+			expected_rps,
+			expected_latency_ms
 		FROM relations WHERE graph_id = $1
 	`
-	rows, err := s.db.QueryContext(ctx, q, graph_id)
+	rows, err := s.conn(ctx).QueryContext(ctx, q, graph_id)
 	if err != nil {
 		return nil, err
 	}
@@ -319,20 +470,27 @@ func (s DB) GetGraphRelations(ctx context.Context, graph_id int) ([]models.Relat
 }
 
 func (s DB) CreateRelation(ctx context.Context, relation models.Relation) (models.Relation, error) {
-	ctx, span := tracer.Start(ctx, "storage/CreateRelation")
+	ctx, span := s.startSpan(ctx, "storage/CreateRelation")
 	defer span.End()
 
 	q := `
-		INSERT INTO relations (graph_id, name, description, from_service, to_service) VALUES ($1, $2, $3, $4, $5) RETURNING id
+		INSERT INTO relations (
+			graph_id, name, description, from_service, to_service,
+			protocol, async, bidirectional, criticality, expected_rps, expected_latency_ms
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id
 	`
 	var newID int
-	err := s.db.QueryRowContext(ctx, q, relation.GraphID, relation.Name, relation.Description, relation.FromService, relation.ToService).Scan(&newID)
+	err := s.conn(ctx).QueryRowContext(ctx, q,
+		relation.GraphID, relation.Name, relation.Description, relation.FromService, relation.ToService,
+		relation.Protocol, relation.Async, relation.Bidirectional, relation.Criticality,
+		relation.ExpectedRPS, relation.ExpectedLatencyMS,
+	).Scan(&newID)
 	relation.ID = newID
 	return relation, err
 }
 
 func (s DB) CreateRelations(ctx context.Context, graph_id int, relations []models.Relation) error {
-	ctx, span := tracer.Start(ctx, "storage/CreateRelations")
+	ctx, span := s.startSpan(ctx, "storage/CreateRelations")
 	defer span.End()
 
 	for _, relation := range relations {
@@ -346,25 +504,46 @@ func (s DB) CreateRelations(ctx context.Context, graph_id int, relations []model
 }
 
 func (s DB) UpdateRelation(ctx context.Context, relation_id int, relation models.Relation) error {
-	ctx, span := tracer.Start(ctx, "storage/UpdateRelation")
+	ctx, span := s.startSpan(ctx, "storage/UpdateRelation")
 	defer span.End()
 
 	q := `
 		UPDATE relations
-		SET graph_id = $1, name = $2, description = $3, from_service = $4, to_service = $5
-		WHERE id = $6
+		SET graph_id = $1, name = $2, description = $3, from_service = $4, to_service = $5,
+			protocol = $6, async = $7, bidirectional = $8, criticality = $9,
+			expected_rps = $10, expected_latency_ms = $11
+		WHERE id = $12
 	`
-	_, err := s.db.ExecContext(ctx, q, relation.GraphID, relation.Name, relation.Description, relation.FromService, relation.ToService, relation_id)
-	return err
+	return updated(s.conn(ctx).ExecContext(ctx, q,
+		relation.GraphID, relation.Name, relation.Description, relation.FromService, relation.ToService,
+		relation.Protocol, relation.Async, relation.Bidirectional, relation.Criticality,
+		relation.ExpectedRPS, relation.ExpectedLatencyMS, relation_id,
+	))
 }
 
 func (s DB) DeleteRelation(ctx context.Context, relation_id int) error {
-	ctx, span := tracer.Start(ctx, "storage/DeleteRelation")
+	ctx, span := s.startSpan(ctx, "storage/DeleteRelation")
 	defer span.End()
 
 	q := `
 		DELETE FROM relations WHERE id = $1
 	`
-	_, err := s.db.ExecContext(ctx, q, relation_id)
+	_, err := s.conn(ctx).ExecContext(ctx, q, relation_id)
 	return err
 }
+
+func (s DB) CountEntities(ctx context.Context) (models.EntityCounts, error) {
+	ctx, span := s.startSpan(ctx, "storage/CountEntities")
+	defer span.End()
+
+	q := `
+		SELECT
+			(SELECT COUNT(*) FROM projects) AS projects,
+			(SELECT COUNT(*) FROM graphs) AS graphs,
+			(SELECT COUNT(*) FROM services) AS services,
+			(SELECT COUNT(*) FROM relations) AS relations
+	`
+	var counts models.EntityCounts
+	err := s.conn(ctx).QueryRowContext(ctx, q).Scan(&counts.Projects, &counts.Graphs, &counts.Services, &counts.Relations)
+	return counts, err
+}
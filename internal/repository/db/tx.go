@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type txKey struct{}
+
+// querier runs the queries of a storage method, on the pool or on the
+// transaction the method takes part in
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction of ctx, or the pool outside of one
+func (s DB) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return s.db
+}
+
+// InTx runs fn in a transaction, the storage methods called with the context
+// fn gets take part in it. fn may run more than once, see inTx.
+func (s DB) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// inTx runs fn in a transaction and commits it. A transaction Postgres aborts
+// on a serialization failure or a deadlock is run again from the start, so fn
+// must not keep state from a previous run. Inside the transaction of ctx fn
+// joins it, the outer transaction commits and retries.
+func (s DB) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(tx)
+	}
+	for attempt := 0; ; attempt++ {
+		err := s.runTx(ctx, fn)
+		if !retryable(err) || attempt >= s.conf.SerializationRetries {
+			return err
+		}
+		slog.WarnContext(ctx, "retrying transaction", "attempt", attempt+1, "error", err)
+
+		// A random delay keeps the conflicting transactions from meeting again
+		delay := time.Duration(rand.Int64N(int64(10*time.Millisecond) << attempt))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+func (s DB) runTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// retryable tells whether the transaction failed on a serialization failure
+// or a deadlock, those succeed when run again
+func retryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == "40001" || pqErr.Code == "40P01"
+}
+
+// updated turns an UPDATE that matched no row into sql.ErrNoRows
+func updated(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func (s DB) GetWebhook(ctx context.Context, webhook_id int) (models.Webhook, error) {
+	ctx, span := s.startSpan(ctx, "storage/GetWebhook")
+	defer span.End()
+
+	q := `
+		SELECT id, project_id, url, secret, events FROM webhooks WHERE id = $1
+	`
+	var webhook models.Webhook
+	err := s.conn(ctx).QueryRowContext(ctx, q, webhook_id).Scan(
+		&webhook.ID, &webhook.ProjectID, &webhook.URL, &webhook.Secret, pq.Array(&webhook.Events),
+	)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (s DB) GetProjectWebhooks(ctx context.Context, project_id int) ([]models.Webhook, error) {
+	ctx, span := s.startSpan(ctx, "storage/GetProjectWebhooks")
+	defer span.End()
+
+	q := `
+		SELECT
+			id,
+			project_id,
+			url,
+			secret,
+			events
+		FROM webhooks WHERE project_id = $1
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, project_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]models.Webhook, 0)
+	for rows.Next() {
+		var webhook models.Webhook
+		err = rows.Scan(&webhook.ID, &webhook.ProjectID, &webhook.URL, &webhook.Secret, pq.Array(&webhook.Events))
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+func (s DB) CreateWebhook(ctx context.Context, webhook models.Webhook) (models.Webhook, error) {
+	ctx, span := s.startSpan(ctx, "storage/CreateWebhook")
+	defer span.End()
+
+	q := `
+		INSERT INTO webhooks (project_id, url, secret, events) VALUES ($1, $2, $3, $4) RETURNING id
+	`
+	var newID int
+	err := s.conn(ctx).QueryRowContext(ctx, q, webhook.ProjectID, webhook.URL, webhook.Secret, stringArray(webhook.Events)).Scan(&newID)
+	webhook.ID = newID
+	return webhook, err
+}
+
+// UpdateWebhook keeps the stored secret when webhook has none, secrets are
+// never handed out so clients cannot send them back
+func (s DB) UpdateWebhook(ctx context.Context, webhook_id int, webhook models.Webhook) error {
+	ctx, span := s.startSpan(ctx, "storage/UpdateWebhook")
+	defer span.End()
+
+	q := `
+		UPDATE webhooks
+		SET url = $1, secret = COALESCE(NULLIF($2, ''), secret), events = $3
+		WHERE id = $4
+	`
+	return updated(s.conn(ctx).ExecContext(ctx, q, webhook.URL, webhook.Secret, stringArray(webhook.Events), webhook_id))
+}
+
+func (s DB) DeleteWebhook(ctx context.Context, webhook_id int) error {
+	ctx, span := s.startSpan(ctx, "storage/DeleteWebhook")
+	defer span.End()
+
+	q := `
+		DELETE FROM webhooks WHERE id = $1
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, webhook_id)
+	return err
+}
+
+func (s DB) CreateWebhookEvents(ctx context.Context, events []models.WebhookEvent) error {
+	ctx, span := s.startSpan(ctx, "storage/CreateWebhookEvents")
+	defer span.End()
+
+	q := `
+		INSERT INTO webhook_outbox (webhook_id, event, payload) VALUES ($1, $2, $3)
+	`
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, event := range events {
+			_, err := tx.ExecContext(ctx, q, event.WebhookID, event.Event, string(event.Payload))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ClaimWebhookEvents picks up to limit pending events that are due and pushes
+// their next attempt forward by lease, so that concurrent dispatchers skip them.
+func (s DB) ClaimWebhookEvents(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookEvent, error) {
+	ctx, span := s.startSpan(ctx, "storage/ClaimWebhookEvents")
+	defer span.End()
+
+	q := `
+		UPDATE webhook_outbox
+		SET next_attempt_at = now() + $2 * interval '1 millisecond'
+		WHERE id IN (
+			SELECT id FROM webhook_outbox
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING
+			id,
+			webhook_id,
+			event,
+			payload,
+			status,
+			attempts,
+			next_attempt_at,
+			created_at
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]models.WebhookEvent, 0)
+	err = sqlx.StructScan(rows, &events)
+	if err != nil {
+		return nil, err
+	}
+	return events, rows.Err()
+}
+
+func (s DB) UpdateWebhookEvent(ctx context.Context, event models.WebhookEvent) error {
+	ctx, span := s.startSpan(ctx, "storage/UpdateWebhookEvent")
+	defer span.End()
+
+	q := `
+		UPDATE webhook_outbox
+		SET status = $1, attempts = $2, next_attempt_at = $3
+		WHERE id = $4
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, event.Status, event.Attempts, event.NextAttemptAt, event.ID)
+	return err
+}
+
+func (s DB) CreateWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+	ctx, span := s.startSpan(ctx, "storage/CreateWebhookDelivery")
+	defer span.End()
+
+	q := `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event, attempt, status_code, error, duration_ms)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q,
+		delivery.WebhookID, delivery.EventID, delivery.Event, delivery.Attempt,
+		delivery.StatusCode, delivery.Error, delivery.DurationMS,
+	)
+	return err
+}
+
+func (s DB) GetWebhookDeliveries(ctx context.Context, webhook_id int) ([]models.WebhookDelivery, error) {
+	ctx, span := s.startSpan(ctx, "storage/GetWebhookDeliveries")
+	defer span.End()
+
+	q := `
+		SELECT
+			id,
+			webhook_id,
+			event_id,
+			event,
+			attempt,
+			status_code,
+			error,
+			duration_ms,
+			created_at
+		FROM webhook_deliveries WHERE webhook_id = $1
+		ORDER BY id DESC
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, webhook_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	err = sqlx.StructScan(rows, &deliveries)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,315 @@
+package facade
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Graph reads kept in the cache, they label the hit and miss metrics
+const (
+	readGraph     = "graph"
+	readServices  = "services"
+	readRelations = "relations"
+	readGroups    = "groups"
+)
+
+var (
+	cacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "cache",
+		Name:      "hits_total",
+		Help:      "Graph reads served from the cache.",
+	}, []string{"read"})
+	cacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "cache",
+		Name:      "misses_total",
+		Help:      "Graph reads that went to the storage.",
+	}, []string{"read"})
+)
+
+// Cache keeps serialized graph reads, every key belongs to a graph and
+// Invalidate drops all keys of the graphs at once. A backend may evict keys
+// on its own at any time and has to be safe for concurrent use.
+type Cache interface {
+	Get(ctx context.Context, graph_id int, key string) ([]byte, bool)
+	Set(ctx context.Context, graph_id int, key string, value []byte)
+	Invalidate(ctx context.Context, graph_ids ...int)
+}
+
+// CacheConfig sets up the in-process cache, Size is the number of graphs it
+// keeps and 0 disables caching. Every replica keeps its own cache and only
+// sees its own writes, so with several replicas it has to stay off unless
+// the facade is given a shared backend.
+type CacheConfig struct {
+	Size int `yaml:"size"`
+}
+
+// NewCache returns the in-process cache or nil when it is disabled
+func NewCache(conf CacheConfig) Cache {
+	if conf.Size <= 0 {
+		return nil
+	}
+	return NewLRU(conf.Size)
+}
+
+// graphCache reads through a Cache. A read that started before an
+// invalidation of its graph does not store its result, otherwise data loaded
+// before a write could land in the cache after the write invalidated it.
+// Only graphs with reads in flight are tracked, so deleted and evicted graphs
+// leave nothing behind.
+type graphCache struct {
+	backend Cache
+
+	mu    sync.Mutex
+	loads map[int]*loads
+}
+
+// loads are the reads of a graph in flight, generation counts the
+// invalidations of the graph since the first of them started
+type loads struct {
+	count      int
+	generation uint64
+}
+
+func newGraphCache(backend Cache) *graphCache {
+	if backend == nil {
+		return nil
+	}
+	return &graphCache{
+		backend: backend,
+		loads:   make(map[int]*loads),
+	}
+}
+
+// start registers a read of the graph and returns its generation
+func (c *graphCache) start(graph_id int) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.loads[graph_id]
+	if !ok {
+		l = &loads{}
+		c.loads[graph_id] = l
+	}
+	l.count++
+	return l.generation
+}
+
+// finish ends a read started at generation and caches its value unless it
+// is nil or the graph has been invalidated since
+func (c *graphCache) finish(ctx context.Context, graph_id int, key string, generation uint64, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l := c.loads[graph_id]
+	if value != nil && l.generation == generation {
+		c.backend.Set(ctx, graph_id, key, value)
+	}
+	l.count--
+	if l.count == 0 {
+		delete(c.loads, graph_id)
+	}
+}
+
+func (c *graphCache) invalidate(ctx context.Context, graph_ids ...int) {
+	if c == nil || len(graph_ids) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, graph_id := range graph_ids {
+		if l, ok := c.loads[graph_id]; ok {
+			l.generation++
+		}
+	}
+	c.backend.Invalidate(ctx, graph_ids...)
+}
+
+// readThrough returns the cached result of load for the graph, calling load
+// and caching its result on a miss. Errors are never cached.
+func readThrough[T any](ctx context.Context, c *graphCache, graph_id int, read string, key string, load func() (T, error)) (T, error) {
+	if c == nil {
+		return load()
+	}
+	key = read + key
+	if data, ok := c.backend.Get(ctx, graph_id, key); ok {
+		var value T
+		err := json.Unmarshal(data, &value)
+		if err == nil {
+			cacheHits.WithLabelValues(read).Inc()
+			return value, nil
+		}
+		slog.WarnContext(ctx, "failed to decode cached graph read", "graph_id", graph_id, "key", key, "error", err)
+	}
+	cacheMisses.WithLabelValues(read).Inc()
+
+	var data []byte
+	generation := c.start(graph_id)
+	defer func() {
+		c.finish(ctx, graph_id, key, generation, data)
+	}()
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	data, err = json.Marshal(value)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to encode graph read", "graph_id", graph_id, "key", key, "error", err)
+	}
+	return value, nil
+}
+
+// filterKey tells apart cached service reads of a graph with different
+// filters
+func filterKey(filter models.ServiceFilter) string {
+	key, _ := json.Marshal(filter)
+	return string(key)
+}
+
+// The lookups below find the graphs a write is going to change before it is
+// made, they do nothing when caching is off. A failed lookup only costs
+// precision: the entity is gone or the write is going to fail as well.
+
+func (f Facade) projectGraphs(ctx context.Context, project_id int) []int {
+	if f.cache == nil {
+		return nil
+	}
+	graphs, err := f.storage.GetProjectGraphs(ctx, project_id)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to get graphs to invalidate", "project_id", project_id, "error", err)
+	}
+	return graphIDs(graphs)
+}
+
+func (f Facade) catalogGraphs(ctx context.Context, catalog_id int) []int {
+	if f.cache == nil {
+		return nil
+	}
+	graphs, err := f.storage.GetCatalogServiceGraphs(ctx, catalog_id)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to get graphs to invalidate", "catalog_id", catalog_id, "error", err)
+	}
+	return graphIDs(graphs)
+}
+
+func (f Facade) groupGraphs(ctx context.Context, group_id int) []int {
+	if f.cache == nil {
+		return nil
+	}
+	group, err := f.storage.GetGroup(ctx, group_id)
+	if err != nil {
+		return nil
+	}
+	return []int{group.GraphID}
+}
+
+// serviceGraphs returns graph_id along with the graphs the services are
+// moved out of
+func (f Facade) serviceGraphs(ctx context.Context, graph_id int, service_ids ...int) []int {
+	if f.cache == nil {
+		return nil
+	}
+	inGraph, _ := f.storage.GetGraphServices(ctx, graph_id, models.ServiceFilter{})
+	known := make(map[int]bool, len(inGraph))
+	for _, service := range inGraph {
+		known[service.ID] = true
+	}
+
+	graphs := []int{graph_id}
+	for _, service_id := range service_ids {
+		if known[service_id] {
+			continue
+		}
+		service, err := f.storage.GetService(ctx, service_id)
+		if err == nil && !slices.Contains(graphs, service.GraphID) {
+			graphs = append(graphs, service.GraphID)
+		}
+	}
+	return graphs
+}
+
+// relationGraphs returns graph_id along with the graphs the relations are
+// moved out of
+func (f Facade) relationGraphs(ctx context.Context, graph_id int, relation_ids ...int) []int {
+	if f.cache == nil {
+		return nil
+	}
+	inGraph, _ := f.storage.GetGraphRelations(ctx, graph_id)
+	known := make(map[int]bool, len(inGraph))
+	for _, relation := range inGraph {
+		known[relation.ID] = true
+	}
+
+	graphs := []int{graph_id}
+	for _, relation_id := range relation_ids {
+		if known[relation_id] {
+			continue
+		}
+		relation, err := f.storage.GetRelation(ctx, relation_id)
+		if err == nil && !slices.Contains(graphs, relation.GraphID) {
+			graphs = append(graphs, relation.GraphID)
+		}
+	}
+	return graphs
+}
+
+// planGraphs returns every graph an applied plan has changed
+func planGraphs(plan models.ProjectPlan) []int {
+	var graphs []int
+	for _, graph := range plan.DeleteGraphs {
+		graphs = append(graphs, graph.ID)
+	}
+	for _, graph := range plan.CreateGraphs {
+		graphs = append(graphs, graph.ID)
+	}
+	for _, service := range plan.DeleteServices {
+		graphs = append(graphs, service.GraphID)
+	}
+	for _, service := range plan.UpdateServices {
+		graphs = append(graphs, service.GraphID)
+	}
+	for _, planned := range plan.CreateServices {
+		graphs = append(graphs, planned.Service.GraphID)
+	}
+	for _, relation := range plan.DeleteRelations {
+		graphs = append(graphs, relation.GraphID)
+	}
+	for _, planned := range plan.CreateRelations {
+		graphs = append(graphs, planned.Relation.GraphID)
+	}
+	for _, planned := range plan.UpdateRelations {
+		graphs = append(graphs, planned.Relation.GraphID)
+	}
+	slices.Sort(graphs)
+	return slices.Compact(graphs)
+}
+
+func graphIDs(graphs []models.Graph) []int {
+	ids := make([]int, 0, len(graphs))
+	for _, graph := range graphs {
+		ids = append(ids, graph.ID)
+	}
+	return ids
+}
+
+func serviceIDs(services []models.Service) []int {
+	ids := make([]int, 0, len(services))
+	for _, service := range services {
+		ids = append(ids, service.ID)
+	}
+	return ids
+}
+
+func relationIDs(relations []models.Relation) []int {
+	ids := make([]int, 0, len(relations))
+	for _, relation := range relations {
+		ids = append(ids, relation.ID)
+	}
+	return ids
+}
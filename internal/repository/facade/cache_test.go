@@ -0,0 +1,57 @@
+package facade
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestReadThroughSkipsReadsInvalidatedInFlight(t *testing.T) {
+	ctx := context.Background()
+	c := newGraphCache(NewLRU(10))
+
+	_, err := readThrough(ctx, c, 1, readGraph, "", func() (string, error) {
+		c.invalidate(ctx, 1)
+		return "stale", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.backend.Get(ctx, 1, readGraph); ok {
+		t.Fatal("read invalidated while in flight is cached")
+	}
+
+	_, err = readThrough(ctx, c, 1, readGraph, "", func() (string, error) {
+		return "fresh", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := readThrough(ctx, c, 1, readGraph, "", func() (string, error) {
+		return "", errors.New("read is not served from the cache")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "fresh" {
+		t.Fatalf("got %q from the cache, want %q", got, "fresh")
+	}
+}
+
+func TestGraphCacheForgetsFinishedReads(t *testing.T) {
+	ctx := context.Background()
+	c := newGraphCache(NewLRU(1))
+
+	for graph_id := range 100 {
+		_, _ = readThrough(ctx, c, graph_id, readGraph, "", func() (int, error) {
+			return graph_id, nil
+		})
+		_, _ = readThrough(ctx, c, graph_id, readServices, "", func() (int, error) {
+			return 0, errors.New("storage is down")
+		})
+		c.invalidate(ctx, graph_id)
+	}
+	if len(c.loads) != 0 {
+		t.Fatalf("%d graphs are tracked with no reads in flight", len(c.loads))
+	}
+}
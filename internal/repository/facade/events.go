@@ -0,0 +1,145 @@
+package facade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+// Events published to project webhooks. There is no project.deleted event:
+// deleting a project removes its webhooks together with the pending outbox.
+const (
+	EventProjectCreated  = "project.created"
+	EventProjectUpdated  = "project.updated"
+	EventGraphCreated    = "graph.created"
+	EventGraphUpdated    = "graph.updated"
+	EventGraphDeleted    = "graph.deleted"
+	EventServiceCreated  = "service.created"
+	EventServiceUpdated  = "service.updated"
+	EventServiceDeleted  = "service.deleted"
+	EventRelationCreated = "relation.created"
+	EventRelationUpdated = "relation.updated"
+	EventRelationDeleted = "relation.deleted"
+)
+
+type eventPayload struct {
+	Event      string    `json:"event"`
+	ProjectID  int       `json:"project_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// MatchEvent reports whether event passes a webhook filter. An empty filter
+// matches everything, "*" matches everything and "graph.*" matches every
+// graph event.
+func MatchEvent(filter []string, event string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if f == "*" || f == event {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(f, ".*"); ok && strings.HasPrefix(event, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// outbox collects the events of a write. Their webhook rows are stored in
+// the transaction of the write, so an event is kept exactly when the change
+// it reports is; the broker hears of them once the write has committed.
+type outbox struct {
+	storage Storage
+	events  []events.Event
+}
+
+// write runs fn in a storage transaction together with the outbox rows of
+// the events fn publishes and hands the events to the broker after the
+// commit. The transaction may run fn more than once.
+func (f Facade) write(ctx context.Context, fn func(ctx context.Context, out *outbox) error) error {
+	var out *outbox
+	err := f.storage.InTx(ctx, func(ctx context.Context) error {
+		out = &outbox{storage: f.storage}
+		return fn(ctx, out)
+	})
+	if err != nil {
+		return err
+	}
+	for _, event := range out.events {
+		f.broker.Publish(event)
+	}
+	return nil
+}
+
+// publish queues an event per data item for the graph subscribers of the
+// broker and puts it into the outbox of every project webhook subscribed to
+// it. graph_id is 0 for project events.
+func (o *outbox) publish(ctx context.Context, project_id int, graph_id int, event string, data ...any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	webhooks, err := o.storage.GetProjectWebhooks(ctx, project_id)
+	if err != nil {
+		return fmt.Errorf("failed to get project webhooks: %w", err)
+	}
+
+	now := time.Now().UTC()
+	rows := make([]models.WebhookEvent, 0)
+	for _, d := range data {
+		o.events = append(o.events, events.Event{
+			Type:       event,
+			ProjectID:  project_id,
+			GraphID:    graph_id,
+			OccurredAt: now,
+			Data:       d,
+		})
+		payload, err := json.Marshal(eventPayload{
+			Event:      event,
+			ProjectID:  project_id,
+			OccurredAt: now,
+			Data:       d,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal webhook payload: %w", err)
+		}
+		for _, webhook := range webhooks {
+			if MatchEvent(webhook.Events, event) {
+				rows = append(rows, models.WebhookEvent{
+					WebhookID: webhook.ID,
+					Event:     event,
+					Payload:   payload,
+				})
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return o.storage.CreateWebhookEvents(ctx, rows)
+}
+
+func (o *outbox) publishGraph(ctx context.Context, graph_id int, event string, data ...any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	graph, err := o.storage.GetGraph(ctx, graph_id)
+	if err != nil {
+		return fmt.Errorf("failed to get graph %d for its events: %w", graph_id, err)
+	}
+	return o.publish(ctx, graph.ProjectID, graph_id, event, data...)
+}
+
+func toAny[T any](items []T) []any {
+	res := make([]any, 0, len(items))
+	for _, item := range items {
+		res = append(res, item)
+	}
+	return res
+}
@@ -0,0 +1,182 @@
+package facade_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/memory"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+var errOutbox = errors.New("outbox is unavailable")
+
+// brokenOutbox fails every write to the outbox
+type brokenOutbox struct {
+	*memory.Storage
+}
+
+func (brokenOutbox) CreateWebhookEvents(context.Context, []models.WebhookEvent) error {
+	return errOutbox
+}
+
+func TestChangesAndTheirEventsCommitTogether(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.New()
+	project, err := storage.CreateProject(ctx, models.Project{Name: "outbox"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph, err := storage.CreateGraph(ctx, models.Graph{ProjectID: project.ID, Name: "outbox"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = storage.CreateWebhook(ctx, models.Webhook{ProjectID: project.ID, URL: "https://hooks.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broker := events.NewBroker()
+	updates, unsubscribe := broker.Subscribe(graph.ID, 10)
+	defer unsubscribe()
+
+	broken := facade.New(brokenOutbox{storage}, broker, nil)
+	_, err = broken.CreateService(ctx, models.Service{GraphID: graph.ID, Name: "api"})
+	if !errors.Is(err, errOutbox) {
+		t.Fatalf("got error %v, want %v", err, errOutbox)
+	}
+	services, err := storage.GetGraphServices(ctx, graph.ID, models.ServiceFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 0 {
+		t.Fatalf("service is stored without its event: %+v", services)
+	}
+	if len(updates) != 0 {
+		t.Fatal("event of a rolled back change is published")
+	}
+
+	f := facade.New(storage, broker, nil)
+	service, err := f.CreateService(ctx, models.Service{GraphID: graph.ID, Name: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outbox, err := storage.ClaimWebhookEvents(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outbox) != 1 || outbox[0].Event != facade.EventServiceCreated {
+		t.Fatalf("unexpected outbox: %+v", outbox)
+	}
+	select {
+	case event := <-updates:
+		if event.Type != facade.EventServiceCreated || event.Data.(models.Service).ID != service.ID {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	default:
+		t.Fatal("committed change is not published")
+	}
+}
+
+func TestUpdatingMissingEntityPublishesNothing(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.New()
+	project, err := storage.CreateProject(ctx, models.Project{Name: "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph, err := storage.CreateGraph(ctx, models.Graph{ProjectID: project.ID, Name: "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = storage.CreateWebhook(ctx, models.Webhook{ProjectID: project.ID, URL: "https://hooks.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broker := events.NewBroker()
+	updates, unsubscribe := broker.Subscribe(graph.ID, 10)
+	defer unsubscribe()
+
+	f := facade.New(storage, broker, nil)
+	err = f.UpdateService(ctx, 404, models.Service{GraphID: graph.ID, Name: "api"})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("got error %v, want %v", err, sql.ErrNoRows)
+	}
+	err = f.UpdateGraph(ctx, 404, models.Graph{ProjectID: project.ID, Name: "missing"})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("got error %v, want %v", err, sql.ErrNoRows)
+	}
+	outbox, err := storage.ClaimWebhookEvents(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outbox) != 0 {
+		t.Fatalf("update of a missing entity is queued: %+v", outbox)
+	}
+	if len(updates) != 0 {
+		t.Fatal("update of a missing entity is published")
+	}
+}
+
+func TestCatalogUpdatePublishesLinkedServices(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.New()
+	project, err := storage.CreateProject(ctx, models.Project{Name: "catalog"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph, err := storage.CreateGraph(ctx, models.Graph{ProjectID: project.ID, Name: "catalog"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = storage.CreateWebhook(ctx, models.Webhook{ProjectID: project.ID, URL: "https://hooks.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := storage.CreateCatalogService(ctx, models.CatalogService{ProjectID: project.ID, Name: "billing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	linked, err := storage.CreateService(ctx, models.Service{GraphID: graph.ID, CatalogID: &entry.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = storage.CreateService(ctx, models.Service{GraphID: graph.ID, Name: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broker := events.NewBroker()
+	updates, unsubscribe := broker.Subscribe(graph.ID, 10)
+	defer unsubscribe()
+
+	f := facade.New(storage, broker, nil)
+	err = f.UpdateCatalogService(ctx, entry.ID, models.CatalogService{Name: "payments"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outbox, err := storage.ClaimWebhookEvents(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outbox) != 1 || outbox[0].Event != facade.EventServiceUpdated {
+		t.Fatalf("unexpected outbox: %+v", outbox)
+	}
+	select {
+	case event := <-updates:
+		service := event.Data.(models.Service)
+		if event.Type != facade.EventServiceUpdated || service.ID != linked.ID || service.Name != "payments" {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	default:
+		t.Fatal("update of the linked service is not published")
+	}
+	if len(updates) != 0 {
+		t.Fatal("unlinked service is published")
+	}
+}
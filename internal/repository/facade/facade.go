@@ -2,45 +2,101 @@ package facade
 
 import (
 	"context"
+	"slices"
+	"time"
 
+	"github.com/hse-telescope/core/internal/events"
 	"github.com/hse-telescope/core/internal/repository/models"
 )
 
 type Storage interface {
+	// InTx runs fn in a transaction, the methods called with the context fn
+	// gets take part in it. fn may run more than once.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+
 	GetProjects(ctx context.Context) ([]models.Project, error)
+	GetProject(ctx context.Context, project_id int) (models.Project, error)
 	CreateProject(ctx context.Context, project models.Project) (models.Project, error)
 	UpdateProject(ctx context.Context, project_id int, project models.Project) error
+	PatchProject(ctx context.Context, project_id int, project models.Project, fields []string) (models.Project, error)
 	DeleteProject(ctx context.Context, project_id int) error
 
 	CreateGraph(ctx context.Context, graph models.Graph) (models.Graph, error)
 	DeleteGraph(ctx context.Context, graph_id int) error
 	UpdateGraph(ctx context.Context, graph_id int, graph models.Graph) error
+	PatchGraph(ctx context.Context, graph_id int, graph models.Graph, fields []string) (models.Graph, error)
+	GetGraph(ctx context.Context, graph_id int) (models.Graph, error)
 	GetProjectGraphs(ctx context.Context, project_id int) ([]models.Graph, error)
+	GetProjectsGraphs(ctx context.Context, project_ids []int) ([]models.Graph, error)
 
 	GetService(ctx context.Context, service_id int) (models.Service, error)
-	GetGraphServices(ctx context.Context, graph_id int) ([]models.Service, error)
+	GetGraphServices(ctx context.Context, graph_id int, filter models.ServiceFilter) ([]models.Service, error)
+	GetGraphsServices(ctx context.Context, graph_ids []int) ([]models.Service, error)
 	CreateService(ctx context.Context, service models.Service) (models.Service, error)
 	CreateServices(ctx context.Context, graph_id int, services []models.Service) ([]int, error)
 	UpdateService(ctx context.Context, service_id int, service models.Service) error
+	PatchService(ctx context.Context, service_id int, service models.Service, fields []string) (models.Service, error)
 	UpdateGraphServices(ctx context.Context, graph_id int, service []models.Service) error
 	DeleteService(ctx context.Context, service_id int) error
 
 	GetRelation(ctx context.Context, relation_id int) (models.Relation, error)
 	GetGraphRelations(ctx context.Context, graph_id int) ([]models.Relation, error)
+	GetServicesRelations(ctx context.Context, service_ids []int) ([]models.Relation, error)
 	CreateRelation(ctx context.Context, relation models.Relation) (models.Relation, error)
 	CreateRelations(ctx context.Context, graph_id int, relations []models.Relation) error
 	UpdateRelation(ctx context.Context, relation_id int, relation models.Relation) error
+	PatchRelation(ctx context.Context, relation_id int, relation models.Relation, fields []string) (models.Relation, error)
 	UpdateGraphRelations(ctx context.Context, graph_id int, relations []models.Relation) error
 	DeleteRelation(ctx context.Context, relation_id int) error
+
+	// ApplyProjectPlan applies the plan computed by plan under the lock of
+	// the project, plan reads the state with the context it gets
+	ApplyProjectPlan(ctx context.Context, project_id int, plan func(ctx context.Context) (models.ProjectPlan, error)) (models.ProjectPlan, error)
+
+	GetCatalogService(ctx context.Context, catalog_id int) (models.CatalogService, error)
+	GetProjectCatalog(ctx context.Context, project_id int) ([]models.CatalogService, error)
+	CreateCatalogService(ctx context.Context, service models.CatalogService) (models.CatalogService, error)
+	UpdateCatalogService(ctx context.Context, catalog_id int, service models.CatalogService) error
+	DeleteCatalogService(ctx context.Context, catalog_id int) error
+	GetCatalogServiceGraphs(ctx context.Context, catalog_id int) ([]models.Graph, error)
+
+	GetGroup(ctx context.Context, group_id int) (models.Group, error)
+	GetGraphGroups(ctx context.Context, graph_id int) ([]models.Group, error)
+	CreateGroup(ctx context.Context, group models.Group) (models.Group, error)
+	UpdateGroup(ctx context.Context, group_id int, group models.Group) error
+	DeleteGroup(ctx context.Context, group_id int) error
+
+	GetWebhook(ctx context.Context, webhook_id int) (models.Webhook, error)
+	GetProjectWebhooks(ctx context.Context, project_id int) ([]models.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook models.Webhook) (models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook_id int, webhook models.Webhook) error
+	DeleteWebhook(ctx context.Context, webhook_id int) error
+	CreateWebhookEvents(ctx context.Context, events []models.WebhookEvent) error
+	ClaimWebhookEvents(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookEvent, error)
+	UpdateWebhookEvent(ctx context.Context, event models.WebhookEvent) error
+	CreateWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, webhook_id int) ([]models.WebhookDelivery, error)
+
+	ClaimIdempotencyKey(ctx context.Context, key models.IdempotencyKey, stale time.Time) (models.IdempotencyKey, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error
+	ReleaseIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int, error)
+
+	CountEntities(ctx context.Context) (models.EntityCounts, error)
 }
 
 type Facade struct {
 	storage Storage
+	broker  *events.Broker
+	cache   *graphCache
 }
 
-func New(storage Storage) Facade {
+// New builds the facade, graph reads go through cache unless it is nil
+func New(storage Storage, broker *events.Broker, cache Cache) Facade {
 	return Facade{
 		storage: storage,
+		broker:  broker,
+		cache:   newGraphCache(cache),
 	}
 }
 
@@ -48,64 +104,286 @@ func (f Facade) GetProjects(ctx context.Context) ([]models.Project, error) {
 	return f.storage.GetProjects(ctx)
 }
 
+func (f Facade) GetProject(ctx context.Context, project_id int) (models.Project, error) {
+	return f.storage.GetProject(ctx, project_id)
+}
+
 func (f Facade) CreateProject(ctx context.Context, project models.Project) (models.Project, error) {
-	return f.storage.CreateProject(ctx, project)
+	var created models.Project
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		var err error
+		created, err = f.storage.CreateProject(ctx, project)
+		if err != nil {
+			return err
+		}
+		return out.publish(ctx, created.ID, 0, EventProjectCreated, created)
+	})
+	return created, err
 }
 
 func (f Facade) UpdateProject(ctx context.Context, project_id int, project models.Project) error {
-	return f.storage.UpdateProject(ctx, project_id, project)
+	return f.write(ctx, func(ctx context.Context, out *outbox) error {
+		err := f.storage.UpdateProject(ctx, project_id, project)
+		if err != nil {
+			return err
+		}
+		stored, err := f.storage.GetProject(ctx, project_id)
+		if err != nil {
+			return err
+		}
+		return out.publish(ctx, project_id, 0, EventProjectUpdated, stored)
+	})
+}
+
+// PatchProject hands the stored project to patch and writes the fields it
+// returns in the same transaction, see PatchService
+func (f Facade) PatchProject(ctx context.Context, project_id int, patch func(models.Project) (models.Project, []string, error)) (models.Project, error) {
+	var patched models.Project
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		stored, err := f.storage.PatchProject(ctx, project_id, models.Project{}, nil)
+		if err != nil {
+			return err
+		}
+		project, fields, err := patch(stored)
+		if err != nil {
+			return err
+		}
+		patched, err = f.storage.PatchProject(ctx, project_id, project, fields)
+		if err != nil {
+			return err
+		}
+		return out.publish(ctx, project_id, 0, EventProjectUpdated, patched)
+	})
+	return patched, err
 }
 
 func (f Facade) DeleteProject(ctx context.Context, project_id int) error {
-	return f.storage.DeleteProject(ctx, project_id)
+	graphs := f.projectGraphs(ctx, project_id)
+	err := f.storage.DeleteProject(ctx, project_id)
+	f.cache.invalidate(ctx, graphs...)
+	return err
 }
 
 func (f Facade) CreateGraph(ctx context.Context, graph models.Graph) (models.Graph, error) {
-	return f.storage.CreateGraph(ctx, graph)
+	var created models.Graph
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		var err error
+		created, err = f.storage.CreateGraph(ctx, graph)
+		if err != nil {
+			return err
+		}
+		return out.publish(ctx, created.ProjectID, created.ID, EventGraphCreated, created)
+	})
+	return created, err
 }
 
 func (f Facade) DeleteGraph(ctx context.Context, graph_id int) error {
-	return f.storage.DeleteGraph(ctx, graph_id)
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		graph, graphErr := f.storage.GetGraph(ctx, graph_id)
+		err := f.storage.DeleteGraph(ctx, graph_id)
+		if err != nil || graphErr != nil {
+			return err
+		}
+		return out.publish(ctx, graph.ProjectID, graph_id, EventGraphDeleted, graph)
+	})
+	f.cache.invalidate(ctx, graph_id)
+	return err
 }
 
 func (f Facade) UpdateGraphServices(ctx context.Context, graph_id int, services []models.Service) error {
-	return f.storage.UpdateGraphServices(ctx, graph_id, services)
+	graphs := f.serviceGraphs(ctx, graph_id, serviceIDs(services)...)
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		err := f.storage.UpdateGraphServices(ctx, graph_id, services)
+		if err != nil {
+			return err
+		}
+		for i := range services {
+			services[i].GraphID = graph_id
+		}
+		return out.publishGraph(ctx, graph_id, EventServiceUpdated, toAny(services)...)
+	})
+	f.cache.invalidate(ctx, graphs...)
+	return err
 }
 
 func (f Facade) UpdateGraphRelations(ctx context.Context, graph_id int, relations []models.Relation) error {
-	return f.storage.UpdateGraphRelations(ctx, graph_id, relations)
+	graphs := f.relationGraphs(ctx, graph_id, relationIDs(relations)...)
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		err := f.storage.UpdateGraphRelations(ctx, graph_id, relations)
+		if err != nil {
+			return err
+		}
+		for i := range relations {
+			relations[i].GraphID = graph_id
+		}
+		return out.publishGraph(ctx, graph_id, EventRelationUpdated, toAny(relations)...)
+	})
+	f.cache.invalidate(ctx, graphs...)
+	return err
 }
 
 func (f Facade) UpdateGraph(ctx context.Context, graph_id int, graph models.Graph) error {
-	return f.storage.UpdateGraph(ctx, graph_id, graph)
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		err := f.storage.UpdateGraph(ctx, graph_id, graph)
+		if err != nil {
+			return err
+		}
+		stored, err := f.storage.GetGraph(ctx, graph_id)
+		if err != nil {
+			return err
+		}
+		return out.publish(ctx, stored.ProjectID, graph_id, EventGraphUpdated, stored)
+	})
+	f.cache.invalidate(ctx, graph_id)
+	return err
+}
+
+// PatchGraph hands the stored graph to patch and writes the fields it returns
+// in the same transaction, see PatchService
+func (f Facade) PatchGraph(ctx context.Context, graph_id int, patch func(models.Graph) (models.Graph, []string, error)) (models.Graph, error) {
+	var patched models.Graph
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		stored, err := f.storage.PatchGraph(ctx, graph_id, models.Graph{}, nil)
+		if err != nil {
+			return err
+		}
+		graph, fields, err := patch(stored)
+		if err != nil {
+			return err
+		}
+		patched, err = f.storage.PatchGraph(ctx, graph_id, graph, fields)
+		if err != nil {
+			return err
+		}
+		return out.publish(ctx, patched.ProjectID, graph_id, EventGraphUpdated, patched)
+	})
+	f.cache.invalidate(ctx, graph_id)
+	return patched, err
+}
+
+func (f Facade) GetGraph(ctx context.Context, graph_id int) (models.Graph, error) {
+	return readThrough(ctx, f.cache, graph_id, readGraph, "", func() (models.Graph, error) {
+		return f.storage.GetGraph(ctx, graph_id)
+	})
 }
 
 func (f Facade) GetProjectGraphs(ctx context.Context, project_id int) ([]models.Graph, error) {
 	return f.storage.GetProjectGraphs(ctx, project_id)
 }
 
+func (f Facade) GetProjectsGraphs(ctx context.Context, project_ids []int) ([]models.Graph, error) {
+	return f.storage.GetProjectsGraphs(ctx, project_ids)
+}
+
 func (f Facade) GetService(ctx context.Context, service_id int) (models.Service, error) {
 	return f.storage.GetService(ctx, service_id)
 }
 
-func (f Facade) GetGraphServices(ctx context.Context, graph_id int) ([]models.Service, error) {
-	return f.storage.GetGraphServices(ctx, graph_id)
+func (f Facade) GetGraphServices(ctx context.Context, graph_id int, filter models.ServiceFilter) ([]models.Service, error) {
+	return readThrough(ctx, f.cache, graph_id, readServices, filterKey(filter), func() ([]models.Service, error) {
+		return f.storage.GetGraphServices(ctx, graph_id, filter)
+	})
+}
+
+func (f Facade) GetGraphsServices(ctx context.Context, graph_ids []int) ([]models.Service, error) {
+	return f.storage.GetGraphsServices(ctx, graph_ids)
 }
 
 func (f Facade) CreateService(ctx context.Context, service models.Service) (models.Service, error) {
-	return f.storage.CreateService(ctx, service)
+	var created models.Service
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		var err error
+		created, err = f.storage.CreateService(ctx, service)
+		if err != nil {
+			return err
+		}
+		return out.publishGraph(ctx, created.GraphID, EventServiceCreated, created)
+	})
+	if err != nil {
+		return created, err
+	}
+	f.cache.invalidate(ctx, created.GraphID)
+	return created, nil
 }
 
 func (f Facade) CreateServices(ctx context.Context, graph_id int, services []models.Service) ([]int, error) {
-	return f.storage.CreateServices(ctx, graph_id, services)
+	var ids []int
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		var err error
+		ids, err = f.storage.CreateServices(ctx, graph_id, services)
+		if err != nil {
+			return err
+		}
+		for i := range ids {
+			services[i].ID = ids[i]
+			services[i].GraphID = graph_id
+		}
+		return out.publishGraph(ctx, graph_id, EventServiceCreated, toAny(services)...)
+	})
+	f.cache.invalidate(ctx, graph_id)
+	return ids, err
 }
 
 func (f Facade) UpdateService(ctx context.Context, service_id int, service models.Service) error {
-	return f.storage.UpdateService(ctx, service_id, service)
+	graphs := f.serviceGraphs(ctx, service.GraphID, service_id)
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		err := f.storage.UpdateService(ctx, service_id, service)
+		if err != nil {
+			return err
+		}
+		stored, err := f.storage.GetService(ctx, service_id)
+		if err != nil {
+			return err
+		}
+		return out.publishGraph(ctx, stored.GraphID, EventServiceUpdated, stored)
+	})
+	f.cache.invalidate(ctx, graphs...)
+	return err
+}
+
+// PatchService hands the stored service to patch and writes the fields it
+// returns in the same transaction. Reading through PatchService without
+// fields locks the row, so no write lands between the read and the write.
+func (f Facade) PatchService(ctx context.Context, service_id int, patch func(models.Service) (models.Service, []string, error)) (models.Service, error) {
+	var stored, patched models.Service
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		var err error
+		stored, err = f.storage.PatchService(ctx, service_id, models.Service{}, nil)
+		if err != nil {
+			return err
+		}
+		service, fields, err := patch(stored)
+		if err != nil {
+			return err
+		}
+		patched, err = f.storage.PatchService(ctx, service_id, service, fields)
+		if err != nil {
+			return err
+		}
+		return out.publishGraph(ctx, patched.GraphID, EventServiceUpdated, patched)
+	})
+	if err != nil {
+		return patched, err
+	}
+	f.cache.invalidate(ctx, stored.GraphID, patched.GraphID)
+	return patched, nil
 }
 
 func (f Facade) DeleteService(ctx context.Context, service_id int) error {
-	return f.storage.DeleteService(ctx, service_id)
+	var graph_id int
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		service, serviceErr := f.storage.GetService(ctx, service_id)
+		err := f.storage.DeleteService(ctx, service_id)
+		if err != nil || serviceErr != nil {
+			return err
+		}
+		graph_id = service.GraphID
+		return out.publishGraph(ctx, service.GraphID, EventServiceDeleted, service)
+	})
+	if graph_id != 0 {
+		f.cache.invalidate(ctx, graph_id)
+	}
+	return err
 }
 
 func (f Facade) GetRelation(ctx context.Context, relation_id int) (models.Relation, error) {
@@ -113,21 +391,304 @@ func (f Facade) GetRelation(ctx context.Context, relation_id int) (models.Relati
 }
 
 func (f Facade) GetGraphRelations(ctx context.Context, graph_id int) ([]models.Relation, error) {
-	return f.storage.GetGraphRelations(ctx, graph_id)
+	return readThrough(ctx, f.cache, graph_id, readRelations, "", func() ([]models.Relation, error) {
+		return f.storage.GetGraphRelations(ctx, graph_id)
+	})
+}
+
+func (f Facade) GetServicesRelations(ctx context.Context, service_ids []int) ([]models.Relation, error) {
+	return f.storage.GetServicesRelations(ctx, service_ids)
 }
 
 func (f Facade) CreateRelation(ctx context.Context, relation models.Relation) (models.Relation, error) {
-	return f.storage.CreateRelation(ctx, relation)
+	var created models.Relation
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		var err error
+		created, err = f.storage.CreateRelation(ctx, relation)
+		if err != nil {
+			return err
+		}
+		return out.publishGraph(ctx, created.GraphID, EventRelationCreated, created)
+	})
+	if err != nil {
+		return created, err
+	}
+	f.cache.invalidate(ctx, created.GraphID)
+	return created, nil
 }
 
 func (f Facade) UpdateRelation(ctx context.Context, relation_id int, relation models.Relation) error {
-	return f.storage.UpdateRelation(ctx, relation_id, relation)
+	graphs := f.relationGraphs(ctx, relation.GraphID, relation_id)
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		err := f.storage.UpdateRelation(ctx, relation_id, relation)
+		if err != nil {
+			return err
+		}
+		stored, err := f.storage.GetRelation(ctx, relation_id)
+		if err != nil {
+			return err
+		}
+		return out.publishGraph(ctx, stored.GraphID, EventRelationUpdated, stored)
+	})
+	f.cache.invalidate(ctx, graphs...)
+	return err
+}
+
+// PatchRelation hands the stored relation to patch and writes the fields it
+// returns in the same transaction, see PatchService
+func (f Facade) PatchRelation(ctx context.Context, relation_id int, patch func(models.Relation) (models.Relation, []string, error)) (models.Relation, error) {
+	var stored, patched models.Relation
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		var err error
+		stored, err = f.storage.PatchRelation(ctx, relation_id, models.Relation{}, nil)
+		if err != nil {
+			return err
+		}
+		relation, fields, err := patch(stored)
+		if err != nil {
+			return err
+		}
+		patched, err = f.storage.PatchRelation(ctx, relation_id, relation, fields)
+		if err != nil {
+			return err
+		}
+		return out.publishGraph(ctx, patched.GraphID, EventRelationUpdated, patched)
+	})
+	if err != nil {
+		return patched, err
+	}
+	f.cache.invalidate(ctx, stored.GraphID, patched.GraphID)
+	return patched, nil
 }
 
 func (f Facade) CreateRelations(ctx context.Context, graph_id int, relations []models.Relation) error {
-	return f.storage.CreateRelations(ctx, graph_id, relations)
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		err := f.storage.CreateRelations(ctx, graph_id, relations)
+		if err != nil {
+			return err
+		}
+		for i := range relations {
+			relations[i].GraphID = graph_id
+		}
+		return out.publishGraph(ctx, graph_id, EventRelationCreated, toAny(relations)...)
+	})
+	f.cache.invalidate(ctx, graph_id)
+	return err
 }
 
 func (f Facade) DeleteRelation(ctx context.Context, relation_id int) error {
-	return f.storage.DeleteRelation(ctx, relation_id)
+	var graph_id int
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		relation, relationErr := f.storage.GetRelation(ctx, relation_id)
+		err := f.storage.DeleteRelation(ctx, relation_id)
+		if err != nil || relationErr != nil {
+			return err
+		}
+		graph_id = relation.GraphID
+		return out.publishGraph(ctx, relation.GraphID, EventRelationDeleted, relation)
+	})
+	if graph_id != 0 {
+		f.cache.invalidate(ctx, graph_id)
+	}
+	return err
+}
+
+// ApplyProjectPlan publishes an event per applied change, deletions first as
+// they were applied
+func (f Facade) ApplyProjectPlan(ctx context.Context, project_id int, plan func(ctx context.Context) (models.ProjectPlan, error)) (models.ProjectPlan, error) {
+	var applied models.ProjectPlan
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		var err error
+		applied, err = f.storage.ApplyProjectPlan(ctx, project_id, plan)
+		if err != nil {
+			return err
+		}
+		return publishPlan(ctx, out, project_id, applied)
+	})
+	if err != nil {
+		return applied, err
+	}
+	f.cache.invalidate(ctx, planGraphs(applied)...)
+	return applied, nil
+}
+
+func publishPlan(ctx context.Context, out *outbox, project_id int, plan models.ProjectPlan) error {
+	var err error
+	publish := func(graph_id int, event string, data any) {
+		if err == nil {
+			err = out.publish(ctx, project_id, graph_id, event, data)
+		}
+	}
+	for _, relation := range plan.DeleteRelations {
+		publish(relation.GraphID, EventRelationDeleted, relation)
+	}
+	for _, service := range plan.DeleteServices {
+		publish(service.GraphID, EventServiceDeleted, service)
+	}
+	for _, graph := range plan.DeleteGraphs {
+		publish(graph.ID, EventGraphDeleted, graph)
+	}
+	for _, graph := range plan.CreateGraphs {
+		publish(graph.ID, EventGraphCreated, graph)
+	}
+	for _, planned := range plan.CreateServices {
+		publish(planned.Service.GraphID, EventServiceCreated, planned.Service)
+	}
+	for _, service := range plan.UpdateServices {
+		publish(service.GraphID, EventServiceUpdated, service)
+	}
+	for _, planned := range plan.CreateRelations {
+		publish(planned.Relation.GraphID, EventRelationCreated, planned.Relation)
+	}
+	for _, planned := range plan.UpdateRelations {
+		publish(planned.Relation.GraphID, EventRelationUpdated, planned.Relation)
+	}
+	return err
+}
+
+func (f Facade) GetCatalogService(ctx context.Context, catalog_id int) (models.CatalogService, error) {
+	return f.storage.GetCatalogService(ctx, catalog_id)
+}
+
+func (f Facade) GetProjectCatalog(ctx context.Context, project_id int) ([]models.CatalogService, error) {
+	return f.storage.GetProjectCatalog(ctx, project_id)
+}
+
+func (f Facade) CreateCatalogService(ctx context.Context, service models.CatalogService) (models.CatalogService, error) {
+	return f.storage.CreateCatalogService(ctx, service)
+}
+
+// UpdateCatalogService changes every graph service linked to the entry as
+// well and publishes service.updated for each of them
+func (f Facade) UpdateCatalogService(ctx context.Context, catalog_id int, service models.CatalogService) error {
+	graphs := f.catalogGraphs(ctx, catalog_id)
+	err := f.write(ctx, func(ctx context.Context, out *outbox) error {
+		err := f.storage.UpdateCatalogService(ctx, catalog_id, service)
+		if err != nil {
+			return err
+		}
+		linked, err := f.storage.GetCatalogServiceGraphs(ctx, catalog_id)
+		if err != nil {
+			return err
+		}
+		for _, graph := range linked {
+			services, err := f.storage.GetGraphServices(ctx, graph.ID, models.ServiceFilter{})
+			if err != nil {
+				return err
+			}
+			services = slices.DeleteFunc(services, func(serv models.Service) bool {
+				return serv.CatalogID == nil || *serv.CatalogID != catalog_id
+			})
+			err = out.publish(ctx, graph.ProjectID, graph.ID, EventServiceUpdated, toAny(services)...)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	f.cache.invalidate(ctx, graphs...)
+	return err
+}
+
+func (f Facade) DeleteCatalogService(ctx context.Context, catalog_id int) error {
+	graphs := f.catalogGraphs(ctx, catalog_id)
+	err := f.storage.DeleteCatalogService(ctx, catalog_id)
+	f.cache.invalidate(ctx, graphs...)
+	return err
+}
+
+func (f Facade) GetCatalogServiceGraphs(ctx context.Context, catalog_id int) ([]models.Graph, error) {
+	return f.storage.GetCatalogServiceGraphs(ctx, catalog_id)
+}
+
+func (f Facade) GetGroup(ctx context.Context, group_id int) (models.Group, error) {
+	return f.storage.GetGroup(ctx, group_id)
+}
+
+func (f Facade) GetGraphGroups(ctx context.Context, graph_id int) ([]models.Group, error) {
+	return readThrough(ctx, f.cache, graph_id, readGroups, "", func() ([]models.Group, error) {
+		return f.storage.GetGraphGroups(ctx, graph_id)
+	})
+}
+
+func (f Facade) CreateGroup(ctx context.Context, group models.Group) (models.Group, error) {
+	group, err := f.storage.CreateGroup(ctx, group)
+	if err != nil {
+		return group, err
+	}
+	f.cache.invalidate(ctx, group.GraphID)
+	return group, nil
+}
+
+// UpdateGroup keeps the group in its graph, so the graph is taken from the
+// stored group
+func (f Facade) UpdateGroup(ctx context.Context, group_id int, group models.Group) error {
+	graphs := f.groupGraphs(ctx, group_id)
+	err := f.storage.UpdateGroup(ctx, group_id, group)
+	f.cache.invalidate(ctx, graphs...)
+	return err
+}
+
+// DeleteGroup detaches the services of the group as well
+func (f Facade) DeleteGroup(ctx context.Context, group_id int) error {
+	graphs := f.groupGraphs(ctx, group_id)
+	err := f.storage.DeleteGroup(ctx, group_id)
+	f.cache.invalidate(ctx, graphs...)
+	return err
+}
+
+func (f Facade) GetWebhook(ctx context.Context, webhook_id int) (models.Webhook, error) {
+	return f.storage.GetWebhook(ctx, webhook_id)
+}
+
+func (f Facade) GetProjectWebhooks(ctx context.Context, project_id int) ([]models.Webhook, error) {
+	return f.storage.GetProjectWebhooks(ctx, project_id)
+}
+
+func (f Facade) CreateWebhook(ctx context.Context, webhook models.Webhook) (models.Webhook, error) {
+	return f.storage.CreateWebhook(ctx, webhook)
+}
+
+func (f Facade) UpdateWebhook(ctx context.Context, webhook_id int, webhook models.Webhook) error {
+	return f.storage.UpdateWebhook(ctx, webhook_id, webhook)
+}
+
+func (f Facade) DeleteWebhook(ctx context.Context, webhook_id int) error {
+	return f.storage.DeleteWebhook(ctx, webhook_id)
+}
+
+func (f Facade) ClaimWebhookEvents(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookEvent, error) {
+	return f.storage.ClaimWebhookEvents(ctx, limit, lease)
+}
+
+func (f Facade) UpdateWebhookEvent(ctx context.Context, event models.WebhookEvent) error {
+	return f.storage.UpdateWebhookEvent(ctx, event)
+}
+
+func (f Facade) CreateWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+	return f.storage.CreateWebhookDelivery(ctx, delivery)
+}
+
+func (f Facade) GetWebhookDeliveries(ctx context.Context, webhook_id int) ([]models.WebhookDelivery, error) {
+	return f.storage.GetWebhookDeliveries(ctx, webhook_id)
+}
+
+func (f Facade) ClaimIdempotencyKey(ctx context.Context, key models.IdempotencyKey, stale time.Time) (models.IdempotencyKey, bool, error) {
+	return f.storage.ClaimIdempotencyKey(ctx, key, stale)
+}
+
+func (f Facade) CompleteIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error {
+	return f.storage.CompleteIdempotencyKey(ctx, key)
+}
+
+func (f Facade) ReleaseIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error {
+	return f.storage.ReleaseIdempotencyKey(ctx, key)
+}
+
+func (f Facade) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	return f.storage.DeleteExpiredIdempotencyKeys(ctx, now)
+}
+
+func (f Facade) CountEntities(ctx context.Context) (models.EntityCounts, error) {
+	return f.storage.CountEntities(ctx)
 }
@@ -0,0 +1,74 @@
+package facade
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// LRU is the in-process Cache. It keeps the reads of up to size graphs and
+// evicts the least recently used graph with all of its keys.
+type LRU struct {
+	size int
+
+	mu     sync.Mutex
+	order  *list.List
+	graphs map[int]*list.Element
+}
+
+type lruGraph struct {
+	id   int
+	keys map[string][]byte
+}
+
+func NewLRU(size int) *LRU {
+	return &LRU{
+		size:   size,
+		order:  list.New(),
+		graphs: make(map[int]*list.Element),
+	}
+}
+
+func (c *LRU) Get(ctx context.Context, graph_id int, key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.graphs[graph_id]
+	if !ok {
+		return nil, false
+	}
+	value, ok := elem.Value.(*lruGraph).keys[key]
+	if ok {
+		c.order.MoveToFront(elem)
+	}
+	return value, ok
+}
+
+func (c *LRU) Set(ctx context.Context, graph_id int, key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.graphs[graph_id]
+	if ok {
+		c.order.MoveToFront(elem)
+	} else {
+		elem = c.order.PushFront(&lruGraph{id: graph_id, keys: make(map[string][]byte)})
+		c.graphs[graph_id] = elem
+	}
+	elem.Value.(*lruGraph).keys[key] = value
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.graphs, oldest.Value.(*lruGraph).id)
+	}
+}
+
+func (c *LRU) Invalidate(ctx context.Context, graph_ids ...int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, graph_id := range graph_ids {
+		if elem, ok := c.graphs[graph_id]; ok {
+			c.order.Remove(elem)
+			delete(c.graphs, graph_id)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package facade_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/memory"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+var errTaken = errors.New("service is taken")
+
+func TestPatchTestsAndWritesInOneTransaction(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.New()
+	project, err := storage.CreateProject(ctx, models.Project{Name: "patch"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph, err := storage.CreateGraph(ctx, models.Graph{ProjectID: project.ID, Name: "patch"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service, err := storage.CreateService(ctx, models.Service{GraphID: graph.ID, Name: "api", OwnerTeam: "core"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := facade.New(storage, events.NewBroker(), nil)
+
+	// Every patch tests the owner is still core before taking the service,
+	// so only one of them may pass
+	const patches = 8
+	var taken atomic.Int32
+	var wg sync.WaitGroup
+	for i := range patches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := f.PatchService(ctx, service.ID, func(stored models.Service) (models.Service, []string, error) {
+				if stored.OwnerTeam != "core" {
+					return stored, nil, errTaken
+				}
+				stored.OwnerTeam = string(rune('a' + i))
+				return stored, []string{"owner_team"}, nil
+			})
+			switch {
+			case err == nil:
+				taken.Add(1)
+			case !errors.Is(err, errTaken):
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if taken.Load() != 1 {
+		t.Fatalf("%d patches passed the test, want 1", taken.Load())
+	}
+
+	stored, err := storage.GetService(ctx, service.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.OwnerTeam == "core" || stored.Name != "api" {
+		t.Fatalf("unexpected service: %+v", stored)
+	}
+}
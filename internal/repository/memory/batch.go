@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func (s *Storage) GetProjectsGraphs(ctx context.Context, project_ids []int) ([]models.Graph, error) {
+	_, span := startSpan(ctx, "storage/GetProjectsGraphs")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	return sorted(s.graphs, func(graph models.Graph) bool {
+		return slices.Contains(project_ids, graph.ProjectID)
+	}), nil
+}
+
+func (s *Storage) GetGraphsServices(ctx context.Context, graph_ids []int) ([]models.Service, error) {
+	_, span := startSpan(ctx, "storage/GetGraphsServices")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	services := sorted(s.services, func(service models.Service) bool {
+		return slices.Contains(graph_ids, service.GraphID)
+	})
+	for i := range services {
+		services[i] = copyService(services[i])
+	}
+	return services, nil
+}
+
+// GetServicesRelations returns the relations going out of the given services
+func (s *Storage) GetServicesRelations(ctx context.Context, service_ids []int) ([]models.Relation, error) {
+	_, span := startSpan(ctx, "storage/GetServicesRelations")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	return sorted(s.relations, func(relation models.Relation) bool {
+		return slices.Contains(service_ids, relation.FromService)
+	}), nil
+}
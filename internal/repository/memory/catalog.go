@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+// ErrDuplicateCatalogService mirrors the unique constraint on catalog
+// service names within a project
+var ErrDuplicateCatalogService = errors.New("catalog service with this name already exists")
+
+func (s *Storage) GetCatalogService(ctx context.Context, catalog_id int) (models.CatalogService, error) {
+	_, span := startSpan(ctx, "storage/GetCatalogService")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	service, err := get(s.catalog, catalog_id)
+	return copyCatalogService(service), err
+}
+
+func (s *Storage) GetProjectCatalog(ctx context.Context, project_id int) ([]models.CatalogService, error) {
+	_, span := startSpan(ctx, "storage/GetProjectCatalog")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	services := sorted(s.catalog, func(service models.CatalogService) bool {
+		return service.ProjectID == project_id
+	})
+	slices.SortStableFunc(services, func(a, b models.CatalogService) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for i := range services {
+		services[i] = copyCatalogService(services[i])
+	}
+	return services, nil
+}
+
+func (s *Storage) CreateCatalogService(ctx context.Context, service models.CatalogService) (models.CatalogService, error) {
+	_, span := startSpan(ctx, "storage/CreateCatalogService")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	service.ID = s.nextID("catalog_services")
+	err := s.checkCatalogService(service)
+	if err != nil {
+		return service, err
+	}
+	put(s, s.catalog, service.ID, copyCatalogService(service))
+	return service, nil
+}
+
+func (s *Storage) checkCatalogService(service models.CatalogService) error {
+	if _, ok := s.projects[service.ProjectID]; !ok {
+		return ErrReference
+	}
+	for _, other := range s.catalog {
+		if other.ID != service.ID && other.ProjectID == service.ProjectID && other.Name == service.Name {
+			return ErrDuplicateCatalogService
+		}
+	}
+	return nil
+}
+
+// UpdateCatalogService updates the catalog entry and copies its fields into
+// every graph service referencing it
+func (s *Storage) UpdateCatalogService(ctx context.Context, catalog_id int, service models.CatalogService) error {
+	_, span := startSpan(ctx, "storage/UpdateCatalogService")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	old, ok := s.catalog[catalog_id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	service.ID = catalog_id
+	service.ProjectID = old.ProjectID
+	err := s.checkCatalogService(service)
+	if err != nil {
+		return err
+	}
+	put(s, s.catalog, catalog_id, copyCatalogService(service))
+
+	for id, serv := range s.services {
+		if serv.CatalogID != nil && *serv.CatalogID == catalog_id {
+			put(s, s.services, id, copyService(inherit(serv, service)))
+		}
+	}
+	return nil
+}
+
+func (s *Storage) DeleteCatalogService(ctx context.Context, catalog_id int) error {
+	_, span := startSpan(ctx, "storage/DeleteCatalogService")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	s.deleteCatalogService(catalog_id)
+	return nil
+}
+
+func (s *Storage) deleteCatalogService(catalog_id int) {
+	remove(s, s.catalog, catalog_id)
+	for id, service := range s.services {
+		if service.CatalogID != nil && *service.CatalogID == catalog_id {
+			service.CatalogID = nil
+			put(s, s.services, id, service)
+		}
+	}
+}
+
+func (s *Storage) GetCatalogServiceGraphs(ctx context.Context, catalog_id int) ([]models.Graph, error) {
+	_, span := startSpan(ctx, "storage/GetCatalogServiceGraphs")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	graphs := make(map[int]bool)
+	for _, service := range s.services {
+		if service.CatalogID != nil && *service.CatalogID == catalog_id {
+			graphs[service.GraphID] = true
+		}
+	}
+	return sorted(s.graphs, func(graph models.Graph) bool {
+		return graphs[graph.ID]
+	}), nil
+}
+
+// inheritCatalogService overwrites the shared fields of a graph service with
+// the ones of the catalog entry it references. The entry has to belong to
+// the project of the service graph.
+func (s *Storage) inheritCatalogService(service models.Service) (models.Service, error) {
+	if service.CatalogID == nil {
+		return service, nil
+	}
+	catalog, ok := s.catalog[*service.CatalogID]
+	graph, graphOK := s.graphs[service.GraphID]
+	if !ok || !graphOK || catalog.ProjectID != graph.ProjectID {
+		return models.Service{}, models.ErrForeignCatalogService
+	}
+	return inherit(service, catalog), nil
+}
+
+func inherit(service models.Service, catalog models.CatalogService) models.Service {
+	service.Name = catalog.Name
+	service.Description = catalog.Description
+	service.Kind = catalog.Kind
+	service.OwnerTeam = catalog.OwnerTeam
+	service.Tags = catalog.Tags
+	service.Attributes = catalog.Attributes
+	service.DocsURL = catalog.DocsURL
+	service.RunbookURL = catalog.RunbookURL
+	service.RepoURL = catalog.RepoURL
+	return service
+}
+
+func copyCatalogService(service models.CatalogService) models.CatalogService {
+	service.Tags = copyTags(service.Tags)
+	service.Attributes = copyAttributes(service.Attributes)
+	return service
+}
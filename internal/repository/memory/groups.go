@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func (s *Storage) GetGroup(ctx context.Context, group_id int) (models.Group, error) {
+	_, span := startSpan(ctx, "storage/GetGroup")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	group, err := get(s.groups, group_id)
+	group.ParentID = copyID(group.ParentID)
+	return group, err
+}
+
+func (s *Storage) GetGraphGroups(ctx context.Context, graph_id int) ([]models.Group, error) {
+	_, span := startSpan(ctx, "storage/GetGraphGroups")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	groups := sorted(s.groups, func(group models.Group) bool {
+		return group.GraphID == graph_id
+	})
+	for i := range groups {
+		groups[i].ParentID = copyID(groups[i].ParentID)
+	}
+	return groups, nil
+}
+
+func (s *Storage) CreateGroup(ctx context.Context, group models.Group) (models.Group, error) {
+	_, span := startSpan(ctx, "storage/CreateGroup")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	group.ID = s.nextID("service_groups")
+	err := s.checkGroup(group)
+	if err != nil {
+		return group, err
+	}
+	group.ParentID = copyID(group.ParentID)
+	put(s, s.groups, group.ID, group)
+	return group, nil
+}
+
+// checkGroup checks the graph and the parent of the same graph
+func (s *Storage) checkGroup(group models.Group) error {
+	if _, ok := s.graphs[group.GraphID]; !ok {
+		return ErrReference
+	}
+	if group.ParentID != nil {
+		parent, ok := s.groups[*group.ParentID]
+		if !ok || parent.GraphID != group.GraphID {
+			return ErrReference
+		}
+	}
+	return nil
+}
+
+// UpdateGroup cannot move a group to another graph
+func (s *Storage) UpdateGroup(ctx context.Context, group_id int, group models.Group) error {
+	_, span := startSpan(ctx, "storage/UpdateGroup")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	old, ok := s.groups[group_id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	group.ID = group_id
+	group.GraphID = old.GraphID
+	err := s.checkGroup(group)
+	if err != nil {
+		return err
+	}
+	group.ParentID = copyID(group.ParentID)
+	put(s, s.groups, group_id, group)
+	return nil
+}
+
+func (s *Storage) DeleteGroup(ctx context.Context, group_id int) error {
+	_, span := startSpan(ctx, "storage/DeleteGroup")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	s.deleteGroup(group_id)
+	return nil
+}
+
+// deleteGroup detaches the services and the child groups of the group
+func (s *Storage) deleteGroup(group_id int) {
+	remove(s, s.groups, group_id)
+	for id, group := range s.groups {
+		if group.ParentID != nil && *group.ParentID == group_id {
+			group.ParentID = nil
+			put(s, s.groups, id, group)
+		}
+	}
+	for id, service := range s.services {
+		if service.GroupID != nil && *service.GroupID == group_id {
+			service.GroupID = nil
+			put(s, s.services, id, service)
+		}
+	}
+}
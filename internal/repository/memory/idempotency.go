@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+// ClaimIdempotencyKey stores the key as in progress unless another request
+// holds it. A key that expired or stayed in progress since before stale is
+// taken over.
+func (s *Storage) ClaimIdempotencyKey(ctx context.Context, key models.IdempotencyKey, stale time.Time) (models.IdempotencyKey, bool, error) {
+	_, span := startSpan(ctx, "storage/ClaimIdempotencyKey")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	held, ok := s.idempotency[key.Key]
+	if ok && held.ExpiresAt.After(key.CreatedAt) && (held.Status != 0 || !held.CreatedAt.Before(stale)) {
+		held.Body = slices.Clone(held.Body)
+		return held, false, nil
+	}
+	key.Status = 0
+	key.ContentType = ""
+	key.Body = []byte{}
+	s.idempotency[key.Key] = key
+	return key, true, nil
+}
+
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error {
+	_, span := startSpan(ctx, "storage/CompleteIdempotencyKey")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	held, ok := s.idempotency[key.Key]
+	if !ok || held.Fingerprint != key.Fingerprint || held.Status != 0 {
+		return nil
+	}
+	held.Status = key.Status
+	held.ContentType = key.ContentType
+	held.Body = slices.Clone(key.Body)
+	s.idempotency[key.Key] = held
+	return nil
+}
+
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error {
+	_, span := startSpan(ctx, "storage/ReleaseIdempotencyKey")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	held, ok := s.idempotency[key.Key]
+	if ok && held.Fingerprint == key.Fingerprint && held.Status == 0 {
+		delete(s.idempotency, key.Key)
+	}
+	return nil
+}
+
+func (s *Storage) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	_, span := startSpan(ctx, "storage/DeleteExpiredIdempotencyKeys")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	deleted := 0
+	for k, key := range s.idempotency {
+		if !key.ExpiresAt.After(now) {
+			delete(s.idempotency, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+// The Patch methods set only the named fields of the stored entity, a missing
+// entity returns sql.ErrNoRows like the RETURNING of the SQL storages
+
+func (s *Storage) PatchProject(ctx context.Context, project_id int, project models.Project, fields []string) (models.Project, error) {
+	_, span := startSpan(ctx, "storage/PatchProject")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	stored, err := get(s.projects, project_id)
+	if err != nil {
+		return models.Project{}, err
+	}
+	err = models.SetFields(&stored, project, fields)
+	if err != nil {
+		return models.Project{}, err
+	}
+	put(s, s.projects, project_id, stored)
+	return stored, nil
+}
+
+func (s *Storage) PatchGraph(ctx context.Context, graph_id int, graph models.Graph, fields []string) (models.Graph, error) {
+	_, span := startSpan(ctx, "storage/PatchGraph")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	stored, err := get(s.graphs, graph_id)
+	if err != nil {
+		return models.Graph{}, err
+	}
+	err = models.SetFields(&stored, graph, fields)
+	if err != nil {
+		return models.Graph{}, err
+	}
+	if _, ok := s.projects[stored.ProjectID]; !ok {
+		return models.Graph{}, ErrReference
+	}
+	put(s, s.graphs, graph_id, stored)
+	return stored, nil
+}
+
+func (s *Storage) PatchService(ctx context.Context, service_id int, service models.Service, fields []string) (models.Service, error) {
+	_, span := startSpan(ctx, "storage/PatchService")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	stored, err := get(s.services, service_id)
+	if err != nil {
+		return models.Service{}, err
+	}
+	err = models.SetFields(&stored, copyService(service), fields)
+	if err != nil {
+		return models.Service{}, err
+	}
+	if models.InheritsCatalog(stored, fields) {
+		stored, err = s.inheritCatalogService(stored)
+		if err != nil {
+			return models.Service{}, err
+		}
+	}
+	err = s.checkService(stored)
+	if err != nil {
+		return models.Service{}, err
+	}
+	put(s, s.services, service_id, copyService(stored))
+	return copyService(stored), nil
+}
+
+func (s *Storage) PatchRelation(ctx context.Context, relation_id int, relation models.Relation, fields []string) (models.Relation, error) {
+	_, span := startSpan(ctx, "storage/PatchRelation")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	stored, err := get(s.relations, relation_id)
+	if err != nil {
+		return models.Relation{}, err
+	}
+	err = models.SetFields(&stored, relation, fields)
+	if err != nil {
+		return models.Relation{}, err
+	}
+	err = s.checkRelation(stored)
+	if err != nil {
+		return models.Relation{}, err
+	}
+	put(s, s.relations, relation_id, stored)
+	return stored, nil
+}
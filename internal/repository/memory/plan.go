@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+// ApplyProjectPlan computes the plan with the context of its transaction and
+// applies the whole of it or nothing. The transaction holds the write lock,
+// so concurrent applies of a project run one after another and each plans
+// against the state the other left. Deletions go first, then graphs,
+// services and relations are created or updated; entities created by the
+// plan get their IDs filled in the returned plan.
+func (s *Storage) ApplyProjectPlan(ctx context.Context, project_id int, plan func(ctx context.Context) (models.ProjectPlan, error)) (models.ProjectPlan, error) {
+	ctx, span := startSpan(ctx, "storage/ApplyProjectPlan")
+	defer span.End()
+
+	var applied models.ProjectPlan
+	err := s.InTx(ctx, func(ctx context.Context) error {
+		if _, ok := s.projects[project_id]; !ok {
+			return sql.ErrNoRows
+		}
+		var err error
+		applied, err = plan(ctx)
+		if err != nil {
+			return err
+		}
+		return s.applyProjectPlan(project_id, &applied)
+	})
+	if err != nil {
+		return models.ProjectPlan{}, err
+	}
+	return applied, nil
+}
+
+func (s *Storage) applyProjectPlan(project_id int, plan *models.ProjectPlan) error {
+	inProject := func(graph_id int) bool {
+		return s.graphs[graph_id].ProjectID == project_id
+	}
+
+	// Deletions and updates are limited to the project, so a plan cannot
+	// touch foreign entities by ID
+	for _, relation := range plan.DeleteRelations {
+		if old, ok := s.relations[relation.ID]; ok && inProject(old.GraphID) {
+			remove(s, s.relations, relation.ID)
+		}
+	}
+	for _, service := range plan.DeleteServices {
+		if old, ok := s.services[service.ID]; ok && inProject(old.GraphID) {
+			s.deleteService(service.ID)
+		}
+	}
+	for _, graph := range plan.DeleteGraphs {
+		if inProject(graph.ID) {
+			s.deleteGraph(graph.ID)
+		}
+	}
+
+	graphs := make(map[string]int)
+	for i, graph := range plan.CreateGraphs {
+		graph.ProjectID = project_id
+		graph, err := s.createGraph(graph)
+		if err != nil {
+			return err
+		}
+		plan.CreateGraphs[i] = graph
+	}
+	for _, graph := range s.graphs {
+		if graph.ProjectID == project_id {
+			graphs[graph.Name] = graph.ID
+		}
+	}
+
+	for i, planned := range plan.CreateServices {
+		service := planned.Service
+		graph_id, ok := graphs[planned.GraphName]
+		if !ok {
+			return fmt.Errorf("graph %q of service %q not found", planned.GraphName, service.Name)
+		}
+		service.GraphID = graph_id
+		service.ID = s.nextID("services")
+		err := s.checkService(service)
+		if err != nil {
+			return err
+		}
+		put(s, s.services, service.ID, copyService(service))
+		plan.CreateServices[i].Service = service
+	}
+	for _, service := range plan.UpdateServices {
+		old, ok := s.services[service.ID]
+		if !ok || !inProject(old.GraphID) {
+			continue
+		}
+		// The plan cannot move services, link them to groups or the catalog
+		service.GraphID = old.GraphID
+		service.GroupID = old.GroupID
+		service.CatalogID = old.CatalogID
+		put(s, s.services, service.ID, copyService(service))
+	}
+
+	services := make(map[[2]string]int)
+	for _, service := range s.services {
+		graph := s.graphs[service.GraphID]
+		if graph.ProjectID == project_id {
+			services[[2]string{graph.Name, service.Name}] = service.ID
+		}
+	}
+	resolve := func(planned models.PlannedRelation) (models.Relation, error) {
+		relation := planned.Relation
+		var ok bool
+		relation.GraphID, ok = graphs[planned.GraphName]
+		if !ok {
+			return models.Relation{}, fmt.Errorf("graph %q of relation %q not found", planned.GraphName, relation.Name)
+		}
+		relation.FromService, ok = services[[2]string{planned.GraphName, planned.From}]
+		if !ok {
+			return models.Relation{}, fmt.Errorf("service %q of relation %q not found", planned.From, relation.Name)
+		}
+		relation.ToService, ok = services[[2]string{planned.GraphName, planned.To}]
+		if !ok {
+			return models.Relation{}, fmt.Errorf("service %q of relation %q not found", planned.To, relation.Name)
+		}
+		return relation, nil
+	}
+	for i, planned := range plan.CreateRelations {
+		relation, err := resolve(planned)
+		if err != nil {
+			return err
+		}
+		relation, err = s.createRelation(relation)
+		if err != nil {
+			return err
+		}
+		plan.CreateRelations[i].Relation = relation
+	}
+	for i, planned := range plan.UpdateRelations {
+		if old, ok := s.relations[planned.Relation.ID]; !ok || !inProject(old.GraphID) {
+			continue
+		}
+		relation, err := resolve(planned)
+		if err != nil {
+			return err
+		}
+		err = s.updateRelation(relation.ID, relation)
+		if err != nil {
+			return err
+		}
+		plan.UpdateRelations[i].Relation = relation
+	}
+	return nil
+}
@@ -0,0 +1,635 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/hse-telescope/core/internal/metrics"
+	"github.com/hse-telescope/core/internal/repository/models"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrReference mirrors a foreign key violation of the Postgres schema
+var ErrReference = errors.New("referenced entity does not exist")
+
+// tables hold copies of the stored entities. Entities are replaced as a
+// whole and never modified in place, so putting the previous row back undoes
+// a write. Writes go through put and remove, which keep the undo log of the
+// running transaction.
+type tables struct {
+	projects   map[int]models.Project
+	graphs     map[int]models.Graph
+	services   map[int]models.Service
+	relations  map[int]models.Relation
+	catalog    map[int]models.CatalogService
+	groups     map[int]models.Group
+	webhooks   map[int]models.Webhook
+	outbox     map[int]models.WebhookEvent
+	deliveries map[int]models.WebhookDelivery
+}
+
+// Storage keeps everything in process memory with the semantics of the
+// Postgres storage: IDs come from per table sequences, deletes cascade the
+// way the foreign keys of the migrations do and references are checked on
+// every write. Getting or updating a missing entity returns sql.ErrNoRows,
+// deleting one does nothing.
+type Storage struct {
+	mu  sync.RWMutex
+	seq map[string]int
+	tables
+	// undo reverts the writes of the running transactions in reverse order,
+	// depth counts the transactions nested in one another
+	undo  []func()
+	depth int
+	// idempotency keys are not entities, transactions leave them alone
+	idempotency map[string]models.IdempotencyKey
+}
+
+// startSpan starts the span of a storage method and times the method
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return metrics.StartStorageSpan(ctx, "memory", name)
+}
+
+func New() *Storage {
+	return &Storage{
+		seq:         make(map[string]int),
+		idempotency: make(map[string]models.IdempotencyKey),
+		tables: tables{
+			projects:   make(map[int]models.Project),
+			graphs:     make(map[int]models.Graph),
+			services:   make(map[int]models.Service),
+			relations:  make(map[int]models.Relation),
+			catalog:    make(map[int]models.CatalogService),
+			groups:     make(map[int]models.Group),
+			webhooks:   make(map[int]models.Webhook),
+			outbox:     make(map[int]models.WebhookEvent),
+			deliveries: make(map[int]models.WebhookDelivery),
+		},
+	}
+}
+
+// nextID works like a SERIAL column: IDs are never reused, even when the
+// write allocating them fails
+func (s *Storage) nextID(table string) int {
+	s.seq[table]++
+	return s.seq[table]
+}
+
+// tx runs f on the tables and undoes its writes if it fails. A nested
+// transaction only undoes its own writes, those of a successful one are
+// undone with the enclosing transaction.
+func (s *Storage) tx(f func() error) error {
+	mark := len(s.undo)
+	s.depth++
+	err := f()
+	s.depth--
+	if err != nil {
+		for i := len(s.undo) - 1; i >= mark; i-- {
+			s.undo[i]()
+		}
+		s.undo = s.undo[:mark]
+	}
+	if s.depth == 0 {
+		s.undo = nil
+	}
+	return err
+}
+
+// record adds the undo of a write of the row to the log while a transaction
+// runs
+func record[T any](s *Storage, table map[int]T, id int) {
+	if s.depth == 0 {
+		return
+	}
+	old, ok := table[id]
+	s.undo = append(s.undo, func() {
+		if ok {
+			table[id] = old
+		} else {
+			delete(table, id)
+		}
+	})
+}
+
+// put stores the row under id
+func put[T any](s *Storage, table map[int]T, id int, row T) {
+	record(s, table, id)
+	table[id] = row
+}
+
+// remove deletes the row stored under id
+func remove[T any](s *Storage, table map[int]T, id int) {
+	record(s, table, id)
+	delete(table, id)
+}
+
+type txKey struct{}
+
+// InTx runs fn holding the write lock and restores the tables if it fails.
+// The storage methods called with the context fn gets run under the same
+// lock.
+func (s *Storage) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) == s {
+		return fn(ctx)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tx(func() error {
+		return fn(context.WithValue(ctx, txKey{}, s))
+	})
+}
+
+// lock takes the write lock unless a transaction of ctx holds it already and
+// returns its release
+func (s *Storage) lock(ctx context.Context) func() {
+	if ctx.Value(txKey{}) == s {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+// rlock is lock for readers
+func (s *Storage) rlock(ctx context.Context) func() {
+	if ctx.Value(txKey{}) == s {
+		return func() {}
+	}
+	s.mu.RLock()
+	return s.mu.RUnlock
+}
+
+// sorted returns the rows matching keep ordered by ID
+func sorted[T any](table map[int]T, keep func(T) bool) []T {
+	res := make([]T, 0)
+	for _, id := range slices.Sorted(maps.Keys(table)) {
+		if keep == nil || keep(table[id]) {
+			res = append(res, table[id])
+		}
+	}
+	return res
+}
+
+func get[T any](table map[int]T, id int) (T, error) {
+	row, ok := table[id]
+	if !ok {
+		var zero T
+		return zero, sql.ErrNoRows
+	}
+	return row, nil
+}
+
+func (s *Storage) GetProjects(ctx context.Context) ([]models.Project, error) {
+	_, span := startSpan(ctx, "storage/GetProjects")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	return sorted(s.projects, nil), nil
+}
+
+func (s *Storage) GetProject(ctx context.Context, project_id int) (models.Project, error) {
+	_, span := startSpan(ctx, "storage/GetProject")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	return get(s.projects, project_id)
+}
+
+func (s *Storage) CreateProject(ctx context.Context, project models.Project) (models.Project, error) {
+	_, span := startSpan(ctx, "storage/CreateProject")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	project.ID = s.nextID("projects")
+	put(s, s.projects, project.ID, project)
+	return project, nil
+}
+
+func (s *Storage) UpdateProject(ctx context.Context, project_id int, project models.Project) error {
+	_, span := startSpan(ctx, "storage/UpdateProject")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	if _, ok := s.projects[project_id]; !ok {
+		return sql.ErrNoRows
+	}
+	project.ID = project_id
+	put(s, s.projects, project_id, project)
+	return nil
+}
+
+func (s *Storage) DeleteProject(ctx context.Context, project_id int) error {
+	_, span := startSpan(ctx, "storage/DeleteProject")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	s.deleteProject(project_id)
+	return nil
+}
+
+func (s *Storage) deleteProject(project_id int) {
+	remove(s, s.projects, project_id)
+	for id, graph := range s.graphs {
+		if graph.ProjectID == project_id {
+			s.deleteGraph(id)
+		}
+	}
+	for id, service := range s.catalog {
+		if service.ProjectID == project_id {
+			s.deleteCatalogService(id)
+		}
+	}
+	for id, webhook := range s.webhooks {
+		if webhook.ProjectID == project_id {
+			s.deleteWebhook(id)
+		}
+	}
+}
+
+func (s *Storage) CreateGraph(ctx context.Context, graph models.Graph) (models.Graph, error) {
+	_, span := startSpan(ctx, "storage/CreateGraph")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	return s.createGraph(graph)
+}
+
+func (s *Storage) createGraph(graph models.Graph) (models.Graph, error) {
+	graph.ID = s.nextID("graphs")
+	if _, ok := s.projects[graph.ProjectID]; !ok {
+		return graph, ErrReference
+	}
+	put(s, s.graphs, graph.ID, graph)
+	return graph, nil
+}
+
+func (s *Storage) DeleteGraph(ctx context.Context, graph_id int) error {
+	_, span := startSpan(ctx, "storage/DeleteGraph")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	s.deleteGraph(graph_id)
+	return nil
+}
+
+func (s *Storage) deleteGraph(graph_id int) {
+	remove(s, s.graphs, graph_id)
+	for id, service := range s.services {
+		if service.GraphID == graph_id {
+			s.deleteService(id)
+		}
+	}
+	for id, relation := range s.relations {
+		if relation.GraphID == graph_id {
+			remove(s, s.relations, id)
+		}
+	}
+	for id, group := range s.groups {
+		if group.GraphID == graph_id {
+			s.deleteGroup(id)
+		}
+	}
+}
+
+func (s *Storage) UpdateGraphServices(ctx context.Context, graph_id int, services []models.Service) error {
+	ctx, span := startSpan(ctx, "storage/UpdateGraphServices")
+	defer span.End()
+
+	for _, service := range services {
+		service.GraphID = graph_id
+		err := s.UpdateService(ctx, service.ID, service)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Storage) UpdateGraphRelations(ctx context.Context, graph_id int, relations []models.Relation) error {
+	ctx, span := startSpan(ctx, "storage/UpdateGraphRelations")
+	defer span.End()
+
+	for _, relation := range relations {
+		relation.GraphID = graph_id
+		err := s.UpdateRelation(ctx, relation.ID, relation)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Storage) UpdateGraph(ctx context.Context, graph_id int, graph models.Graph) error {
+	_, span := startSpan(ctx, "storage/UpdateGraph")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	if _, ok := s.graphs[graph_id]; !ok {
+		return sql.ErrNoRows
+	}
+	if _, ok := s.projects[graph.ProjectID]; !ok {
+		return ErrReference
+	}
+	graph.ID = graph_id
+	put(s, s.graphs, graph_id, graph)
+	return nil
+}
+
+func (s *Storage) GetGraph(ctx context.Context, graph_id int) (models.Graph, error) {
+	_, span := startSpan(ctx, "storage/GetGraph")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	return get(s.graphs, graph_id)
+}
+
+func (s *Storage) GetProjectGraphs(ctx context.Context, project_id int) ([]models.Graph, error) {
+	_, span := startSpan(ctx, "storage/GetProjectGraphs")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	return sorted(s.graphs, func(graph models.Graph) bool {
+		return graph.ProjectID == project_id
+	}), nil
+}
+
+func (s *Storage) GetService(ctx context.Context, service_id int) (models.Service, error) {
+	_, span := startSpan(ctx, "storage/GetService")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	service, err := get(s.services, service_id)
+	return copyService(service), err
+}
+
+func (s *Storage) GetGraphServices(ctx context.Context, graph_id int, filter models.ServiceFilter) ([]models.Service, error) {
+	_, span := startSpan(ctx, "storage/GetGraphServices")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	services := sorted(s.services, func(service models.Service) bool {
+		return service.GraphID == graph_id && matchService(service, filter)
+	})
+	for i := range services {
+		services[i] = copyService(services[i])
+	}
+	return services, nil
+}
+
+// matchService works like the containment operators the Postgres storage
+// filters tags and attributes with
+func matchService(service models.Service, filter models.ServiceFilter) bool {
+	if filter.Kind != "" && service.Kind != filter.Kind {
+		return false
+	}
+	if filter.OwnerTeam != "" && service.OwnerTeam != filter.OwnerTeam {
+		return false
+	}
+	for _, tag := range filter.Tags {
+		if !slices.Contains(service.Tags, tag) {
+			return false
+		}
+	}
+	for key, value := range filter.Attributes {
+		if v, ok := service.Attributes[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Storage) UpdateService(ctx context.Context, service_id int, service models.Service) error {
+	_, span := startSpan(ctx, "storage/UpdateService")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	return s.updateService(service_id, service)
+}
+
+func (s *Storage) updateService(service_id int, service models.Service) error {
+	service, err := s.inheritCatalogService(service)
+	if err != nil {
+		return err
+	}
+	if _, ok := s.services[service_id]; !ok {
+		return sql.ErrNoRows
+	}
+	err = s.checkService(service)
+	if err != nil {
+		return err
+	}
+	service.ID = service_id
+	put(s, s.services, service_id, copyService(service))
+	return nil
+}
+
+func (s *Storage) DeleteService(ctx context.Context, service_id int) error {
+	_, span := startSpan(ctx, "storage/DeleteService")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	s.deleteService(service_id)
+	return nil
+}
+
+func (s *Storage) deleteService(service_id int) {
+	remove(s, s.services, service_id)
+	for id, relation := range s.relations {
+		if relation.FromService == service_id || relation.ToService == service_id {
+			remove(s, s.relations, id)
+		}
+	}
+}
+
+func (s *Storage) CreateService(ctx context.Context, service models.Service) (models.Service, error) {
+	_, span := startSpan(ctx, "storage/CreateService")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	return s.createService(service)
+}
+
+func (s *Storage) createService(service models.Service) (models.Service, error) {
+	service, err := s.inheritCatalogService(service)
+	if err != nil {
+		return models.Service{}, err
+	}
+	service.ID = s.nextID("services")
+	err = s.checkService(service)
+	if err != nil {
+		return service, err
+	}
+	service = copyService(service)
+	put(s, s.services, service.ID, service)
+	return copyService(service), nil
+}
+
+// checkService checks the graph, the group of the same graph and the catalog
+// entry the service references
+func (s *Storage) checkService(service models.Service) error {
+	if _, ok := s.graphs[service.GraphID]; !ok {
+		return ErrReference
+	}
+	if service.GroupID != nil {
+		group, ok := s.groups[*service.GroupID]
+		if !ok || group.GraphID != service.GraphID {
+			return ErrReference
+		}
+	}
+	if service.CatalogID != nil {
+		if _, ok := s.catalog[*service.CatalogID]; !ok {
+			return ErrReference
+		}
+	}
+	return nil
+}
+
+func (s *Storage) CreateServices(ctx context.Context, graph_id int, services []models.Service) ([]int, error) {
+	ctx, span := startSpan(ctx, "storage/CreateServices")
+	defer span.End()
+
+	var res []int
+	for _, service := range services {
+		service.GraphID = graph_id
+		serv, err := s.CreateService(ctx, service)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, serv.ID)
+	}
+	return res, nil
+}
+
+func (s *Storage) GetRelation(ctx context.Context, relation_id int) (models.Relation, error) {
+	_, span := startSpan(ctx, "storage/GetRelation")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	return get(s.relations, relation_id)
+}
+
+func (s *Storage) GetGraphRelations(ctx context.Context, graph_id int) ([]models.Relation, error) {
+	_, span := startSpan(ctx, "storage/GetGraphRelations")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	return sorted(s.relations, func(relation models.Relation) bool {
+		return relation.GraphID == graph_id
+	}), nil
+}
+
+func (s *Storage) CreateRelation(ctx context.Context, relation models.Relation) (models.Relation, error) {
+	_, span := startSpan(ctx, "storage/CreateRelation")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	return s.createRelation(relation)
+}
+
+func (s *Storage) createRelation(relation models.Relation) (models.Relation, error) {
+	relation.ID = s.nextID("relations")
+	err := s.checkRelation(relation)
+	if err != nil {
+		return relation, err
+	}
+	put(s, s.relations, relation.ID, relation)
+	return relation, nil
+}
+
+func (s *Storage) checkRelation(relation models.Relation) error {
+	_, graph := s.graphs[relation.GraphID]
+	_, from := s.services[relation.FromService]
+	_, to := s.services[relation.ToService]
+	if !graph || !from || !to {
+		return ErrReference
+	}
+	return nil
+}
+
+func (s *Storage) CreateRelations(ctx context.Context, graph_id int, relations []models.Relation) error {
+	ctx, span := startSpan(ctx, "storage/CreateRelations")
+	defer span.End()
+
+	for _, relation := range relations {
+		relation.GraphID = graph_id
+		_, err := s.CreateRelation(ctx, relation)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Storage) UpdateRelation(ctx context.Context, relation_id int, relation models.Relation) error {
+	_, span := startSpan(ctx, "storage/UpdateRelation")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	return s.updateRelation(relation_id, relation)
+}
+
+func (s *Storage) updateRelation(relation_id int, relation models.Relation) error {
+	if _, ok := s.relations[relation_id]; !ok {
+		return sql.ErrNoRows
+	}
+	err := s.checkRelation(relation)
+	if err != nil {
+		return err
+	}
+	relation.ID = relation_id
+	put(s, s.relations, relation_id, relation)
+	return nil
+}
+
+func (s *Storage) DeleteRelation(ctx context.Context, relation_id int) error {
+	_, span := startSpan(ctx, "storage/DeleteRelation")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	remove(s, s.relations, relation_id)
+	return nil
+}
+
+// copyService keeps callers from sharing tags and attributes with the
+// stored service. Like Postgres it never returns nil ones.
+func copyService(service models.Service) models.Service {
+	service.Tags = copyTags(service.Tags)
+	service.Attributes = copyAttributes(service.Attributes)
+	service.GroupID = copyID(service.GroupID)
+	service.CatalogID = copyID(service.CatalogID)
+	return service
+}
+
+func copyTags(tags []string) []string {
+	return append(make([]string, 0, len(tags)), tags...)
+}
+
+func copyAttributes(attributes map[string]string) map[string]string {
+	res := make(map[string]string, len(attributes))
+	maps.Copy(res, attributes)
+	return res
+}
+
+func copyID(id *int) *int {
+	if id == nil {
+		return nil
+	}
+	v := *id
+	return &v
+}
+
+func (s *Storage) CountEntities(ctx context.Context) (models.EntityCounts, error) {
+	_, span := startSpan(ctx, "storage/CountEntities")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	return models.EntityCounts{
+		Projects:  len(s.projects),
+		Graphs:    len(s.graphs),
+		Services:  len(s.services),
+		Relations: len(s.relations),
+	}, nil
+}
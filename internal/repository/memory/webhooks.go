@@ -0,0 +1,191 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func (s *Storage) GetWebhook(ctx context.Context, webhook_id int) (models.Webhook, error) {
+	_, span := startSpan(ctx, "storage/GetWebhook")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	webhook, err := get(s.webhooks, webhook_id)
+	webhook.Events = copyTags(webhook.Events)
+	return webhook, err
+}
+
+func (s *Storage) GetProjectWebhooks(ctx context.Context, project_id int) ([]models.Webhook, error) {
+	_, span := startSpan(ctx, "storage/GetProjectWebhooks")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	webhooks := sorted(s.webhooks, func(webhook models.Webhook) bool {
+		return webhook.ProjectID == project_id
+	})
+	for i := range webhooks {
+		webhooks[i].Events = copyTags(webhooks[i].Events)
+	}
+	return webhooks, nil
+}
+
+func (s *Storage) CreateWebhook(ctx context.Context, webhook models.Webhook) (models.Webhook, error) {
+	_, span := startSpan(ctx, "storage/CreateWebhook")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	webhook.ID = s.nextID("webhooks")
+	if _, ok := s.projects[webhook.ProjectID]; !ok {
+		return webhook, ErrReference
+	}
+	stored := webhook
+	stored.Events = copyTags(webhook.Events)
+	put(s, s.webhooks, webhook.ID, stored)
+	return webhook, nil
+}
+
+// UpdateWebhook keeps the stored secret when webhook has none, secrets are
+// never handed out so clients cannot send them back
+func (s *Storage) UpdateWebhook(ctx context.Context, webhook_id int, webhook models.Webhook) error {
+	_, span := startSpan(ctx, "storage/UpdateWebhook")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	old, ok := s.webhooks[webhook_id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	old.URL = webhook.URL
+	if webhook.Secret != "" {
+		old.Secret = webhook.Secret
+	}
+	old.Events = copyTags(webhook.Events)
+	put(s, s.webhooks, webhook_id, old)
+	return nil
+}
+
+func (s *Storage) DeleteWebhook(ctx context.Context, webhook_id int) error {
+	_, span := startSpan(ctx, "storage/DeleteWebhook")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	s.deleteWebhook(webhook_id)
+	return nil
+}
+
+// deleteWebhook drops the pending outbox and the delivery log with it
+func (s *Storage) deleteWebhook(webhook_id int) {
+	remove(s, s.webhooks, webhook_id)
+	for id, event := range s.outbox {
+		if event.WebhookID == webhook_id {
+			remove(s, s.outbox, id)
+		}
+	}
+	for id, delivery := range s.deliveries {
+		if delivery.WebhookID == webhook_id {
+			remove(s, s.deliveries, id)
+		}
+	}
+}
+
+func (s *Storage) CreateWebhookEvents(ctx context.Context, events []models.WebhookEvent) error {
+	_, span := startSpan(ctx, "storage/CreateWebhookEvents")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	return s.tx(func() error {
+		now := time.Now()
+		for _, event := range events {
+			if _, ok := s.webhooks[event.WebhookID]; !ok {
+				return ErrReference
+			}
+			id := s.nextID("webhook_outbox")
+			put(s, s.outbox, id, models.WebhookEvent{
+				ID:            id,
+				WebhookID:     event.WebhookID,
+				Event:         event.Event,
+				Payload:       slices.Clone(event.Payload),
+				Status:        models.WebhookEventPending,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+			})
+		}
+		return nil
+	})
+}
+
+// ClaimWebhookEvents picks up to limit pending events that are due and pushes
+// their next attempt forward by lease
+func (s *Storage) ClaimWebhookEvents(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookEvent, error) {
+	_, span := startSpan(ctx, "storage/ClaimWebhookEvents")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	now := time.Now()
+	due := sorted(s.outbox, func(event models.WebhookEvent) bool {
+		return event.Status == models.WebhookEventPending && !event.NextAttemptAt.After(now)
+	})
+	slices.SortStableFunc(due, func(a, b models.WebhookEvent) int {
+		return a.NextAttemptAt.Compare(b.NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	events := make([]models.WebhookEvent, 0, len(due))
+	for _, event := range due {
+		event.NextAttemptAt = now.Add(lease)
+		put(s, s.outbox, event.ID, event)
+		event.Payload = slices.Clone(event.Payload)
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (s *Storage) UpdateWebhookEvent(ctx context.Context, event models.WebhookEvent) error {
+	_, span := startSpan(ctx, "storage/UpdateWebhookEvent")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	old, ok := s.outbox[event.ID]
+	if !ok {
+		return nil
+	}
+	old.Status = event.Status
+	old.Attempts = event.Attempts
+	old.NextAttemptAt = event.NextAttemptAt
+	put(s, s.outbox, event.ID, old)
+	return nil
+}
+
+func (s *Storage) CreateWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+	_, span := startSpan(ctx, "storage/CreateWebhookDelivery")
+	defer span.End()
+
+	defer s.lock(ctx)()
+	delivery.ID = s.nextID("webhook_deliveries")
+	_, webhook := s.webhooks[delivery.WebhookID]
+	_, event := s.outbox[delivery.EventID]
+	if !webhook || !event {
+		return ErrReference
+	}
+	delivery.CreatedAt = time.Now()
+	put(s, s.deliveries, delivery.ID, delivery)
+	return nil
+}
+
+func (s *Storage) GetWebhookDeliveries(ctx context.Context, webhook_id int) ([]models.WebhookDelivery, error) {
+	_, span := startSpan(ctx, "storage/GetWebhookDeliveries")
+	defer span.End()
+
+	defer s.rlock(ctx)()
+	deliveries := sorted(s.deliveries, func(delivery models.WebhookDelivery) bool {
+		return delivery.WebhookID == webhook_id
+	})
+	slices.Reverse(deliveries)
+	return deliveries, nil
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// CatalogFields are the columns a graph service referencing a catalog entry
+// takes from it
+var CatalogFields = []string{
+	"name", "description", "kind", "owner_team", "tags", "attributes", "docs_url", "runbook_url", "repo_url",
+}
+
+// InheritsCatalog tells whether writing the fields of a service has to copy
+// the catalog entry it references again
+func InheritsCatalog(service Service, fields []string) bool {
+	if service.CatalogID == nil {
+		return false
+	}
+	for _, field := range fields {
+		if field == "catalog_id" || field == "graph_id" || slices.Contains(CatalogFields, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldValues returns the values of the named columns of an entity
+func FieldValues[T any](entity T, fields []string) ([]any, error) {
+	v := reflect.ValueOf(entity)
+	values := make([]any, 0, len(fields))
+	for _, field := range fields {
+		i, err := fieldIndex(v.Type(), field)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v.Field(i).Interface())
+	}
+	return values, nil
+}
+
+// SetFields copies the named columns of src into dst
+func SetFields[T any](dst *T, src T, fields []string) error {
+	d, s := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src)
+	for _, field := range fields {
+		i, err := fieldIndex(s.Type(), field)
+		if err != nil {
+			return err
+		}
+		d.Field(i).Set(s.Field(i))
+	}
+	return nil
+}
+
+func fieldIndex(t reflect.Type, column string) (int, error) {
+	for i := 0; i < t.NumField(); i++ {
+		if column != "id" && t.Field(i).Tag.Get("db") == column {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%s has no column %q to patch", t.Name(), column)
+}
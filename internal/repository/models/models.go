@@ -1,30 +1,175 @@
 package models
 
+import (
+	"errors"
+	"time"
+)
+
+var ErrForeignCatalogService = errors.New("catalog service belongs to another project")
+
 type Project struct {
-	ID   int    `db:"id"`
-	Name string `db:"name"`
+	ID   int    `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
 }
 
 type Graph struct {
-	ID        int    `db:"id"`
-	ProjectID int    `db:"project_id"`
-	Name      string `db:"name"`
+	ID        int    `db:"id" json:"id"`
+	ProjectID int    `db:"project_id" json:"project_id"`
+	Name      string `db:"name" json:"name"`
 }
 
 type Service struct {
-	ID          int     `db:"id"`
-	GraphID     int     `db:"graph_id"`
-	Name        string  `db:"name"`
-	Description string  `db:"description"`
-	X           float32 `db:"x"`
-	Y           float32 `db:"y"`
+	ID          int               `db:"id" json:"id"`
+	GraphID     int               `db:"graph_id" json:"graph_id"`
+	Name        string            `db:"name" json:"name"`
+	Description string            `db:"description" json:"description"`
+	X           float32           `db:"x" json:"x"`
+	Y           float32           `db:"y" json:"y"`
+	GroupID     *int              `db:"group_id" json:"group_id"`
+	CatalogID   *int              `db:"catalog_id" json:"catalog_id"`
+	Kind        string            `db:"kind" json:"kind"`
+	OwnerTeam   string            `db:"owner_team" json:"owner_team"`
+	Tags        []string          `db:"tags" json:"tags"`
+	Attributes  map[string]string `db:"attributes" json:"attributes"`
+	DocsURL     string            `db:"docs_url" json:"docs_url"`
+	RunbookURL  string            `db:"runbook_url" json:"runbook_url"`
+	RepoURL     string            `db:"repo_url" json:"repo_url"`
+}
+
+// CatalogService is the canonical definition of a service shared by every
+// graph service of the project referencing it.
+type CatalogService struct {
+	ID          int               `db:"id" json:"id"`
+	ProjectID   int               `db:"project_id" json:"project_id"`
+	Name        string            `db:"name" json:"name"`
+	Description string            `db:"description" json:"description"`
+	Kind        string            `db:"kind" json:"kind"`
+	OwnerTeam   string            `db:"owner_team" json:"owner_team"`
+	Tags        []string          `db:"tags" json:"tags"`
+	Attributes  map[string]string `db:"attributes" json:"attributes"`
+	DocsURL     string            `db:"docs_url" json:"docs_url"`
+	RunbookURL  string            `db:"runbook_url" json:"runbook_url"`
+	RepoURL     string            `db:"repo_url" json:"repo_url"`
+}
+
+type Group struct {
+	ID          int     `db:"id" json:"id"`
+	GraphID     int     `db:"graph_id" json:"graph_id"`
+	ParentID    *int    `db:"parent_id" json:"parent_id"`
+	Name        string  `db:"name" json:"name"`
+	Description string  `db:"description" json:"description"`
+	Kind        string  `db:"kind" json:"kind"`
+	X           float32 `db:"x" json:"x"`
+	Y           float32 `db:"y" json:"y"`
+	Width       float32 `db:"width" json:"width"`
+	Height      float32 `db:"height" json:"height"`
+}
+
+// EntityCounts is the number of stored entities of each kind
+type EntityCounts struct {
+	Projects  int `db:"projects" json:"projects"`
+	Graphs    int `db:"graphs" json:"graphs"`
+	Services  int `db:"services" json:"services"`
+	Relations int `db:"relations" json:"relations"`
+}
+
+// ServiceFilter narrows down graph services. Zero fields match everything,
+// every tag and attribute has to be present on a service.
+type ServiceFilter struct {
+	Kind       string
+	OwnerTeam  string
+	Tags       []string
+	Attributes map[string]string
 }
 
 type Relation struct {
-	ID          int    `db:"id"`
-	GraphID     int    `db:"graph_id"`
-	Name        string `db:"name"`
-	Description string `db:"description"`
-	FromService int    `db:"from_service"`
-	ToService   int    `db:"to_service"`
+	ID                int     `db:"id" json:"id"`
+	GraphID           int     `db:"graph_id" json:"graph_id"`
+	Name              string  `db:"name" json:"name"`
+	Description       string  `db:"description" json:"description"`
+	FromService       int     `db:"from_service" json:"from_service"`
+	ToService         int     `db:"to_service" json:"to_service"`
+	Protocol          string  `db:"protocol" json:"protocol"`
+	Async             bool    `db:"async" json:"async"`
+	Bidirectional     bool    `db:"bidirectional" json:"bidirectional"`
+	Criticality       string  `db:"criticality" json:"criticality"`
+	ExpectedRPS       float64 `db:"expected_rps" json:"expected_rps"`
+	ExpectedLatencyMS float64 `db:"expected_latency_ms" json:"expected_latency_ms"`
+}
+
+type Webhook struct {
+	ID        int      `db:"id"`
+	ProjectID int      `db:"project_id"`
+	URL       string   `db:"url"`
+	Secret    string   `db:"secret"`
+	Events    []string `db:"events"`
+}
+
+const (
+	WebhookEventPending   = "pending"
+	WebhookEventDelivered = "delivered"
+	WebhookEventFailed    = "failed"
+)
+
+type WebhookEvent struct {
+	ID            int       `db:"id"`
+	WebhookID     int       `db:"webhook_id"`
+	Event         string    `db:"event"`
+	Payload       []byte    `db:"payload"`
+	Status        string    `db:"status"`
+	Attempts      int       `db:"attempts"`
+	NextAttemptAt time.Time `db:"next_attempt_at"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID         int       `db:"id"`
+	WebhookID  int       `db:"webhook_id"`
+	EventID    int       `db:"event_id"`
+	Event      string    `db:"event"`
+	Attempt    int       `db:"attempt"`
+	StatusCode int       `db:"status_code"`
+	Error      string    `db:"error"`
+	DurationMS int       `db:"duration_ms"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// IdempotencyKey holds the response to a request sent with an
+// Idempotency-Key header, so a retry gets it again instead of running the
+// request twice. Status is 0 while the request is in progress.
+type IdempotencyKey struct {
+	Key         string    `db:"key"`
+	Fingerprint string    `db:"fingerprint"`
+	Status      int       `db:"status"`
+	ContentType string    `db:"content_type"`
+	Body        []byte    `db:"body"`
+	CreatedAt   time.Time `db:"created_at"`
+	ExpiresAt   time.Time `db:"expires_at"`
+}
+
+// ProjectPlan lists the changes bringing a project to a declared state. Graphs
+// and services created by the plan have no IDs yet, so whatever refers to
+// them does it by name. Deleted entities are kept whole to be reported in
+// events.
+type ProjectPlan struct {
+	CreateGraphs    []Graph
+	DeleteGraphs    []Graph
+	CreateServices  []PlannedService
+	UpdateServices  []Service
+	DeleteServices  []Service
+	CreateRelations []PlannedRelation
+	UpdateRelations []PlannedRelation
+	DeleteRelations []Relation
+}
+
+type PlannedService struct {
+	GraphName string
+	Service   Service
+}
+
+type PlannedRelation struct {
+	GraphName string
+	From      string
+	To        string
+	Relation  Relation
 }
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+// Batch getters load the children of several parents in a single query, IDs
+// are bound as a JSON array.
+
+func (s DB) GetProjectsGraphs(ctx context.Context, project_ids []int) ([]models.Graph, error) {
+	ctx, span := startSpan(ctx, "storage/GetProjectsGraphs")
+	defer span.End()
+
+	q := `
+		SELECT
+			id,
+			project_id,
+			name
+		FROM graphs WHERE project_id IN (SELECT value FROM json_each($1))
+		ORDER BY id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, intArray(project_ids))
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, scanGraph)
+}
+
+func (s DB) GetGraphsServices(ctx context.Context, graph_ids []int) ([]models.Service, error) {
+	ctx, span := startSpan(ctx, "storage/GetGraphsServices")
+	defer span.End()
+
+	q := `
+		SELECT ` + serviceColumns + `
+		FROM services WHERE graph_id IN (SELECT value FROM json_each($1))
+		ORDER BY id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, intArray(graph_ids))
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, scanService)
+}
+
+// GetServicesRelations returns the relations going out of the given services
+func (s DB) GetServicesRelations(ctx context.Context, service_ids []int) ([]models.Relation, error) {
+	ctx, span := startSpan(ctx, "storage/GetServicesRelations")
+	defer span.End()
+
+	q := `
+		SELECT ` + relationColumns + `
+		FROM relations WHERE from_service IN (SELECT value FROM json_each($1))
+		ORDER BY id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, intArray(service_ids))
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, scanRelation)
+}
@@ -0,0 +1,169 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func (s DB) GetCatalogService(ctx context.Context, catalog_id int) (models.CatalogService, error) {
+	ctx, span := startSpan(ctx, "storage/GetCatalogService")
+	defer span.End()
+
+	q := `
+		SELECT ` + catalogServiceColumns + ` FROM catalog_services WHERE id = $1
+	`
+	return scanCatalogService(s.conn(ctx).QueryRowContext(ctx, q, catalog_id))
+}
+
+func (s DB) GetProjectCatalog(ctx context.Context, project_id int) ([]models.CatalogService, error) {
+	ctx, span := startSpan(ctx, "storage/GetProjectCatalog")
+	defer span.End()
+
+	q := `
+		SELECT ` + catalogServiceColumns + `
+		FROM catalog_services WHERE project_id = $1
+		ORDER BY name
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, project_id)
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, scanCatalogService)
+}
+
+func (s DB) CreateCatalogService(ctx context.Context, service models.CatalogService) (models.CatalogService, error) {
+	ctx, span := startSpan(ctx, "storage/CreateCatalogService")
+	defer span.End()
+
+	q := `
+		INSERT INTO catalog_services (
+			project_id, name, description, kind, owner_team, tags, attributes,
+			docs_url, runbook_url, repo_url
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id
+	`
+	tags, err := stringArray(service.Tags)
+	if err != nil {
+		return models.CatalogService{}, err
+	}
+	attributes, err := marshalAttributes(service.Attributes)
+	if err != nil {
+		return models.CatalogService{}, err
+	}
+	var newID int
+	err = s.conn(ctx).QueryRowContext(ctx, q,
+		service.ProjectID, service.Name, service.Description, service.Kind, service.OwnerTeam,
+		tags, attributes, service.DocsURL, service.RunbookURL, service.RepoURL,
+	).Scan(&newID)
+	service.ID = newID
+	return service, err
+}
+
+// UpdateCatalogService updates the catalog entry and copies its fields into
+// every graph service referencing it within the same transaction.
+func (s DB) UpdateCatalogService(ctx context.Context, catalog_id int, service models.CatalogService) error {
+	ctx, span := startSpan(ctx, "storage/UpdateCatalogService")
+	defer span.End()
+
+	tags, err := stringArray(service.Tags)
+	if err != nil {
+		return err
+	}
+	attributes, err := marshalAttributes(service.Attributes)
+	if err != nil {
+		return err
+	}
+
+	updateCatalog := `
+		UPDATE catalog_services
+		SET name = $1, description = $2, kind = $3, owner_team = $4, tags = $5, attributes = $6,
+			docs_url = $7, runbook_url = $8, repo_url = $9
+		WHERE id = $10
+	`
+	updateServices := `
+		UPDATE services
+		SET name = c.name, description = c.description, kind = c.kind, owner_team = c.owner_team,
+			tags = c.tags, attributes = c.attributes,
+			docs_url = c.docs_url, runbook_url = c.runbook_url, repo_url = c.repo_url
+		FROM catalog_services c
+		WHERE c.id = $1 AND services.catalog_id = c.id
+	`
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		err := updated(tx.ExecContext(ctx, updateCatalog,
+			service.Name, service.Description, service.Kind, service.OwnerTeam, tags, attributes,
+			service.DocsURL, service.RunbookURL, service.RepoURL, catalog_id,
+		))
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, updateServices, catalog_id)
+		return err
+	})
+}
+
+func (s DB) DeleteCatalogService(ctx context.Context, catalog_id int) error {
+	ctx, span := startSpan(ctx, "storage/DeleteCatalogService")
+	defer span.End()
+
+	q := `
+		DELETE FROM catalog_services WHERE id = $1
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, catalog_id)
+	return err
+}
+
+func (s DB) GetCatalogServiceGraphs(ctx context.Context, catalog_id int) ([]models.Graph, error) {
+	ctx, span := startSpan(ctx, "storage/GetCatalogServiceGraphs")
+	defer span.End()
+
+	q := `
+		SELECT DISTINCT
+			g.id,
+			g.project_id,
+			g.name
+		FROM graphs g
+		JOIN services s ON s.graph_id = g.id
+		WHERE s.catalog_id = $1
+		ORDER BY g.id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, catalog_id)
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, scanGraph)
+}
+
+// inheritCatalogService overwrites the shared fields of a graph service with
+// the ones of the catalog entry it references. The entry has to belong to
+// the project of the service graph.
+func (s DB) inheritCatalogService(ctx context.Context, service models.Service) (models.Service, error) {
+	if service.CatalogID == nil {
+		return service, nil
+	}
+
+	q := `
+		SELECT ` + catalogServiceColumns + `
+		FROM catalog_services
+		WHERE id = $1 AND project_id = (SELECT project_id FROM graphs WHERE id = $2)
+	`
+	catalog, err := scanCatalogService(s.conn(ctx).QueryRowContext(ctx, q, *service.CatalogID, service.GraphID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Service{}, models.ErrForeignCatalogService
+	}
+	if err != nil {
+		return models.Service{}, err
+	}
+
+	service.Name = catalog.Name
+	service.Description = catalog.Description
+	service.Kind = catalog.Kind
+	service.OwnerTeam = catalog.OwnerTeam
+	service.Tags = catalog.Tags
+	service.Attributes = catalog.Attributes
+	service.DocsURL = catalog.DocsURL
+	service.RunbookURL = catalog.RunbookURL
+	service.RepoURL = catalog.RepoURL
+	return service, nil
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func (s DB) GetGroup(ctx context.Context, group_id int) (models.Group, error) {
+	ctx, span := startSpan(ctx, "storage/GetGroup")
+	defer span.End()
+
+	q := `
+		SELECT ` + groupColumns + ` FROM service_groups WHERE id = $1
+	`
+	return scanGroup(s.conn(ctx).QueryRowContext(ctx, q, group_id))
+}
+
+func (s DB) GetGraphGroups(ctx context.Context, graph_id int) ([]models.Group, error) {
+	ctx, span := startSpan(ctx, "storage/GetGraphGroups")
+	defer span.End()
+
+	q := `
+		SELECT ` + groupColumns + ` FROM service_groups WHERE graph_id = $1
+		ORDER BY id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, graph_id)
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, scanGroup)
+}
+
+func (s DB) CreateGroup(ctx context.Context, group models.Group) (models.Group, error) {
+	ctx, span := startSpan(ctx, "storage/CreateGroup")
+	defer span.End()
+
+	q := `
+		INSERT INTO service_groups (graph_id, parent_id, name, description, kind, x, y, width, height)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id
+	`
+	var newID int
+	err := s.conn(ctx).QueryRowContext(ctx, q,
+		group.GraphID, group.ParentID, group.Name, group.Description, group.Kind,
+		group.X, group.Y, group.Width, group.Height,
+	).Scan(&newID)
+	group.ID = newID
+	return group, err
+}
+
+func (s DB) UpdateGroup(ctx context.Context, group_id int, group models.Group) error {
+	ctx, span := startSpan(ctx, "storage/UpdateGroup")
+	defer span.End()
+
+	q := `
+		UPDATE service_groups
+		SET parent_id = $1, name = $2, description = $3, kind = $4, x = $5, y = $6, width = $7, height = $8
+		WHERE id = $9
+	`
+	return updated(s.conn(ctx).ExecContext(ctx, q,
+		group.ParentID, group.Name, group.Description, group.Kind,
+		group.X, group.Y, group.Width, group.Height, group_id,
+	))
+}
+
+func (s DB) DeleteGroup(ctx context.Context, group_id int) error {
+	ctx, span := startSpan(ctx, "storage/DeleteGroup")
+	defer span.End()
+
+	q := `
+		DELETE FROM service_groups WHERE id = $1
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, group_id)
+	return err
+}
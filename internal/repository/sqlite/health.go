@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+)
+
+// Ping checks the database file is open
+func (s DB) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// CheckMigrations reports the last applied migration and fails when one of
+// the embedded migrations is missing
+func (s DB) CheckMigrations(ctx context.Context) (string, error) {
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return "", err
+	}
+	slices.Sort(names)
+
+	var version string
+	err = s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), '') FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the schema version: %w", err)
+	}
+	detail := "version " + path.Base(version)
+	for _, name := range names {
+		var applied bool
+		err = s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, name).Scan(&applied)
+		if err != nil {
+			return detail, err
+		}
+		if !applied {
+			return detail, fmt.Errorf("migration %s is not applied", name)
+		}
+	}
+	return detail, nil
+}
@@ -0,0 +1,151 @@
+-- The schema mirrors the Postgres migrations. Arrays and attributes are JSON
+-- text, timestamps are UTC and compare as text.
+
+CREATE TABLE IF NOT EXISTS projects (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT
+);
+
+CREATE TABLE IF NOT EXISTS graphs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+    name TEXT
+);
+
+CREATE TABLE IF NOT EXISTS catalog_services (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    kind TEXT NOT NULL DEFAULT '',
+    owner_team TEXT NOT NULL DEFAULT '',
+    tags TEXT NOT NULL DEFAULT '[]',
+    attributes TEXT NOT NULL DEFAULT '{}',
+    docs_url TEXT NOT NULL DEFAULT '',
+    runbook_url TEXT NOT NULL DEFAULT '',
+    repo_url TEXT NOT NULL DEFAULT '',
+    UNIQUE (project_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS service_groups (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    graph_id INTEGER NOT NULL REFERENCES graphs(id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES service_groups(id) ON DELETE SET NULL,
+    name TEXT,
+    description TEXT NOT NULL DEFAULT '',
+    kind TEXT NOT NULL DEFAULT '',
+    x REAL NOT NULL DEFAULT 0,
+    y REAL NOT NULL DEFAULT 0,
+    width REAL NOT NULL DEFAULT 0,
+    height REAL NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS services (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    graph_id INTEGER REFERENCES graphs(id) ON DELETE CASCADE,
+    name TEXT,
+    description TEXT,
+    x REAL,
+    y REAL,
+    kind TEXT NOT NULL DEFAULT '',
+    owner_team TEXT NOT NULL DEFAULT '',
+    tags TEXT NOT NULL DEFAULT '[]',
+    attributes TEXT NOT NULL DEFAULT '{}',
+    docs_url TEXT NOT NULL DEFAULT '',
+    runbook_url TEXT NOT NULL DEFAULT '',
+    repo_url TEXT NOT NULL DEFAULT '',
+    group_id INTEGER REFERENCES service_groups(id) ON DELETE SET NULL,
+    catalog_id INTEGER REFERENCES catalog_services(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS services_graph_id_idx ON services (graph_id);
+CREATE INDEX IF NOT EXISTS services_catalog_id_idx ON services (catalog_id);
+
+-- Postgres keeps groups within their graph with composite foreign keys, SQLite
+-- would null the graph too when the group goes away, so triggers check it
+CREATE TRIGGER IF NOT EXISTS services_group_graph_insert
+BEFORE INSERT ON services
+WHEN NEW.group_id IS NOT NULL AND NOT EXISTS (
+    SELECT 1 FROM service_groups WHERE id = NEW.group_id AND graph_id = NEW.graph_id
+)
+BEGIN
+    SELECT RAISE(ABORT, 'group belongs to another graph');
+END;
+
+CREATE TRIGGER IF NOT EXISTS services_group_graph_update
+BEFORE UPDATE OF group_id, graph_id ON services
+WHEN NEW.group_id IS NOT NULL AND NOT EXISTS (
+    SELECT 1 FROM service_groups WHERE id = NEW.group_id AND graph_id = NEW.graph_id
+)
+BEGIN
+    SELECT RAISE(ABORT, 'group belongs to another graph');
+END;
+
+CREATE TRIGGER IF NOT EXISTS service_groups_parent_graph_insert
+BEFORE INSERT ON service_groups
+WHEN NEW.parent_id IS NOT NULL AND NOT EXISTS (
+    SELECT 1 FROM service_groups WHERE id = NEW.parent_id AND graph_id = NEW.graph_id
+)
+BEGIN
+    SELECT RAISE(ABORT, 'parent group belongs to another graph');
+END;
+
+CREATE TRIGGER IF NOT EXISTS service_groups_parent_graph_update
+BEFORE UPDATE OF parent_id ON service_groups
+WHEN NEW.parent_id IS NOT NULL AND NOT EXISTS (
+    SELECT 1 FROM service_groups WHERE id = NEW.parent_id AND graph_id = NEW.graph_id
+)
+BEGIN
+    SELECT RAISE(ABORT, 'parent group belongs to another graph');
+END;
+
+CREATE TABLE IF NOT EXISTS relations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    graph_id INTEGER REFERENCES graphs(id) ON DELETE CASCADE,
+    name TEXT,
+    description TEXT,
+    from_service INTEGER REFERENCES services(id) ON DELETE CASCADE,
+    to_service INTEGER REFERENCES services(id) ON DELETE CASCADE,
+    protocol TEXT NOT NULL DEFAULT '',
+    async BOOLEAN NOT NULL DEFAULT FALSE,
+    bidirectional BOOLEAN NOT NULL DEFAULT FALSE,
+    criticality TEXT NOT NULL DEFAULT '',
+    expected_rps REAL NOT NULL DEFAULT 0,
+    expected_latency_ms REAL NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS relations_graph_id_idx ON relations (graph_id);
+CREATE INDEX IF NOT EXISTS relations_from_service_idx ON relations (from_service);
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL DEFAULT '',
+    events TEXT NOT NULL DEFAULT '[]'
+);
+
+CREATE TABLE IF NOT EXISTS webhook_outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id INTEGER REFERENCES webhooks(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_outbox_pending_idx ON webhook_outbox (next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id INTEGER REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id INTEGER REFERENCES webhook_outbox(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL
+);
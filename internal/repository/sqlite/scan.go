@@ -0,0 +1,178 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+const serviceColumns = `
+	id,
+	graph_id,
+	name,
+	description,
+	x,
+	y,
+	group_id,
+	catalog_id,
+	kind,
+	owner_team,
+	tags,
+	attributes,
+	docs_url,
+	runbook_url,
+	repo_url
+`
+
+func scanService(row scanner) (models.Service, error) {
+	var service models.Service
+	var tags, attributes string
+	err := row.Scan(
+		&service.ID, &service.GraphID, &service.Name, &service.Description, &service.X, &service.Y,
+		&service.GroupID, &service.CatalogID, &service.Kind, &service.OwnerTeam, &tags, &attributes,
+		&service.DocsURL, &service.RunbookURL, &service.RepoURL,
+	)
+	if err != nil {
+		return models.Service{}, err
+	}
+	err = json.Unmarshal([]byte(tags), &service.Tags)
+	if err != nil {
+		return models.Service{}, err
+	}
+	err = json.Unmarshal([]byte(attributes), &service.Attributes)
+	if err != nil {
+		return models.Service{}, err
+	}
+	return service, nil
+}
+
+const catalogServiceColumns = `
+	id,
+	project_id,
+	name,
+	description,
+	kind,
+	owner_team,
+	tags,
+	attributes,
+	docs_url,
+	runbook_url,
+	repo_url
+`
+
+func scanCatalogService(row scanner) (models.CatalogService, error) {
+	var service models.CatalogService
+	var tags, attributes string
+	err := row.Scan(
+		&service.ID, &service.ProjectID, &service.Name, &service.Description,
+		&service.Kind, &service.OwnerTeam, &tags, &attributes,
+		&service.DocsURL, &service.RunbookURL, &service.RepoURL,
+	)
+	if err != nil {
+		return models.CatalogService{}, err
+	}
+	err = json.Unmarshal([]byte(tags), &service.Tags)
+	if err != nil {
+		return models.CatalogService{}, err
+	}
+	err = json.Unmarshal([]byte(attributes), &service.Attributes)
+	if err != nil {
+		return models.CatalogService{}, err
+	}
+	return service, nil
+}
+
+const relationColumns = `
+	id,
+	graph_id,
+	name,
+	description,
+	from_service,
+	to_service,
+	protocol,
+	async,
+	bidirectional,
+	criticality,
+	expected_rps,
+	expected_latency_ms
+`
+
+func scanRelation(row scanner) (models.Relation, error) {
+	var relation models.Relation
+	err := row.Scan(
+		&relation.ID, &relation.GraphID, &relation.Name, &relation.Description, &relation.FromService, &relation.ToService,
+		&relation.Protocol, &relation.Async, &relation.Bidirectional, &relation.Criticality,
+		&relation.ExpectedRPS, &relation.ExpectedLatencyMS,
+	)
+	return relation, err
+}
+
+const groupColumns = `
+	id,
+	graph_id,
+	parent_id,
+	name,
+	description,
+	kind,
+	x,
+	y,
+	width,
+	height
+`
+
+func scanGroup(row scanner) (models.Group, error) {
+	var group models.Group
+	err := row.Scan(
+		&group.ID, &group.GraphID, &group.ParentID, &group.Name, &group.Description, &group.Kind,
+		&group.X, &group.Y, &group.Width, &group.Height,
+	)
+	return group, err
+}
+
+// stringArray keeps nil slices from turning into null
+func stringArray(values []string) (string, error) {
+	if values == nil {
+		values = []string{}
+	}
+	bytes, err := json.Marshal(values)
+	return string(bytes), err
+}
+
+func marshalAttributes(attributes map[string]string) (string, error) {
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	bytes, err := json.Marshal(attributes)
+	return string(bytes), err
+}
+
+// intArray is bound to json_each to stand in for Postgres = ANY($1)
+func intArray(values []int) string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		res = append(res, strconv.Itoa(v))
+	}
+	return "[" + strings.Join(res, ",") + "]"
+}
+
+// scanRows collects every row with scan
+func scanRows[T any](rows *sql.Rows, scan func(scanner) (T, error)) ([]T, error) {
+	defer rows.Close()
+
+	res := make([]T, 0)
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+	}
+	return res, rows.Err()
+}
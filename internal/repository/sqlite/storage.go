@@ -0,0 +1,506 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+
+	"github.com/hse-telescope/core/internal/metrics"
+	"github.com/hse-telescope/core/internal/repository/models"
+	"go.opentelemetry.io/otel/trace"
+	_ "modernc.org/sqlite"
+)
+
+type Config struct {
+	// Path of the database file, ":memory:" keeps it in memory
+	Path string `yaml:"path"`
+}
+
+// Validate reports an invalid value
+func (c Config) Validate() error {
+	if c.Path == "" {
+		return errors.New("path is required")
+	}
+	return nil
+}
+
+// DB stores everything in a single SQLite file with the semantics of the
+// Postgres storage. Writes are serialized through a single connection.
+type DB struct {
+	db *sql.DB
+}
+
+// startSpan starts the span of a storage method and times the method
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return metrics.StartStorageSpan(ctx, "sqlite", name)
+}
+
+func New(conf Config) (DB, error) {
+	dsn := url.URL{
+		Scheme:   "file",
+		Opaque:   conf.Path,
+		RawQuery: "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)",
+	}
+	db, err := sql.Open("sqlite", dsn.String())
+	if err != nil {
+		return DB{}, err
+	}
+	// An in-memory database lives as long as its connection
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	err = migrate(context.Background(), db)
+	if err != nil {
+		db.Close() // nolint:errcheck
+		return DB{}, err
+	}
+	return DB{
+		db: db,
+	}, nil
+}
+
+// Stats of the connection pool
+func (s DB) Stats() sql.DBStats {
+	return s.db.Stats()
+}
+
+// Close closes the connection pool once the queries in flight are done
+func (s DB) Close() error {
+	return s.db.Close()
+}
+
+func (s DB) GetProjects(ctx context.Context) ([]models.Project, error) {
+	ctx, span := startSpan(ctx, "storage/GetProjects")
+	defer span.End()
+
+	q := `
+		SELECT
+			id,
+			name
+		FROM projects
+		ORDER BY id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, func(row scanner) (models.Project, error) {
+		var project models.Project
+		err := row.Scan(&project.ID, &project.Name)
+		return project, err
+	})
+}
+
+func (s DB) GetProject(ctx context.Context, project_id int) (models.Project, error) {
+	ctx, span := startSpan(ctx, "storage/GetProject")
+	defer span.End()
+
+	q := `
+		SELECT id, name FROM projects WHERE id = $1
+	`
+	var project models.Project
+	err := s.conn(ctx).QueryRowContext(ctx, q, project_id).Scan(&project.ID, &project.Name)
+	if err != nil {
+		return models.Project{}, err
+	}
+	return project, nil
+}
+
+func (s DB) CreateProject(ctx context.Context, project models.Project) (models.Project, error) {
+	ctx, span := startSpan(ctx, "storage/CreateProject")
+	defer span.End()
+
+	q := `
+		INSERT INTO projects (name) VALUES ($1) RETURNING id
+	`
+	var newID int
+	err := s.conn(ctx).QueryRowContext(ctx, q, project.Name).Scan(&newID)
+	project.ID = newID
+	return project, err
+}
+
+func (s DB) UpdateProject(ctx context.Context, project_id int, project models.Project) error {
+	ctx, span := startSpan(ctx, "storage/UpdateProject")
+	defer span.End()
+
+	q := `
+		UPDATE projects
+		SET name = $1
+		WHERE id = $2
+	`
+	return updated(s.conn(ctx).ExecContext(ctx, q, project.Name, project_id))
+}
+
+func (s DB) DeleteProject(ctx context.Context, project_id int) error {
+	ctx, span := startSpan(ctx, "storage/DeleteProject")
+	defer span.End()
+
+	q := `
+		DELETE FROM projects
+		WHERE id = $1
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, project_id)
+	return err
+}
+
+func (s DB) CreateGraph(ctx context.Context, graph models.Graph) (models.Graph, error) {
+	ctx, span := startSpan(ctx, "storage/CreateGraph")
+	defer span.End()
+
+	q := `
+		INSERT INTO graphs (project_id, name) VALUES ($1, $2) RETURNING id
+	`
+	var newID int
+	err := s.conn(ctx).QueryRowContext(ctx, q, graph.ProjectID, graph.Name).Scan(&newID)
+	graph.ID = newID
+	return graph, err
+}
+
+func (s DB) DeleteGraph(ctx context.Context, graph_id int) error {
+	ctx, span := startSpan(ctx, "storage/DeleteGraph")
+	defer span.End()
+
+	q := `
+		DELETE FROM graphs WHERE id = $1
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, graph_id)
+	return err
+}
+
+func (s DB) UpdateGraphServices(ctx context.Context, graph_id int, services []models.Service) error {
+	ctx, span := startSpan(ctx, "storage/UpdateGraphServices")
+	defer span.End()
+
+	for _, service := range services {
+		service.GraphID = graph_id
+		err := s.UpdateService(ctx, service.ID, service)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s DB) UpdateGraphRelations(ctx context.Context, graph_id int, relations []models.Relation) error {
+	ctx, span := startSpan(ctx, "storage/UpdateGraphRelations")
+	defer span.End()
+
+	for _, relation := range relations {
+		relation.GraphID = graph_id
+		err := s.UpdateRelation(ctx, relation.ID, relation)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s DB) UpdateGraph(ctx context.Context, graph_id int, graph models.Graph) error {
+	ctx, span := startSpan(ctx, "storage/UpdateGraph")
+	defer span.End()
+
+	q := `
+		UPDATE graphs
+		SET project_id = $1, name = $2
+		WHERE id = $3
+	`
+	return updated(s.conn(ctx).ExecContext(ctx, q, graph.ProjectID, graph.Name, graph_id))
+}
+
+func (s DB) GetGraph(ctx context.Context, graph_id int) (models.Graph, error) {
+	ctx, span := startSpan(ctx, "storage/GetGraph")
+	defer span.End()
+
+	q := `
+		SELECT id, project_id, name FROM graphs WHERE id = $1
+	`
+	var graph models.Graph
+	err := s.conn(ctx).QueryRowContext(ctx, q, graph_id).Scan(&graph.ID, &graph.ProjectID, &graph.Name)
+	if err != nil {
+		return models.Graph{}, err
+	}
+	return graph, nil
+}
+
+func (s DB) GetProjectGraphs(ctx context.Context, project_id int) ([]models.Graph, error) {
+	ctx, span := startSpan(ctx, "storage/GetProjectGraphs")
+	defer span.End()
+
+	q := `
+		SELECT
+			id,
+			project_id,
+			name
+		FROM graphs WHERE project_id = $1
+		ORDER BY id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, project_id)
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, scanGraph)
+}
+
+func scanGraph(row scanner) (models.Graph, error) {
+	var graph models.Graph
+	err := row.Scan(&graph.ID, &graph.ProjectID, &graph.Name)
+	return graph, err
+}
+
+func (s DB) GetService(ctx context.Context, service_id int) (models.Service, error) {
+	ctx, span := startSpan(ctx, "storage/GetService")
+	defer span.End()
+
+	q := `
+		SELECT ` + serviceColumns + ` FROM services WHERE id = $1
+	`
+	return scanService(s.conn(ctx).QueryRowContext(ctx, q, service_id))
+}
+
+// GetGraphServices matches tags and attributes with json_each in place of
+// the Postgres containment operators
+func (s DB) GetGraphServices(ctx context.Context, graph_id int, filter models.ServiceFilter) ([]models.Service, error) {
+	ctx, span := startSpan(ctx, "storage/GetGraphServices")
+	defer span.End()
+
+	q := `
+		SELECT ` + serviceColumns + `
+		FROM services
+		WHERE graph_id = $1
+			AND ($2 = '' OR kind = $2)
+			AND ($3 = '' OR owner_team = $3)
+			AND NOT EXISTS (
+				SELECT 1 FROM json_each($4) f
+				WHERE f.value NOT IN (SELECT value FROM json_each(services.tags))
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM json_each($5) f
+				WHERE json_extract(services.attributes, '$."' || f.key || '"') IS NOT f.value
+			)
+		ORDER BY id
+	`
+	tags, err := stringArray(filter.Tags)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := marshalAttributes(filter.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.conn(ctx).QueryContext(ctx, q, graph_id, filter.Kind, filter.OwnerTeam, tags, attributes)
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, scanService)
+}
+
+func (s DB) UpdateService(ctx context.Context, service_id int, service models.Service) error {
+	ctx, span := startSpan(ctx, "storage/UpdateService")
+	defer span.End()
+
+	q := `
+		UPDATE services
+		SET graph_id = $1, name = $2, description = $3, x = $4, y = $5,
+			kind = $6, owner_team = $7, tags = $8, attributes = $9,
+			docs_url = $10, runbook_url = $11, repo_url = $12, group_id = $13, catalog_id = $14
+		WHERE id = $15
+	`
+	service, err := s.inheritCatalogService(ctx, service)
+	if err != nil {
+		return err
+	}
+	tags, err := stringArray(service.Tags)
+	if err != nil {
+		return err
+	}
+	attributes, err := marshalAttributes(service.Attributes)
+	if err != nil {
+		return err
+	}
+	return updated(s.conn(ctx).ExecContext(ctx, q,
+		service.GraphID, service.Name, service.Description, service.X, service.Y,
+		service.Kind, service.OwnerTeam, tags, attributes,
+		service.DocsURL, service.RunbookURL, service.RepoURL, service.GroupID, service.CatalogID, service_id,
+	))
+}
+
+func (s DB) DeleteService(ctx context.Context, service_id int) error {
+	ctx, span := startSpan(ctx, "storage/DeleteService")
+	defer span.End()
+
+	q := `
+		DELETE FROM services WHERE id = $1
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, service_id)
+	return err
+}
+
+func (s DB) CreateService(ctx context.Context, service models.Service) (models.Service, error) {
+	ctx, span := startSpan(ctx, "storage/CreateService")
+	defer span.End()
+
+	service, err := s.inheritCatalogService(ctx, service)
+	if err != nil {
+		return models.Service{}, err
+	}
+	return insertService(ctx, s.conn(ctx), service)
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// insertService is shared by CreateService and ApplyProjectPlan, it runs on
+// the database or within a transaction
+func insertService(ctx context.Context, db execer, service models.Service) (models.Service, error) {
+	q := `
+		INSERT INTO services (
+			graph_id, name, description, x, y,
+			kind, owner_team, tags, attributes,
+			docs_url, runbook_url, repo_url, group_id, catalog_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id
+	`
+	tags, err := stringArray(service.Tags)
+	if err != nil {
+		return models.Service{}, err
+	}
+	attributes, err := marshalAttributes(service.Attributes)
+	if err != nil {
+		return models.Service{}, err
+	}
+	var newID int
+	err = db.QueryRowContext(ctx, q,
+		service.GraphID, service.Name, service.Description, service.X, service.Y,
+		service.Kind, service.OwnerTeam, tags, attributes,
+		service.DocsURL, service.RunbookURL, service.RepoURL, service.GroupID, service.CatalogID,
+	).Scan(&newID)
+	service.ID = newID
+	return service, err
+}
+
+func (s DB) CreateServices(ctx context.Context, graph_id int, services []models.Service) ([]int, error) {
+	ctx, span := startSpan(ctx, "storage/CreateServices")
+	defer span.End()
+
+	var res []int
+	for _, service := range services {
+		service.GraphID = graph_id
+		serv, err := s.CreateService(ctx, service)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, serv.ID)
+	}
+	return res, nil
+}
+
+func (s DB) GetRelation(ctx context.Context, relation_id int) (models.Relation, error) {
+	ctx, span := startSpan(ctx, "storage/GetRelation")
+	defer span.End()
+
+	q := `
+		SELECT ` + relationColumns + ` FROM relations WHERE id = $1
+	`
+	return scanRelation(s.conn(ctx).QueryRowContext(ctx, q, relation_id))
+}
+
+func (s DB) GetGraphRelations(ctx context.Context, graph_id int) ([]models.Relation, error) {
+	ctx, span := startSpan(ctx, "storage/GetGraphRelations")
+	defer span.End()
+
+	q := `
+		SELECT ` + relationColumns + ` FROM relations WHERE graph_id = $1
+		ORDER BY id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, graph_id)
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, scanRelation)
+}
+
+func (s DB) CreateRelation(ctx context.Context, relation models.Relation) (models.Relation, error) {
+	ctx, span := startSpan(ctx, "storage/CreateRelation")
+	defer span.End()
+
+	return insertRelation(ctx, s.conn(ctx), relation)
+}
+
+func insertRelation(ctx context.Context, db execer, relation models.Relation) (models.Relation, error) {
+	q := `
+		INSERT INTO relations (
+			graph_id, name, description, from_service, to_service,
+			protocol, async, bidirectional, criticality, expected_rps, expected_latency_ms
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id
+	`
+	var newID int
+	err := db.QueryRowContext(ctx, q,
+		relation.GraphID, relation.Name, relation.Description, relation.FromService, relation.ToService,
+		relation.Protocol, relation.Async, relation.Bidirectional, relation.Criticality,
+		relation.ExpectedRPS, relation.ExpectedLatencyMS,
+	).Scan(&newID)
+	relation.ID = newID
+	return relation, err
+}
+
+func (s DB) CreateRelations(ctx context.Context, graph_id int, relations []models.Relation) error {
+	ctx, span := startSpan(ctx, "storage/CreateRelations")
+	defer span.End()
+
+	for _, relation := range relations {
+		relation.GraphID = graph_id
+		_, err := s.CreateRelation(ctx, relation)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s DB) UpdateRelation(ctx context.Context, relation_id int, relation models.Relation) error {
+	ctx, span := startSpan(ctx, "storage/UpdateRelation")
+	defer span.End()
+
+	q := `
+		UPDATE relations
+		SET graph_id = $1, name = $2, description = $3, from_service = $4, to_service = $5,
+			protocol = $6, async = $7, bidirectional = $8, criticality = $9,
+			expected_rps = $10, expected_latency_ms = $11
+		WHERE id = $12
+	`
+	return updated(s.conn(ctx).ExecContext(ctx, q,
+		relation.GraphID, relation.Name, relation.Description, relation.FromService, relation.ToService,
+		relation.Protocol, relation.Async, relation.Bidirectional, relation.Criticality,
+		relation.ExpectedRPS, relation.ExpectedLatencyMS, relation_id,
+	))
+}
+
+func (s DB) DeleteRelation(ctx context.Context, relation_id int) error {
+	ctx, span := startSpan(ctx, "storage/DeleteRelation")
+	defer span.End()
+
+	q := `
+		DELETE FROM relations WHERE id = $1
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, relation_id)
+	return err
+}
+
+func (s DB) CountEntities(ctx context.Context) (models.EntityCounts, error) {
+	ctx, span := startSpan(ctx, "storage/CountEntities")
+	defer span.End()
+
+	q := `
+		SELECT
+			(SELECT COUNT(*) FROM projects) AS projects,
+			(SELECT COUNT(*) FROM graphs) AS graphs,
+			(SELECT COUNT(*) FROM services) AS services,
+			(SELECT COUNT(*) FROM relations) AS relations
+	`
+	var counts models.EntityCounts
+	err := s.conn(ctx).QueryRowContext(ctx, q).Scan(&counts.Projects, &counts.Graphs, &counts.Services, &counts.Relations)
+	return counts, err
+}
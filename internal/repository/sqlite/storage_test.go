@@ -0,0 +1,21 @@
+package sqlite_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/sqlite"
+	"github.com/hse-telescope/core/internal/repository/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) facade.Storage {
+		storage, err := sqlite.New(sqlite.Config{Path: filepath.Join(t.TempDir(), "core.db")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { storage.Close() }) // nolint:errcheck
+		return storage
+	})
+}
@@ -0,0 +1,224 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func scanWebhook(row scanner) (models.Webhook, error) {
+	var webhook models.Webhook
+	var events string
+	err := row.Scan(&webhook.ID, &webhook.ProjectID, &webhook.URL, &webhook.Secret, &events)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	err = json.Unmarshal([]byte(events), &webhook.Events)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (s DB) GetWebhook(ctx context.Context, webhook_id int) (models.Webhook, error) {
+	ctx, span := startSpan(ctx, "storage/GetWebhook")
+	defer span.End()
+
+	q := `
+		SELECT id, project_id, url, secret, events FROM webhooks WHERE id = $1
+	`
+	return scanWebhook(s.conn(ctx).QueryRowContext(ctx, q, webhook_id))
+}
+
+func (s DB) GetProjectWebhooks(ctx context.Context, project_id int) ([]models.Webhook, error) {
+	ctx, span := startSpan(ctx, "storage/GetProjectWebhooks")
+	defer span.End()
+
+	q := `
+		SELECT
+			id,
+			project_id,
+			url,
+			secret,
+			events
+		FROM webhooks WHERE project_id = $1
+		ORDER BY id
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, project_id)
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, scanWebhook)
+}
+
+func (s DB) CreateWebhook(ctx context.Context, webhook models.Webhook) (models.Webhook, error) {
+	ctx, span := startSpan(ctx, "storage/CreateWebhook")
+	defer span.End()
+
+	q := `
+		INSERT INTO webhooks (project_id, url, secret, events) VALUES ($1, $2, $3, $4) RETURNING id
+	`
+	events, err := stringArray(webhook.Events)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	var newID int
+	err = s.conn(ctx).QueryRowContext(ctx, q, webhook.ProjectID, webhook.URL, webhook.Secret, events).Scan(&newID)
+	webhook.ID = newID
+	return webhook, err
+}
+
+// UpdateWebhook keeps the stored secret when webhook has none, secrets are
+// never handed out so clients cannot send them back
+func (s DB) UpdateWebhook(ctx context.Context, webhook_id int, webhook models.Webhook) error {
+	ctx, span := startSpan(ctx, "storage/UpdateWebhook")
+	defer span.End()
+
+	q := `
+		UPDATE webhooks
+		SET url = $1, secret = COALESCE(NULLIF($2, ''), secret), events = $3
+		WHERE id = $4
+	`
+	events, err := stringArray(webhook.Events)
+	if err != nil {
+		return err
+	}
+	return updated(s.conn(ctx).ExecContext(ctx, q, webhook.URL, webhook.Secret, events, webhook_id))
+}
+
+func (s DB) DeleteWebhook(ctx context.Context, webhook_id int) error {
+	ctx, span := startSpan(ctx, "storage/DeleteWebhook")
+	defer span.End()
+
+	q := `
+		DELETE FROM webhooks WHERE id = $1
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, webhook_id)
+	return err
+}
+
+func (s DB) CreateWebhookEvents(ctx context.Context, events []models.WebhookEvent) error {
+	ctx, span := startSpan(ctx, "storage/CreateWebhookEvents")
+	defer span.End()
+
+	q := `
+		INSERT INTO webhook_outbox (webhook_id, event, payload, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $4)
+	`
+	now := time.Now().UTC()
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, event := range events {
+			_, err := tx.ExecContext(ctx, q, event.WebhookID, event.Event, string(event.Payload), now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ClaimWebhookEvents picks up to limit pending events that are due and pushes
+// their next attempt forward by lease. Writes to SQLite are serialized, so
+// concurrent dispatchers never claim the same event.
+func (s DB) ClaimWebhookEvents(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookEvent, error) {
+	ctx, span := startSpan(ctx, "storage/ClaimWebhookEvents")
+	defer span.End()
+
+	q := `
+		UPDATE webhook_outbox
+		SET next_attempt_at = $3
+		WHERE id IN (
+			SELECT id FROM webhook_outbox
+			WHERE status = 'pending' AND next_attempt_at <= $2
+			ORDER BY next_attempt_at
+			LIMIT $1
+		)
+		RETURNING
+			id,
+			webhook_id,
+			event,
+			payload,
+			status,
+			attempts,
+			next_attempt_at,
+			created_at
+	`
+	now := time.Now().UTC()
+	rows, err := s.conn(ctx).QueryContext(ctx, q, limit, now, now.Add(lease))
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, func(row scanner) (models.WebhookEvent, error) {
+		var event models.WebhookEvent
+		var payload string
+		err := row.Scan(
+			&event.ID, &event.WebhookID, &event.Event, &payload, &event.Status,
+			&event.Attempts, &event.NextAttemptAt, &event.CreatedAt,
+		)
+		event.Payload = []byte(payload)
+		return event, err
+	})
+}
+
+func (s DB) UpdateWebhookEvent(ctx context.Context, event models.WebhookEvent) error {
+	ctx, span := startSpan(ctx, "storage/UpdateWebhookEvent")
+	defer span.End()
+
+	q := `
+		UPDATE webhook_outbox
+		SET status = $1, attempts = $2, next_attempt_at = $3
+		WHERE id = $4
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q, event.Status, event.Attempts, event.NextAttemptAt.UTC(), event.ID)
+	return err
+}
+
+func (s DB) CreateWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+	ctx, span := startSpan(ctx, "storage/CreateWebhookDelivery")
+	defer span.End()
+
+	q := `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event, attempt, status_code, error, duration_ms, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := s.conn(ctx).ExecContext(ctx, q,
+		delivery.WebhookID, delivery.EventID, delivery.Event, delivery.Attempt,
+		delivery.StatusCode, delivery.Error, delivery.DurationMS, time.Now().UTC(),
+	)
+	return err
+}
+
+func (s DB) GetWebhookDeliveries(ctx context.Context, webhook_id int) ([]models.WebhookDelivery, error) {
+	ctx, span := startSpan(ctx, "storage/GetWebhookDeliveries")
+	defer span.End()
+
+	q := `
+		SELECT
+			id,
+			webhook_id,
+			event_id,
+			event,
+			attempt,
+			status_code,
+			error,
+			duration_ms,
+			created_at
+		FROM webhook_deliveries WHERE webhook_id = $1
+		ORDER BY id DESC
+	`
+	rows, err := s.conn(ctx).QueryContext(ctx, q, webhook_id)
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rows, func(row scanner) (models.WebhookDelivery, error) {
+		var delivery models.WebhookDelivery
+		err := row.Scan(
+			&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.Event, &delivery.Attempt,
+			&delivery.StatusCode, &delivery.Error, &delivery.DurationMS, &delivery.CreatedAt,
+		)
+		return delivery, err
+	})
+}
@@ -0,0 +1,88 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func testBulk(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	graph := createGraph(t, s, createProject(t, s, "bulk").ID, "bulk")
+
+	// The graph of the batch wins over the one of each service
+	ids, err := s.CreateServices(ctx, graph.ID, []models.Service{
+		newService(missingID, "api"),
+		newService(missingID, "db"),
+	})
+	must(t, err)
+	if len(ids) != 2 || ids[0] == ids[1] {
+		t.Fatalf("created services get IDs %v", ids)
+	}
+	api := newService(graph.ID, "api")
+	api.ID = ids[0]
+	db := newService(graph.ID, "db")
+	db.ID = ids[1]
+	services, err := s.GetGraphServices(ctx, graph.ID, models.ServiceFilter{})
+	must(t, err)
+	equal(t, services, []models.Service{api, db})
+
+	api.X, db.X = 10, 20
+	must(t, s.UpdateGraphServices(ctx, graph.ID, []models.Service{api, db}))
+	services, err = s.GetGraphServices(ctx, graph.ID, models.ServiceFilter{})
+	must(t, err)
+	equal(t, services, []models.Service{api, db})
+
+	must(t, s.CreateRelations(ctx, graph.ID, []models.Relation{
+		newRelation(missingID, api.ID, db.ID),
+		newRelation(missingID, db.ID, api.ID),
+	}))
+	relations, err := s.GetGraphRelations(ctx, graph.ID)
+	must(t, err)
+	if len(relations) != 2 {
+		t.Fatalf("want 2 relations, got %+v", relations)
+	}
+	for i := range relations {
+		relations[i].Criticality = "low"
+	}
+	must(t, s.UpdateGraphRelations(ctx, graph.ID, relations))
+	got, err := s.GetGraphRelations(ctx, graph.ID)
+	must(t, err)
+	equal(t, got, relations)
+}
+
+func testBatch(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	first := createProject(t, s, "batch first")
+	second := createProject(t, s, "batch second")
+	skipped := createProject(t, s, "batch skipped")
+	firstGraph := createGraph(t, s, first.ID, "first")
+	secondGraph := createGraph(t, s, second.ID, "second")
+	createGraph(t, s, skipped.ID, "skipped")
+
+	graphs, err := s.GetProjectsGraphs(ctx, []int{first.ID, second.ID})
+	must(t, err)
+	equal(t, graphs, []models.Graph{firstGraph, secondGraph})
+
+	api := createService(t, s, newService(firstGraph.ID, "api"))
+	db := createService(t, s, newService(firstGraph.ID, "db"))
+	worker := createService(t, s, newService(secondGraph.ID, "worker"))
+	services, err := s.GetGraphsServices(ctx, []int{firstGraph.ID, secondGraph.ID})
+	must(t, err)
+	equal(t, services, []models.Service{api, db, worker})
+
+	// Relations are looked up by the service they start from
+	toDB := createRelation(t, s, newRelation(firstGraph.ID, api.ID, db.ID))
+	createRelation(t, s, newRelation(firstGraph.ID, db.ID, api.ID))
+	relations, err := s.GetServicesRelations(ctx, []int{api.ID, worker.ID})
+	must(t, err)
+	equal(t, relations, []models.Relation{toDB})
+
+	graphs, err = s.GetProjectsGraphs(ctx, nil)
+	must(t, err)
+	if len(graphs) != 0 {
+		t.Fatalf("no projects give graphs %+v", graphs)
+	}
+}
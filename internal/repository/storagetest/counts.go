@@ -0,0 +1,35 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+// testCountEntities only checks the counts grow by the entities it creates,
+// other entities of a shared database come and go meanwhile
+func testCountEntities(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	before, err := s.CountEntities(ctx)
+	must(t, err)
+
+	project := createProject(t, s, "counts")
+	graph := createGraph(t, s, project.ID, "counts")
+	from := createService(t, s, newService(graph.ID, "from"))
+	to := createService(t, s, newService(graph.ID, "to"))
+	createRelation(t, s, newRelation(graph.ID, from.ID, to.ID))
+
+	after, err := s.CountEntities(ctx)
+	must(t, err)
+	want := models.EntityCounts{
+		Projects:  before.Projects + 1,
+		Graphs:    before.Graphs + 1,
+		Services:  before.Services + 2,
+		Relations: before.Relations + 1,
+	}
+	if after.Projects < want.Projects || after.Graphs < want.Graphs || after.Services < want.Services || after.Relations < want.Relations {
+		t.Fatalf("counts went from %+v to %+v, want at least %+v", before, after, want)
+	}
+}
@@ -0,0 +1,104 @@
+package storagetest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+// missingID is never allocated by a backend under test
+const missingID = 1 << 30
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func equal(t *testing.T, got any, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func notFound(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("want sql.ErrNoRows, got %v", err)
+	}
+}
+
+func containsProject(projects []models.Project, project models.Project) bool {
+	return slices.Contains(projects, project)
+}
+
+func createProject(t *testing.T, s facade.Storage, name string) models.Project {
+	t.Helper()
+	project, err := s.CreateProject(context.Background(), models.Project{Name: name})
+	must(t, err)
+	if project.ID == 0 || project.Name != name {
+		t.Fatalf("project is not created: %+v", project)
+	}
+	return project
+}
+
+func createGraph(t *testing.T, s facade.Storage, project_id int, name string) models.Graph {
+	t.Helper()
+	graph, err := s.CreateGraph(context.Background(), models.Graph{ProjectID: project_id, Name: name})
+	must(t, err)
+	if graph.ID == 0 || graph.ProjectID != project_id || graph.Name != name {
+		t.Fatalf("graph is not created: %+v", graph)
+	}
+	return graph
+}
+
+// newService has every collection filled, backends differ in whether empty
+// ones come back nil
+func newService(graph_id int, name string) models.Service {
+	return models.Service{
+		GraphID:    graph_id,
+		Name:       name,
+		Tags:       []string{name},
+		Attributes: map[string]string{"name": name},
+	}
+}
+
+func createService(t *testing.T, s facade.Storage, service models.Service) models.Service {
+	t.Helper()
+	created, err := s.CreateService(context.Background(), service)
+	must(t, err)
+	if created.ID == 0 {
+		t.Fatalf("service is not created: %+v", created)
+	}
+	if service.CatalogID == nil {
+		service.ID = created.ID
+		equal(t, created, service)
+	}
+	return created
+}
+
+func newRelation(graph_id int, from int, to int) models.Relation {
+	return models.Relation{
+		GraphID:     graph_id,
+		Name:        "calls",
+		FromService: from,
+		ToService:   to,
+	}
+}
+
+func createRelation(t *testing.T, s facade.Storage, relation models.Relation) models.Relation {
+	t.Helper()
+	created, err := s.CreateRelation(context.Background(), relation)
+	must(t, err)
+	relation.ID = created.ID
+	equal(t, created, relation)
+	return created
+}
@@ -0,0 +1,82 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func testGroups(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	project := createProject(t, s, "groups")
+	graph := createGraph(t, s, project.ID, "groups")
+	parent := createGroup(t, s, models.Group{
+		GraphID:     graph.ID,
+		Name:        "platform",
+		Description: "shared services",
+		Kind:        "team",
+		X:           1,
+		Y:           2,
+		Width:       300,
+		Height:      200.5,
+	})
+	child := createGroup(t, s, models.Group{GraphID: graph.ID, ParentID: &parent.ID, Name: "storage"})
+
+	got, err := s.GetGroup(ctx, child.ID)
+	must(t, err)
+	equal(t, got, child)
+
+	parent.Name = "infrastructure"
+	parent.Width = 400
+	must(t, s.UpdateGroup(ctx, parent.ID, parent))
+	groups, err := s.GetGraphGroups(ctx, graph.ID)
+	must(t, err)
+	equal(t, groups, []models.Group{parent, child})
+
+	// Groups and their services stay in one graph
+	other := createGraph(t, s, project.ID, "groups other")
+	_, err = s.CreateGroup(ctx, models.Group{GraphID: other.ID, ParentID: &parent.ID, Name: "foreign"})
+	if err == nil {
+		t.Fatal("group with a parent of another graph is created")
+	}
+	service := newService(other.ID, "foreign")
+	service.GroupID = &parent.ID
+	_, err = s.CreateService(ctx, service)
+	if err == nil {
+		t.Fatal("service in a group of another graph is created")
+	}
+
+	// Deleting a group detaches its children and services
+	service = newService(graph.ID, "db")
+	service.GroupID = &parent.ID
+	service = createService(t, s, service)
+	must(t, s.DeleteGroup(ctx, parent.ID))
+	_, err = s.GetGroup(ctx, parent.ID)
+	notFound(t, err)
+	got, err = s.GetGroup(ctx, child.ID)
+	must(t, err)
+	if got.ParentID != nil {
+		t.Fatalf("child group keeps the deleted parent: %+v", got)
+	}
+	serv, err := s.GetService(ctx, service.ID)
+	must(t, err)
+	if serv.GroupID != nil {
+		t.Fatalf("service keeps the deleted group: %+v", serv)
+	}
+
+	// And deleting a graph deletes its groups
+	must(t, s.DeleteGraph(ctx, graph.ID))
+	_, err = s.GetGroup(ctx, child.ID)
+	notFound(t, err)
+}
+
+func createGroup(t *testing.T, s facade.Storage, group models.Group) models.Group {
+	t.Helper()
+	created, err := s.CreateGroup(context.Background(), group)
+	must(t, err)
+	group.ID = created.ID
+	equal(t, created, group)
+	return created
+}
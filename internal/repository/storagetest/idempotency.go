@@ -0,0 +1,78 @@
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func testIdempotencyKeys(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	// Keys are global, a shared database may hold those of an earlier run
+	prefix := fmt.Sprintf("idempotency-%d-", time.Now().UnixNano())
+	now := time.Now().Truncate(time.Millisecond)
+	newKey := func(name string, fingerprint string) models.IdempotencyKey {
+		return models.IdempotencyKey{
+			Key:         prefix + name,
+			Fingerprint: fingerprint,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(time.Hour),
+		}
+	}
+	claim := func(key models.IdempotencyKey, stale time.Time, want bool) models.IdempotencyKey {
+		t.Helper()
+		held, claimed, err := s.ClaimIdempotencyKey(ctx, key, stale)
+		must(t, err)
+		if claimed != want {
+			t.Fatalf("claim of %s: claimed %t, want %t", key.Key, claimed, want)
+		}
+		return held
+	}
+	stale := now.Add(-time.Minute)
+
+	key := newKey("create", "a")
+	held := claim(key, stale, true)
+	equal(t, []any{held.Key, held.Fingerprint, held.Status}, []any{key.Key, "a", 0})
+
+	// In progress, whatever the request
+	held = claim(newKey("create", "b"), stale, false)
+	equal(t, []any{held.Fingerprint, held.Status}, []any{"a", 0})
+
+	// Only the request holding the key completes it
+	other := key
+	other.Fingerprint = "b"
+	other.Status = 500
+	must(t, s.CompleteIdempotencyKey(ctx, other))
+	key.Status = 201
+	key.ContentType = "application/json"
+	key.Body = []byte(`{"id":1}`)
+	must(t, s.CompleteIdempotencyKey(ctx, key))
+	held = claim(newKey("create", "a"), stale, false)
+	equal(t, []any{held.Status, held.ContentType, string(held.Body)}, []any{201, "application/json", `{"id":1}`})
+
+	// Completed keys are kept until they expire, even past the stale time
+	must(t, s.ReleaseIdempotencyKey(ctx, key))
+	claim(newKey("create", "a"), now.Add(time.Minute), false)
+
+	// Released and stale keys are claimed again
+	released := newKey("released", "a")
+	claim(released, stale, true)
+	must(t, s.ReleaseIdempotencyKey(ctx, released))
+	claim(released, stale, true)
+	claim(newKey("released", "b"), now.Add(time.Minute), true)
+
+	expired := newKey("expired", "a")
+	expired.ExpiresAt = now.Add(-time.Second)
+	claim(expired, stale, true)
+	deleted, err := s.DeleteExpiredIdempotencyKeys(ctx, now)
+	must(t, err)
+	if deleted < 1 {
+		t.Fatalf("deleted %d expired keys, want at least 1", deleted)
+	}
+	claim(newKey("expired", "b"), stale, true)
+	claim(newKey("create", "a"), stale, false)
+}
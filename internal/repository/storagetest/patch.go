@@ -0,0 +1,99 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func testPatch(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	project := createProject(t, s, "patch")
+	got, err := s.GetProject(ctx, project.ID)
+	must(t, err)
+	equal(t, got, project)
+	_, err = s.GetProject(ctx, missingID)
+	notFound(t, err)
+
+	project.Name = "patch renamed"
+	got, err = s.PatchProject(ctx, project.ID, project, []string{"name"})
+	must(t, err)
+	equal(t, got, project)
+
+	// Columns left out of the patch keep their stored values
+	graph := createGraph(t, s, project.ID, "patch")
+	patchedGraph, err := s.PatchGraph(ctx, graph.ID, models.Graph{ProjectID: missingID, Name: "patch renamed"}, []string{"name"})
+	must(t, err)
+	graph.Name = "patch renamed"
+	equal(t, patchedGraph, graph)
+
+	service := newService(graph.ID, "api")
+	service.Description = "entrypoint"
+	service.Kind = "api"
+	service = createService(t, s, service)
+	patched, err := s.PatchService(ctx, service.ID, models.Service{X: 10, Y: 20}, []string{"x", "y"})
+	must(t, err)
+	service.X, service.Y = 10, 20
+	equal(t, patched, service)
+	got2, err := s.GetService(ctx, service.ID)
+	must(t, err)
+	equal(t, got2, service)
+
+	// Without columns the stored row comes back untouched
+	patched, err = s.PatchService(ctx, service.ID, models.Service{}, nil)
+	must(t, err)
+	equal(t, patched, service)
+
+	_, err = s.PatchService(ctx, service.ID, service, []string{"owner"})
+	if err == nil {
+		t.Fatal("unknown column is patched")
+	}
+	_, err = s.PatchService(ctx, missingID, service, []string{"x"})
+	notFound(t, err)
+
+	// Linking a service copies its catalog entry, the entry keeps owning its
+	// fields while linked
+	entry, err := s.CreateCatalogService(ctx, models.CatalogService{
+		ProjectID:  project.ID,
+		Name:       "auth",
+		Kind:       "service",
+		OwnerTeam:  "identity",
+		Tags:       []string{"go"},
+		Attributes: map[string]string{"tier": "0"},
+	})
+	must(t, err)
+	linked := service
+	linked.CatalogID = &entry.ID
+	patched, err = s.PatchService(ctx, service.ID, linked, []string{"catalog_id"})
+	must(t, err)
+	if patched.Name != "auth" || patched.OwnerTeam != "identity" || patched.X != 10 {
+		t.Fatalf("patched service does not inherit the catalog entry: %+v", patched)
+	}
+	linked = patched
+	linked.Name = "web"
+	patched, err = s.PatchService(ctx, service.ID, linked, []string{"name"})
+	must(t, err)
+	if patched.Name != "auth" {
+		t.Fatalf("catalog field of a linked service is patched: %+v", patched)
+	}
+	linked.CatalogID = nil
+	patched, err = s.PatchService(ctx, service.ID, linked, []string{"catalog_id"})
+	must(t, err)
+	if patched.CatalogID != nil || patched.Name != "auth" {
+		t.Fatalf("service is not unlinked: %+v", patched)
+	}
+
+	from := createService(t, s, newService(graph.ID, "patch from"))
+	relation := newRelation(graph.ID, from.ID, service.ID)
+	relation.Protocol = "grpc"
+	relation = createRelation(t, s, relation)
+	patchedRelation, err := s.PatchRelation(ctx, relation.ID, models.Relation{Criticality: "high"}, []string{"criticality"})
+	must(t, err)
+	relation.Criticality = "high"
+	equal(t, patchedRelation, relation)
+	gotRelation, err := s.GetRelation(ctx, relation.ID)
+	must(t, err)
+	equal(t, gotRelation, relation)
+}
@@ -0,0 +1,141 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func testApplyProjectPlan(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	project := createProject(t, s, "plan")
+	kept := createGraph(t, s, project.ID, "kept")
+	dropped := createGraph(t, s, project.ID, "dropped")
+	api := createService(t, s, newService(kept.ID, "api"))
+	old := createService(t, s, newService(kept.ID, "old"))
+	toOld := createRelation(t, s, newRelation(kept.ID, api.ID, old.ID))
+	droppedService := createService(t, s, newService(dropped.ID, "gone"))
+
+	// A plan touching another project by ID is ignored
+	foreign := createGraph(t, s, createProject(t, s, "plan foreign").ID, "foreign")
+
+	foreignService := createService(t, s, newService(foreign.ID, "foreign"))
+	foreignRelation := createRelation(t, s, newRelation(foreign.ID, foreignService.ID, foreignService.ID))
+
+	api.X = 5
+	applied, err := s.ApplyProjectPlan(ctx, project.ID, planned(models.ProjectPlan{
+		DeleteGraphs:   []models.Graph{dropped, foreign},
+		DeleteServices: []models.Service{old},
+		UpdateServices: []models.Service{api, {ID: foreignService.ID, Name: "hijacked"}},
+		CreateGraphs:   []models.Graph{{Name: "created"}},
+		CreateServices: []models.PlannedService{
+			{GraphName: "created", Service: newService(0, "db")},
+			{GraphName: "kept", Service: newService(0, "cache")},
+		},
+		CreateRelations: []models.PlannedRelation{
+			{GraphName: "kept", From: "api", To: "cache", Relation: models.Relation{Name: "reads"}},
+		},
+	}))
+	must(t, err)
+
+	created := applied.CreateGraphs[0]
+	if created.ID == 0 || created.ProjectID != project.ID {
+		t.Fatalf("planned graph is not created: %+v", created)
+	}
+	graphs, err := s.GetProjectGraphs(ctx, project.ID)
+	must(t, err)
+	equal(t, graphs, []models.Graph{kept, created})
+	_, err = s.GetService(ctx, droppedService.ID)
+	notFound(t, err)
+	_, err = s.GetGraph(ctx, foreign.ID)
+	must(t, err)
+	gotService, err := s.GetService(ctx, foreignService.ID)
+	must(t, err)
+	equal(t, gotService, foreignService)
+
+	db := applied.CreateServices[0].Service
+	cache := applied.CreateServices[1].Service
+	if db.ID == 0 || db.GraphID != created.ID || cache.ID == 0 || cache.GraphID != kept.ID {
+		t.Fatalf("planned services are not created: %+v %+v", db, cache)
+	}
+	services, err := s.GetGraphServices(ctx, kept.ID, models.ServiceFilter{})
+	must(t, err)
+	equal(t, services, []models.Service{api, cache})
+
+	_, err = s.GetRelation(ctx, toOld.ID)
+	notFound(t, err)
+	reads := applied.CreateRelations[0].Relation
+	if reads.ID == 0 || reads.FromService != api.ID || reads.ToService != cache.ID {
+		t.Fatalf("planned relation is not resolved: %+v", reads)
+	}
+
+	reads.Criticality = "high"
+	_, err = s.ApplyProjectPlan(ctx, project.ID, planned(models.ProjectPlan{
+		UpdateRelations: []models.PlannedRelation{
+			{GraphName: "kept", From: "cache", To: "api", Relation: reads},
+			{GraphName: "kept", From: "api", To: "cache", Relation: models.Relation{ID: foreignRelation.ID, Name: "hijacked"}},
+		},
+	}))
+	must(t, err)
+	got, err := s.GetRelation(ctx, reads.ID)
+	must(t, err)
+	reads.FromService, reads.ToService = cache.ID, api.ID
+	equal(t, got, reads)
+	got, err = s.GetRelation(ctx, foreignRelation.ID)
+	must(t, err)
+	equal(t, got, foreignRelation)
+
+	// A plan is applied whole or not at all
+	_, err = s.ApplyProjectPlan(ctx, project.ID, planned(models.ProjectPlan{
+		DeleteServices: []models.Service{api},
+		CreateGraphs:   []models.Graph{{Name: "partial"}},
+		CreateRelations: []models.PlannedRelation{
+			{GraphName: "kept", From: "cache", To: "missing"},
+		},
+	}))
+	if err == nil {
+		t.Fatal("plan with an unknown service is applied")
+	}
+	graphs, err = s.GetProjectGraphs(ctx, project.ID)
+	must(t, err)
+	equal(t, graphs, []models.Graph{kept, created})
+	_, err = s.GetService(ctx, api.ID)
+	must(t, err)
+
+	_, err = s.ApplyProjectPlan(ctx, missingID, planned(models.ProjectPlan{}))
+	notFound(t, err)
+
+	// The plan is made in the transaction of the apply
+	_, err = s.ApplyProjectPlan(ctx, project.ID, func(ctx context.Context) (models.ProjectPlan, error) {
+		graphs, err := s.GetProjectGraphs(ctx, project.ID)
+		if err != nil {
+			return models.ProjectPlan{}, err
+		}
+		return models.ProjectPlan{DeleteGraphs: graphs}, nil
+	})
+	must(t, err)
+	graphs, err = s.GetProjectGraphs(ctx, project.ID)
+	must(t, err)
+	equal(t, graphs, []models.Graph{})
+
+	errPlan := errors.New("plan failed")
+	_, err = s.ApplyProjectPlan(ctx, project.ID, func(ctx context.Context) (models.ProjectPlan, error) {
+		return models.ProjectPlan{CreateGraphs: []models.Graph{{Name: "unplanned"}}}, errPlan
+	})
+	if !errors.Is(err, errPlan) {
+		t.Fatalf("got error %v, want %v", err, errPlan)
+	}
+	graphs, err = s.GetProjectGraphs(ctx, project.ID)
+	must(t, err)
+	equal(t, graphs, []models.Graph{})
+}
+
+// planned returns a plan that does not depend on the state
+func planned(plan models.ProjectPlan) func(ctx context.Context) (models.ProjectPlan, error) {
+	return func(context.Context) (models.ProjectPlan, error) {
+		return plan, nil
+	}
+}
@@ -0,0 +1,357 @@
+// Package storagetest is the conformance suite every facade.Storage backend
+// has to pass. A backend runs it from its own tests:
+//
+//	func TestStorage(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) facade.Storage {
+//			return memory.New()
+//		})
+//	}
+//
+// The suite only looks at the entities it creates, so it can run against a
+// shared database as well as a fresh one per test.
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+// Run runs every check of the suite as a subtest, newStorage is called once
+// per subtest
+func Run(t *testing.T, newStorage func(t *testing.T) facade.Storage) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s facade.Storage)
+	}{
+		{"Projects", testProjects},
+		{"Graphs", testGraphs},
+		{"Services", testServices},
+		{"ServiceFilter", testServiceFilter},
+		{"Relations", testRelations},
+		{"Cascades", testCascades},
+		{"UpdateMissing", testUpdateMissing},
+		{"Catalog", testCatalog},
+		{"Groups", testGroups},
+		{"Bulk", testBulk},
+		{"Batch", testBatch},
+		{"ApplyProjectPlan", testApplyProjectPlan},
+		{"Webhooks", testWebhooks},
+		{"WebhookOutbox", testWebhookOutbox},
+		{"IdempotencyKeys", testIdempotencyKeys},
+		{"Patch", testPatch},
+		{"Transactions", testTransactions},
+		{"CountEntities", testCountEntities},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t, newStorage(t))
+		})
+	}
+}
+
+func testProjects(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	project := createProject(t, s, "projects")
+	other := createProject(t, s, "projects other")
+	if project.ID == other.ID {
+		t.Fatalf("projects share ID %d", project.ID)
+	}
+
+	err := s.UpdateProject(ctx, project.ID, models.Project{Name: "projects renamed"})
+	must(t, err)
+	projects, err := s.GetProjects(ctx)
+	must(t, err)
+	if !containsProject(projects, models.Project{ID: project.ID, Name: "projects renamed"}) {
+		t.Fatalf("renamed project is missing from %v", projects)
+	}
+
+	must(t, s.DeleteProject(ctx, project.ID))
+	projects, err = s.GetProjects(ctx)
+	must(t, err)
+	if containsProject(projects, models.Project{ID: project.ID, Name: "projects renamed"}) {
+		t.Fatalf("deleted project is still in %v", projects)
+	}
+	must(t, s.DeleteProject(ctx, project.ID))
+}
+
+func testGraphs(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	project := createProject(t, s, "graphs")
+	first := createGraph(t, s, project.ID, "first")
+	second := createGraph(t, s, project.ID, "second")
+
+	got, err := s.GetGraph(ctx, first.ID)
+	must(t, err)
+	equal(t, got, first)
+
+	first.Name = "renamed"
+	must(t, s.UpdateGraph(ctx, first.ID, first))
+	got, err = s.GetGraph(ctx, first.ID)
+	must(t, err)
+	equal(t, got, first)
+
+	graphs, err := s.GetProjectGraphs(ctx, project.ID)
+	must(t, err)
+	equal(t, graphs, []models.Graph{first, second})
+
+	must(t, s.DeleteGraph(ctx, first.ID))
+	_, err = s.GetGraph(ctx, first.ID)
+	notFound(t, err)
+
+	_, err = s.CreateGraph(ctx, models.Graph{ProjectID: missingID, Name: "orphan"})
+	if err == nil {
+		t.Fatal("graph of a missing project is created")
+	}
+}
+
+func testServices(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	graph := createGraph(t, s, createProject(t, s, "services").ID, "services")
+	service := createService(t, s, models.Service{
+		GraphID:     graph.ID,
+		Name:        "api",
+		Description: "public API",
+		X:           1.5,
+		Y:           -2,
+		Kind:        "service",
+		OwnerTeam:   "core",
+		Tags:        []string{"go", "http"},
+		Attributes:  map[string]string{"tier": "1"},
+		DocsURL:     "https://docs.example.com",
+		RunbookURL:  "https://runbook.example.com",
+		RepoURL:     "https://git.example.com",
+	})
+
+	got, err := s.GetService(ctx, service.ID)
+	must(t, err)
+	equal(t, got, service)
+
+	service.Name = "gateway"
+	service.Tags = []string{"go"}
+	service.Attributes = map[string]string{"tier": "0"}
+	must(t, s.UpdateService(ctx, service.ID, service))
+	got, err = s.GetService(ctx, service.ID)
+	must(t, err)
+	equal(t, got, service)
+
+	must(t, s.DeleteService(ctx, service.ID))
+	_, err = s.GetService(ctx, service.ID)
+	notFound(t, err)
+	must(t, s.DeleteService(ctx, service.ID))
+
+	_, err = s.CreateService(ctx, newService(missingID, "orphan"))
+	if err == nil {
+		t.Fatal("service of a missing graph is created")
+	}
+}
+
+func testServiceFilter(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	graph := createGraph(t, s, createProject(t, s, "filter").ID, "filter")
+	api := newService(graph.ID, "api")
+	api.Kind = "service"
+	api.OwnerTeam = "core"
+	api.Tags = []string{"go", "http"}
+	api.Attributes = map[string]string{"tier": "1", "region": "eu"}
+	api = createService(t, s, api)
+	db := newService(graph.ID, "db")
+	db.Kind = "database"
+	db.OwnerTeam = "core"
+	db.Tags = []string{"sql"}
+	db.Attributes = map[string]string{"tier": "1"}
+	db = createService(t, s, db)
+	worker := newService(graph.ID, "worker")
+	worker.Kind = "service"
+	worker.OwnerTeam = "jobs"
+	worker.Tags = []string{"go"}
+	worker = createService(t, s, worker)
+
+	filters := []struct {
+		filter models.ServiceFilter
+		want   []models.Service
+	}{
+		{models.ServiceFilter{}, []models.Service{api, db, worker}},
+		{models.ServiceFilter{Kind: "service"}, []models.Service{api, worker}},
+		{models.ServiceFilter{OwnerTeam: "core"}, []models.Service{api, db}},
+		{models.ServiceFilter{Tags: []string{"go"}}, []models.Service{api, worker}},
+		{models.ServiceFilter{Tags: []string{"go", "http"}}, []models.Service{api}},
+		{models.ServiceFilter{Attributes: map[string]string{"tier": "1"}}, []models.Service{api, db}},
+		{models.ServiceFilter{Attributes: map[string]string{"tier": "1", "region": "eu"}}, []models.Service{api}},
+		{models.ServiceFilter{Kind: "service", OwnerTeam: "jobs"}, []models.Service{worker}},
+		{models.ServiceFilter{Tags: []string{"rust"}}, nil},
+	}
+	for _, test := range filters {
+		services, err := s.GetGraphServices(ctx, graph.ID, test.filter)
+		must(t, err)
+		if len(services) != 0 || len(test.want) != 0 {
+			if !reflect.DeepEqual(services, test.want) {
+				t.Errorf("filter %+v:\n got %+v\nwant %+v", test.filter, services, test.want)
+			}
+		}
+	}
+}
+
+func testRelations(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	graph := createGraph(t, s, createProject(t, s, "relations").ID, "relations")
+	api := createService(t, s, newService(graph.ID, "api"))
+	db := createService(t, s, newService(graph.ID, "db"))
+
+	relation := createRelation(t, s, models.Relation{
+		GraphID:           graph.ID,
+		Name:              "reads",
+		Description:       "reads users",
+		FromService:       api.ID,
+		ToService:         db.ID,
+		Protocol:          "sql",
+		Async:             true,
+		Bidirectional:     true,
+		Criticality:       "high",
+		ExpectedRPS:       120.5,
+		ExpectedLatencyMS: 3.25,
+	})
+	got, err := s.GetRelation(ctx, relation.ID)
+	must(t, err)
+	equal(t, got, relation)
+
+	relation.Name = "writes"
+	relation.FromService, relation.ToService = db.ID, api.ID
+	relation.Async = false
+	must(t, s.UpdateRelation(ctx, relation.ID, relation))
+	got, err = s.GetRelation(ctx, relation.ID)
+	must(t, err)
+	equal(t, got, relation)
+
+	relations, err := s.GetGraphRelations(ctx, graph.ID)
+	must(t, err)
+	equal(t, relations, []models.Relation{relation})
+
+	must(t, s.DeleteRelation(ctx, relation.ID))
+	_, err = s.GetRelation(ctx, relation.ID)
+	notFound(t, err)
+
+	_, err = s.CreateRelation(ctx, newRelation(graph.ID, api.ID, missingID))
+	if err == nil {
+		t.Fatal("relation to a missing service is created")
+	}
+}
+
+func testCascades(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	project := createProject(t, s, "cascades")
+	graph := createGraph(t, s, project.ID, "cascades")
+	api := createService(t, s, newService(graph.ID, "api"))
+	db := createService(t, s, newService(graph.ID, "db"))
+	cache := createService(t, s, newService(graph.ID, "cache"))
+	toDB := createRelation(t, s, newRelation(graph.ID, api.ID, db.ID))
+	toCache := createRelation(t, s, newRelation(graph.ID, api.ID, cache.ID))
+
+	// Relations of a deleted service go with it
+	must(t, s.DeleteService(ctx, db.ID))
+	_, err := s.GetRelation(ctx, toDB.ID)
+	notFound(t, err)
+	got, err := s.GetRelation(ctx, toCache.ID)
+	must(t, err)
+	equal(t, got, toCache)
+
+	// So does everything of a deleted project
+	must(t, s.DeleteProject(ctx, project.ID))
+	_, err = s.GetGraph(ctx, graph.ID)
+	notFound(t, err)
+	_, err = s.GetService(ctx, api.ID)
+	notFound(t, err)
+	_, err = s.GetRelation(ctx, toCache.ID)
+	notFound(t, err)
+}
+
+// testUpdateMissing checks that updates report a missing row instead of
+// succeeding without a change
+func testUpdateMissing(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	project := createProject(t, s, "update missing")
+	graph := createGraph(t, s, project.ID, "update missing")
+	api := createService(t, s, newService(graph.ID, "api"))
+
+	notFound(t, s.UpdateProject(ctx, missingID, models.Project{Name: "missing"}))
+	notFound(t, s.UpdateGraph(ctx, missingID, models.Graph{ProjectID: project.ID, Name: "missing"}))
+	notFound(t, s.UpdateService(ctx, missingID, newService(graph.ID, "missing")))
+	notFound(t, s.UpdateRelation(ctx, missingID, newRelation(graph.ID, api.ID, api.ID)))
+	notFound(t, s.UpdateGroup(ctx, missingID, models.Group{GraphID: graph.ID, Name: "missing"}))
+	notFound(t, s.UpdateCatalogService(ctx, missingID, models.CatalogService{ProjectID: project.ID, Name: "missing"}))
+	notFound(t, s.UpdateWebhook(ctx, missingID, models.Webhook{ProjectID: project.ID, URL: "https://hooks.example.com"}))
+
+	// Nothing is created under the missing ID either
+	_, err := s.GetProject(ctx, missingID)
+	notFound(t, err)
+	_, err = s.GetService(ctx, missingID)
+	notFound(t, err)
+}
+
+func testCatalog(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	project := createProject(t, s, "catalog")
+	graph := createGraph(t, s, project.ID, "catalog")
+	entry, err := s.CreateCatalogService(ctx, models.CatalogService{
+		ProjectID:   project.ID,
+		Name:        "auth",
+		Description: "authentication",
+		Kind:        "service",
+		OwnerTeam:   "identity",
+		Tags:        []string{"go"},
+		Attributes:  map[string]string{"tier": "0"},
+		DocsURL:     "https://docs.example.com/auth",
+	})
+	must(t, err)
+	got, err := s.GetCatalogService(ctx, entry.ID)
+	must(t, err)
+	equal(t, got, entry)
+
+	// A graph service referencing the entry takes its fields, not its own
+	service := newService(graph.ID, "ignored")
+	service.CatalogID = &entry.ID
+	service.X = 10
+	service = createService(t, s, service)
+	if service.Name != "auth" || service.OwnerTeam != "identity" || service.DocsURL != entry.DocsURL {
+		t.Fatalf("service does not inherit the catalog entry: %+v", service)
+	}
+
+	entry.Description = "authentication and sessions"
+	entry.Tags = []string{"go", "grpc"}
+	must(t, s.UpdateCatalogService(ctx, entry.ID, entry))
+	got2, err := s.GetService(ctx, service.ID)
+	must(t, err)
+	if got2.Description != entry.Description || !reflect.DeepEqual(got2.Tags, entry.Tags) || got2.X != 10 {
+		t.Fatalf("catalog update is not propagated: %+v", got2)
+	}
+
+	graphs, err := s.GetCatalogServiceGraphs(ctx, entry.ID)
+	must(t, err)
+	equal(t, graphs, []models.Graph{graph})
+	catalog, err := s.GetProjectCatalog(ctx, project.ID)
+	must(t, err)
+	equal(t, catalog, []models.CatalogService{entry})
+
+	// Entries are shared within a project only
+	foreign := createGraph(t, s, createProject(t, s, "catalog foreign").ID, "foreign")
+	service = newService(foreign.ID, "auth")
+	service.CatalogID = &entry.ID
+	_, err = s.CreateService(ctx, service)
+	if !errors.Is(err, models.ErrForeignCatalogService) {
+		t.Fatalf("service of another project is linked: %v", err)
+	}
+
+	// Services stay when their entry is deleted and keep the last fields
+	must(t, s.DeleteCatalogService(ctx, entry.ID))
+	_, err = s.GetCatalogService(ctx, entry.ID)
+	notFound(t, err)
+	got2, err = s.GetService(ctx, got2.ID)
+	must(t, err)
+	if got2.CatalogID != nil || got2.Name != "auth" {
+		t.Fatalf("service is not unlinked from the deleted entry: %+v", got2)
+	}
+}
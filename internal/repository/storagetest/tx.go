@@ -0,0 +1,91 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func testTransactions(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	project := createProject(t, s, "transactions")
+
+	// A failing transaction leaves nothing behind, including the writes of
+	// methods running their own transaction
+	errRollback := errors.New("rollback")
+	var graph models.Graph
+	err := s.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		graph, err = s.CreateGraph(ctx, models.Graph{ProjectID: project.ID, Name: "transactions"})
+		if err != nil {
+			return err
+		}
+		_, err = s.CreateServices(ctx, graph.ID, []models.Service{newService(graph.ID, "api")})
+		if err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("want %v, got %v", errRollback, err)
+	}
+	_, err = s.GetGraph(ctx, graph.ID)
+	notFound(t, err)
+
+	// Reads inside the transaction see its writes
+	err = s.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		graph, err = s.CreateGraph(ctx, models.Graph{ProjectID: project.ID, Name: "transactions"})
+		if err != nil {
+			return err
+		}
+		got, err := s.GetGraph(ctx, graph.ID)
+		if err != nil {
+			return err
+		}
+		equal(t, got, graph)
+		return s.CreateWebhookEvents(ctx, nil)
+	})
+	must(t, err)
+	got, err := s.GetGraph(ctx, graph.ID)
+	must(t, err)
+	equal(t, got, graph)
+
+	// Updates and cascading deletes are undone as well, the rows come back
+	// the way they were
+	api := createService(t, s, newService(graph.ID, "api"))
+	db := createService(t, s, newService(graph.ID, "db"))
+	relation := createRelation(t, s, newRelation(graph.ID, api.ID, db.ID))
+	err = s.InTx(ctx, func(ctx context.Context) error {
+		renamed := graph
+		renamed.Name = "renamed"
+		err := s.UpdateGraph(ctx, graph.ID, renamed)
+		if err != nil {
+			return err
+		}
+		err = s.DeleteService(ctx, db.ID)
+		if err != nil {
+			return err
+		}
+		_, err = s.CreateServices(ctx, graph.ID, []models.Service{newService(graph.ID, "cache")})
+		if err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("want %v, got %v", errRollback, err)
+	}
+	got, err = s.GetGraph(ctx, graph.ID)
+	must(t, err)
+	equal(t, got, graph)
+	services, err := s.GetGraphServices(ctx, graph.ID, models.ServiceFilter{})
+	must(t, err)
+	equal(t, services, []models.Service{api, db})
+	gotRelation, err := s.GetRelation(ctx, relation.ID)
+	must(t, err)
+	equal(t, gotRelation, relation)
+}
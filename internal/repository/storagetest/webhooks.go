@@ -0,0 +1,143 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/models"
+)
+
+func testWebhooks(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	project := createProject(t, s, "webhooks")
+	webhook, err := s.CreateWebhook(ctx, models.Webhook{
+		ProjectID: project.ID,
+		URL:       "https://hooks.example.com",
+		Secret:    "secret",
+		Events:    []string{"service.created", "service.deleted"},
+	})
+	must(t, err)
+	if webhook.ID == 0 {
+		t.Fatalf("webhook is not created: %+v", webhook)
+	}
+	got, err := s.GetWebhook(ctx, webhook.ID)
+	must(t, err)
+	equal(t, got, webhook)
+
+	webhook.URL = "https://hooks.example.com/v2"
+	webhook.Events = []string{"graph.created"}
+	must(t, s.UpdateWebhook(ctx, webhook.ID, webhook))
+	webhooks, err := s.GetProjectWebhooks(ctx, project.ID)
+	must(t, err)
+	equal(t, webhooks, []models.Webhook{webhook})
+
+	// Secrets are never handed out, an update without one keeps it
+	withoutSecret := webhook
+	withoutSecret.Secret = ""
+	must(t, s.UpdateWebhook(ctx, webhook.ID, withoutSecret))
+	got, err = s.GetWebhook(ctx, webhook.ID)
+	must(t, err)
+	equal(t, got, webhook)
+
+	must(t, s.DeleteWebhook(ctx, webhook.ID))
+	_, err = s.GetWebhook(ctx, webhook.ID)
+	notFound(t, err)
+
+	// Webhooks go with their project
+	webhook, err = s.CreateWebhook(ctx, models.Webhook{ProjectID: project.ID, URL: "https://hooks.example.com", Events: []string{}})
+	must(t, err)
+	must(t, s.DeleteProject(ctx, project.ID))
+	_, err = s.GetWebhook(ctx, webhook.ID)
+	notFound(t, err)
+}
+
+func testWebhookOutbox(t *testing.T, s facade.Storage) {
+	ctx := context.Background()
+	webhook, err := s.CreateWebhook(ctx, models.Webhook{
+		ProjectID: createProject(t, s, "outbox").ID,
+		URL:       "https://hooks.example.com",
+		Events:    []string{"graph.created"},
+	})
+	must(t, err)
+
+	must(t, s.CreateWebhookEvents(ctx, []models.WebhookEvent{
+		{WebhookID: webhook.ID, Event: "graph.created", Payload: []byte(`{"id":1}`)},
+		{WebhookID: webhook.ID, Event: "graph.created", Payload: []byte(`{"id":2}`)},
+	}))
+	err = s.CreateWebhookEvents(ctx, []models.WebhookEvent{
+		{WebhookID: webhook.ID, Event: "graph.created", Payload: []byte(`{"id":3}`)},
+		{WebhookID: missingID, Event: "graph.created", Payload: []byte(`{"id":4}`)},
+	})
+	if err == nil {
+		t.Fatal("event of a missing webhook is created")
+	}
+
+	// Only the events of the first batch are there and they are due now
+	events := claim(t, s, webhook.ID, time.Minute)
+	if len(events) != 2 {
+		t.Fatalf("want 2 claimed events, got %+v", events)
+	}
+	for _, event := range events {
+		if event.Status != models.WebhookEventPending || event.Attempts != 0 || event.CreatedAt.IsZero() {
+			t.Fatalf("event is not pending: %+v", event)
+		}
+		if time.Until(event.NextAttemptAt) < 30*time.Second {
+			t.Fatalf("claimed event is not leased: %+v", event)
+		}
+	}
+	// Leased events are not claimed twice
+	if again := claim(t, s, webhook.ID, time.Minute); len(again) != 0 {
+		t.Fatalf("leased events are claimed again: %+v", again)
+	}
+
+	delivered := events[0]
+	delivered.Status = models.WebhookEventDelivered
+	delivered.Attempts = 1
+	must(t, s.UpdateWebhookEvent(ctx, delivered))
+	retried := events[1]
+	retried.Attempts = 1
+	retried.NextAttemptAt = time.Now().Add(-time.Second)
+	must(t, s.UpdateWebhookEvent(ctx, retried))
+	again := claim(t, s, webhook.ID, time.Minute)
+	if len(again) != 1 || again[0].ID != retried.ID || again[0].Attempts != 1 {
+		t.Fatalf("want the retried event claimed, got %+v", again)
+	}
+
+	for _, delivery := range []models.WebhookDelivery{
+		{WebhookID: webhook.ID, EventID: delivered.ID, Event: delivered.Event, Attempt: 1, StatusCode: 200, DurationMS: 12},
+		{WebhookID: webhook.ID, EventID: retried.ID, Event: retried.Event, Attempt: 1, StatusCode: 500, Error: "internal", DurationMS: 30},
+	} {
+		must(t, s.CreateWebhookDelivery(ctx, delivery))
+	}
+	err = s.CreateWebhookDelivery(ctx, models.WebhookDelivery{WebhookID: webhook.ID, EventID: missingID})
+	if err == nil {
+		t.Fatal("delivery of a missing event is created")
+	}
+
+	// The latest delivery comes first
+	deliveries, err := s.GetWebhookDeliveries(ctx, webhook.ID)
+	must(t, err)
+	if len(deliveries) != 2 || deliveries[0].EventID != retried.ID || deliveries[1].EventID != delivered.ID {
+		t.Fatalf("deliveries are not listed latest first: %+v", deliveries)
+	}
+	if deliveries[0].Error != "internal" || deliveries[0].StatusCode != 500 || deliveries[0].CreatedAt.IsZero() {
+		t.Fatalf("delivery is not stored: %+v", deliveries[0])
+	}
+}
+
+// claim claims every due event and keeps the ones of the webhook, other
+// events may be there when the storage is shared
+func claim(t *testing.T, s facade.Storage, webhook_id int, lease time.Duration) []models.WebhookEvent {
+	t.Helper()
+	claimed, err := s.ClaimWebhookEvents(context.Background(), 1000, lease)
+	must(t, err)
+	var events []models.WebhookEvent
+	for _, event := range claimed {
+		if event.WebhookID == webhook_id {
+			events = append(events, event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/olegdayo/omniconv"
+)
+
+func (s *Server) getProjectCatalogHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	project_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	services, err := s.providerCatalog.GetProjectCatalog(r.Context(), project_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(omniconv.ConvertSlice(services, ProviderCatalogService2ServerCatalogService))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func (s *Server) createCatalogServiceHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	project_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	var service CatalogService
+	err = json.NewDecoder(r.Body).Decode(&service)
+	if err != nil {
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateCatalogService(service); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	service.ProjectID = project_id
+
+	newservice, err := s.providerCatalog.CreateCatalogService(r.Context(), ServerCatalogService2ProviderCatalogService(service))
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(ProviderCatalogService2ServerCatalogService(newservice))
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Write(body)
+}
+
+func (s *Server) getCatalogServiceHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	catalog_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	service, err := s.providerCatalog.GetCatalogService(r.Context(), catalog_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(ProviderCatalogService2ServerCatalogService(service))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func (s *Server) updateCatalogServiceHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	catalog_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	var service CatalogService
+	err = json.NewDecoder(r.Body).Decode(&service)
+	if err != nil {
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateCatalogService(service); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = s.providerCatalog.UpdateCatalogService(r.Context(), catalog_id, ServerCatalogService2ProviderCatalogService(service))
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteCatalogServiceHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	catalog_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	err = s.providerCatalog.DeleteCatalogService(r.Context(), catalog_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getCatalogServiceGraphsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	catalog_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	graphs, err := s.providerCatalog.GetCatalogServiceGraphs(r.Context(), catalog_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(omniconv.ConvertSlice(graphs, ProviderGraph2ServerGraph))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
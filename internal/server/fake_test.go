@@ -0,0 +1,282 @@
+package server_test
+
+import (
+	"context"
+
+	"github.com/hse-telescope/core/internal/providers/catalog"
+	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/providers/group"
+	"github.com/hse-telescope/core/internal/providers/idempotency"
+	"github.com/hse-telescope/core/internal/providers/manifest"
+	"github.com/hse-telescope/core/internal/providers/project"
+	"github.com/hse-telescope/core/internal/providers/relation"
+	"github.com/hse-telescope/core/internal/providers/service"
+	"github.com/hse-telescope/core/internal/providers/webhook"
+)
+
+// Call is a provider method called by a handler, the context is left out
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Fake implements every provider of the server. A method returns the value
+// and the error set for its name, zero values otherwise, and is recorded in
+// Calls.
+type Fake struct {
+	Results map[string]any
+	Errors  map[string]error
+	Calls   []Call
+}
+
+func newFake() *Fake {
+	return &Fake{
+		Results: make(map[string]any),
+		Errors:  make(map[string]error),
+	}
+}
+
+func result[T any](f *Fake, method string, args ...any) (T, error) {
+	f.Calls = append(f.Calls, Call{Method: method, Args: args})
+	res, _ := f.Results[method].(T)
+	return res, f.Errors[method]
+}
+
+// patchResult applies patch to the result set for the method, which stands
+// for the stored entity, and returns the patched one. The call is recorded
+// with the patched entity and its fields when the patch applies.
+func patchResult[T any](f *Fake, method string, id int, patch func(T) (T, []string, error)) (T, error) {
+	var zero T
+	stored, err := result[T](f, method, id)
+	if err != nil {
+		return zero, err
+	}
+	patched, fields, err := patch(stored)
+	if err != nil {
+		return zero, err
+	}
+	f.Calls[len(f.Calls)-1].Args = []any{id, patched, fields}
+	return patched, nil
+}
+
+func (f *Fake) call(method string, args ...any) error {
+	_, err := result[struct{}](f, method, args...)
+	return err
+}
+
+func (f *Fake) GetProjects(ctx context.Context) ([]project.Project, error) {
+	return result[[]project.Project](f, "GetProjects")
+}
+
+func (f *Fake) GetProject(ctx context.Context, project_id int) (project.Project, error) {
+	return result[project.Project](f, "GetProject", project_id)
+}
+
+func (f *Fake) CreateProject(ctx context.Context, proj project.Project) (project.Project, error) {
+	return result[project.Project](f, "CreateProject", proj)
+}
+
+func (f *Fake) UpdateProject(ctx context.Context, project_id int, proj project.Project) error {
+	return f.call("UpdateProject", project_id, proj)
+}
+
+func (f *Fake) PatchProject(ctx context.Context, project_id int, patch func(project.Project) (project.Project, []string, error)) (project.Project, error) {
+	return patchResult(f, "PatchProject", project_id, patch)
+}
+
+func (f *Fake) DeleteProject(ctx context.Context, project_id int) error {
+	return f.call("DeleteProject", project_id)
+}
+
+func (f *Fake) CreateGraph(ctx context.Context, gr graph.Graph) (graph.Graph, error) {
+	return result[graph.Graph](f, "CreateGraph", gr)
+}
+
+func (f *Fake) DeleteGraph(ctx context.Context, graph_id int) error {
+	return f.call("DeleteGraph", graph_id)
+}
+
+func (f *Fake) UpdateGraph(ctx context.Context, graph_id int, gr graph.Graph) error {
+	return f.call("UpdateGraph", graph_id, gr)
+}
+
+func (f *Fake) PatchGraph(ctx context.Context, graph_id int, patch func(graph.Graph) (graph.Graph, []string, error)) (graph.Graph, error) {
+	return patchResult(f, "PatchGraph", graph_id, patch)
+}
+
+func (f *Fake) GetGraph(ctx context.Context, graph_id int) (graph.Graph, error) {
+	return result[graph.Graph](f, "GetGraph", graph_id)
+}
+
+func (f *Fake) GetProjectGraphs(ctx context.Context, project_id int) ([]graph.Graph, error) {
+	return result[[]graph.Graph](f, "GetProjectGraphs", project_id)
+}
+
+func (f *Fake) GetProjectsGraphs(ctx context.Context, project_ids []int) ([]graph.Graph, error) {
+	return result[[]graph.Graph](f, "GetProjectsGraphs", project_ids)
+}
+
+func (f *Fake) GetService(ctx context.Context, service_id int) (service.Service, error) {
+	return result[service.Service](f, "GetService", service_id)
+}
+
+func (f *Fake) GetGraphServices(ctx context.Context, graph_id int, filter service.Filter) ([]service.Service, error) {
+	return result[[]service.Service](f, "GetGraphServices", graph_id, filter)
+}
+
+func (f *Fake) GetGraphsServices(ctx context.Context, graph_ids []int) ([]service.Service, error) {
+	return result[[]service.Service](f, "GetGraphsServices", graph_ids)
+}
+
+func (f *Fake) CreateService(ctx context.Context, serv service.Service) (service.Service, error) {
+	return result[service.Service](f, "CreateService", serv)
+}
+
+func (f *Fake) CreateServices(ctx context.Context, graph_id int, services []service.Service) ([]int, error) {
+	return result[[]int](f, "CreateServices", graph_id, services)
+}
+
+func (f *Fake) UpdateGraphServices(ctx context.Context, graph_id int, services []service.Service) error {
+	return f.call("UpdateGraphServices", graph_id, services)
+}
+
+func (f *Fake) UpdateService(ctx context.Context, service_id int, serv service.Service) error {
+	return f.call("UpdateService", service_id, serv)
+}
+
+func (f *Fake) PatchService(ctx context.Context, service_id int, patch func(service.Service) (service.Service, []string, error)) (service.Service, error) {
+	return patchResult(f, "PatchService", service_id, patch)
+}
+
+func (f *Fake) DeleteService(ctx context.Context, service_id int) error {
+	return f.call("DeleteService", service_id)
+}
+
+func (f *Fake) GetRelation(ctx context.Context, relation_id int) (relation.Relation, error) {
+	return result[relation.Relation](f, "GetRelation", relation_id)
+}
+
+func (f *Fake) GetGraphRelations(ctx context.Context, graph_id int) ([]relation.Relation, error) {
+	return result[[]relation.Relation](f, "GetGraphRelations", graph_id)
+}
+
+func (f *Fake) GetServicesRelations(ctx context.Context, service_ids []int) ([]relation.Relation, error) {
+	return result[[]relation.Relation](f, "GetServicesRelations", service_ids)
+}
+
+func (f *Fake) CreateRelation(ctx context.Context, rel relation.Relation) (relation.Relation, error) {
+	return result[relation.Relation](f, "CreateRelation", rel)
+}
+
+func (f *Fake) CreateRelations(ctx context.Context, graph_id int, relations []relation.Relation) error {
+	return f.call("CreateRelations", graph_id, relations)
+}
+
+func (f *Fake) UpdateGraphRelations(ctx context.Context, graph_id int, relations []relation.Relation) error {
+	return f.call("UpdateGraphRelations", graph_id, relations)
+}
+
+func (f *Fake) UpdateRelation(ctx context.Context, relation_id int, rel relation.Relation) error {
+	return f.call("UpdateRelation", relation_id, rel)
+}
+
+func (f *Fake) PatchRelation(ctx context.Context, relation_id int, patch func(relation.Relation) (relation.Relation, []string, error)) (relation.Relation, error) {
+	return patchResult(f, "PatchRelation", relation_id, patch)
+}
+
+func (f *Fake) DeleteRelation(ctx context.Context, relation_id int) error {
+	return f.call("DeleteRelation", relation_id)
+}
+
+func (f *Fake) GetCatalogService(ctx context.Context, catalog_id int) (catalog.Service, error) {
+	return result[catalog.Service](f, "GetCatalogService", catalog_id)
+}
+
+func (f *Fake) GetProjectCatalog(ctx context.Context, project_id int) ([]catalog.Service, error) {
+	return result[[]catalog.Service](f, "GetProjectCatalog", project_id)
+}
+
+func (f *Fake) CreateCatalogService(ctx context.Context, serv catalog.Service) (catalog.Service, error) {
+	return result[catalog.Service](f, "CreateCatalogService", serv)
+}
+
+func (f *Fake) UpdateCatalogService(ctx context.Context, catalog_id int, serv catalog.Service) error {
+	return f.call("UpdateCatalogService", catalog_id, serv)
+}
+
+func (f *Fake) DeleteCatalogService(ctx context.Context, catalog_id int) error {
+	return f.call("DeleteCatalogService", catalog_id)
+}
+
+func (f *Fake) GetCatalogServiceGraphs(ctx context.Context, catalog_id int) ([]graph.Graph, error) {
+	return result[[]graph.Graph](f, "GetCatalogServiceGraphs", catalog_id)
+}
+
+func (f *Fake) GetGroup(ctx context.Context, group_id int) (group.Group, error) {
+	return result[group.Group](f, "GetGroup", group_id)
+}
+
+func (f *Fake) GetGraphGroups(ctx context.Context, graph_id int) ([]group.Group, error) {
+	return result[[]group.Group](f, "GetGraphGroups", graph_id)
+}
+
+func (f *Fake) CreateGroup(ctx context.Context, gr group.Group) (group.Group, error) {
+	return result[group.Group](f, "CreateGroup", gr)
+}
+
+func (f *Fake) UpdateGroup(ctx context.Context, group_id int, gr group.Group) error {
+	return f.call("UpdateGroup", group_id, gr)
+}
+
+func (f *Fake) DeleteGroup(ctx context.Context, group_id int) error {
+	return f.call("DeleteGroup", group_id)
+}
+
+func (f *Fake) GetCollapsedGraph(ctx context.Context, graph_id int, collapse []int) (group.CollapsedGraph, error) {
+	return result[group.CollapsedGraph](f, "GetCollapsedGraph", graph_id, collapse)
+}
+
+func (f *Fake) GetWebhook(ctx context.Context, webhook_id int) (webhook.Webhook, error) {
+	return result[webhook.Webhook](f, "GetWebhook", webhook_id)
+}
+
+func (f *Fake) GetProjectWebhooks(ctx context.Context, project_id int) ([]webhook.Webhook, error) {
+	return result[[]webhook.Webhook](f, "GetProjectWebhooks", project_id)
+}
+
+func (f *Fake) CreateWebhook(ctx context.Context, hook webhook.Webhook) (webhook.Webhook, error) {
+	return result[webhook.Webhook](f, "CreateWebhook", hook)
+}
+
+func (f *Fake) UpdateWebhook(ctx context.Context, webhook_id int, hook webhook.Webhook) error {
+	return f.call("UpdateWebhook", webhook_id, hook)
+}
+
+func (f *Fake) DeleteWebhook(ctx context.Context, webhook_id int) error {
+	return f.call("DeleteWebhook", webhook_id)
+}
+
+func (f *Fake) GetWebhookDeliveries(ctx context.Context, webhook_id int) ([]webhook.Delivery, error) {
+	return result[[]webhook.Delivery](f, "GetWebhookDeliveries", webhook_id)
+}
+
+func (f *Fake) Plan(ctx context.Context, project_id int, man manifest.Manifest) (manifest.Plan, error) {
+	return result[manifest.Plan](f, "Plan", project_id, man)
+}
+
+func (f *Fake) Apply(ctx context.Context, project_id int, man manifest.Manifest) (manifest.Plan, error) {
+	return result[manifest.Plan](f, "Apply", project_id, man)
+}
+
+// Begin records the key alone, the fingerprint is a hash of the request
+func (f *Fake) Begin(ctx context.Context, key string, fingerprint string) (*idempotency.Response, error) {
+	return result[*idempotency.Response](f, "Begin", key)
+}
+
+func (f *Fake) Complete(ctx context.Context, key string, fingerprint string, res idempotency.Response) error {
+	return f.call("Complete", key, res)
+}
+
+func (f *Fake) Release(ctx context.Context, key string, fingerprint string) error {
+	return f.call("Release", key)
+}
@@ -0,0 +1,229 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+	"github.com/olegdayo/omniconv"
+)
+
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type graphQLLoadersKey struct{}
+
+// graphQLLoaders are created per request, so that results are never shared
+// between queries
+type graphQLLoaders struct {
+	projectGraphs    *batchLoader[Graph]
+	graphServices    *batchLoader[Service]
+	serviceRelations *batchLoader[Relation]
+}
+
+func (s *Server) newGraphQLLoaders() *graphQLLoaders {
+	return &graphQLLoaders{
+		projectGraphs: newBatchLoader(func(ctx context.Context, ids []int) ([]Graph, error) {
+			graphs, err := s.providerGraph.GetProjectsGraphs(ctx, ids)
+			return omniconv.ConvertSlice(graphs, ProviderGraph2ServerGraph), err
+		}, func(g Graph) int { return g.ProjectID }),
+		graphServices: newBatchLoader(func(ctx context.Context, ids []int) ([]Service, error) {
+			services, err := s.providerService.GetGraphsServices(ctx, ids)
+			return omniconv.ConvertSlice(services, ProviderService2ServerService), err
+		}, func(serv Service) int { return serv.GraphID }),
+		serviceRelations: newBatchLoader(func(ctx context.Context, ids []int) ([]Relation, error) {
+			relations, err := s.providerRelation.GetServicesRelations(ctx, ids)
+			return omniconv.ConvertSlice(relations, ProviderRelation2ServerRelation), err
+		}, func(rel Relation) int { return rel.FromService }),
+	}
+}
+
+func loadersFromContext(ctx context.Context) *graphQLLoaders {
+	return ctx.Value(graphQLLoadersKey{}).(*graphQLLoaders)
+}
+
+// newGraphQLSchema describes projects, their graphs, graph services and the
+// relations going out of every service. Field names follow the JSON of the
+// REST API. Nested lists are resolved through batch loaders, so a query costs
+// one storage call per level instead of one per parent.
+func (s *Server) newGraphQLSchema() (graphql.Schema, error) {
+	relationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Relation",
+		Fields: graphql.Fields{
+			"id":                  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"graph_id":            &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"name":                &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"from_service":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"to_service":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"protocol":            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"async":               &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"bidirectional":       &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"criticality":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"expected_rps":        &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"expected_latency_ms": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	linksType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ServiceLinks",
+		Fields: graphql.Fields{
+			"documentation": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"runbook":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"repository":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	attributeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Attribute",
+		Fields: graphql.Fields{
+			"key":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"value": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	serviceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Service",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"graph_id":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"x":           &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"y":           &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"group_id":    &graphql.Field{Type: graphql.Int},
+			"catalog_id":  &graphql.Field{Type: graphql.Int},
+			"kind":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"owner_team":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"tags":        &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+			"attributes": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(attributeType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					attributes := p.Source.(Service).Attributes
+					res := make([]map[string]any, 0, len(attributes))
+					for key, value := range attributes {
+						res = append(res, map[string]any{"key": key, "value": value})
+					}
+					sort.Slice(res, func(i, j int) bool { return res[i]["key"].(string) < res[j]["key"].(string) })
+					return res, nil
+				},
+			},
+			"links": &graphql.Field{Type: graphql.NewNonNull(linksType)},
+			"relations": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(relationType))),
+				Description: "Relations going out of the service",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadersFromContext(p.Context).serviceRelations.Load(p.Context, p.Source.(Service).ID), nil
+				},
+			},
+		},
+	})
+
+	graphType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Graph",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"project_id": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"name":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"services": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(serviceType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadersFromContext(p.Context).graphServices.Load(p.Context, p.Source.(Graph).ID), nil
+				},
+			},
+		},
+	})
+
+	projectType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Project",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"graphs": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadersFromContext(p.Context).projectGraphs.Load(p.Context, p.Source.(Project).ID), nil
+				},
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"projects": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(projectType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					projects, err := s.providerProject.GetProjects(p.Context)
+					if err != nil {
+						return nil, err
+					}
+					return omniconv.ConvertSlice(projects, ProviderProject2ServerProject), nil
+				},
+			},
+			"graph": &graphql.Field{
+				Type: graphType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					graph, err := s.providerGraph.GetGraph(p.Context, p.Args["id"].(int))
+					if err != nil {
+						return nil, err
+					}
+					return ProviderGraph2ServerGraph(graph), nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+func (s *Server) graphQLHandler(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			err := json.Unmarshal([]byte(variables), &req.Variables)
+			if err != nil {
+				http.Error(w, "Invalid variables: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	} else {
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if req.Query == "" {
+		http.Error(w, "Query must not be empty", http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), graphQLLoadersKey{}, s.newGraphQLLoaders())
+	result := graphql.Do(graphql.Params{
+		Schema:         s.graphqlSchema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+	body, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/olegdayo/omniconv"
+)
+
+func (s *Server) getGraphGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	graph_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	groups, err := s.providerGroup.GetGraphGroups(r.Context(), graph_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(omniconv.ConvertSlice(groups, ProviderGroup2ServerGroup))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// getCollapsedGraphHandler collapses the groups listed in ?group=, or every
+// top level group when there are none.
+func (s *Server) getCollapsedGraphHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	graph_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+	collapse := make([]int, 0)
+	for _, raw := range r.URL.Query()["group"] {
+		group_id, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "Group ID must be a number", http.StatusBadRequest)
+			return
+		}
+		collapse = append(collapse, group_id)
+	}
+
+	graph, err := s.providerGroup.GetCollapsedGraph(r.Context(), graph_id, collapse)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(ProviderCollapsedGraph2ServerCollapsedGraph(graph))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func (s *Server) getGroupHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	group_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	group, err := s.providerGroup.GetGroup(r.Context(), group_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(ProviderGroup2ServerGroup(group))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func (s *Server) createGroupHandler(w http.ResponseWriter, r *http.Request) {
+	var gr Group
+	err := json.NewDecoder(r.Body).Decode(&gr)
+	if err != nil {
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	newgroup, err := s.providerGroup.CreateGroup(r.Context(), ServerGroup2ProviderGroup(gr))
+	if isClientError(err) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(ProviderGroup2ServerGroup(newgroup))
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Write(body)
+}
+
+func (s *Server) updateGroupHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	group_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	var gr Group
+	err = json.NewDecoder(r.Body).Decode(&gr)
+	if err != nil {
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = s.providerGroup.UpdateGroup(r.Context(), group_id, ServerGroup2ProviderGroup(gr))
+	if isClientError(err) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteGroupHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	group_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	err = s.providerGroup.DeleteGroup(r.Context(), group_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -13,31 +13,28 @@ func (s *Server) createProjectHandler(w http.ResponseWriter, r *http.Request) {
 	var project Project
 	err := json.NewDecoder(r.Body).Decode(&project)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	newproject, err := s.providerProject.CreateProject(r.Context(), ServerProject2ProviderProject(project))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	body, err := json.Marshal(ProviderProject2ServerProject(newproject))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
-	w.Write(body)
 	w.WriteHeader(http.StatusOK)
+	w.Write(body)
 }
 
 func (s *Server) getProjectsHanlder(w http.ResponseWriter, r *http.Request) {
 	projects, err := s.providerProject.GetProjects(r.Context())
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	body, err := json.Marshal(omniconv.ConvertSlice(projects, ProviderProject2ServerProject))
@@ -60,8 +57,7 @@ func (s *Server) deleteProjectHandler(w http.ResponseWriter, r *http.Request) {
 
 	err = s.providerProject.DeleteProject(r.Context(), project_id)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -78,14 +74,13 @@ func (s *Server) updateProjectHandler(w http.ResponseWriter, r *http.Request) {
 	var project Project
 	err = json.NewDecoder(r.Body).Decode(&project)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	err = s.providerProject.UpdateProject(r.Context(), project_id, ServerProject2ProviderProject(project))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -100,8 +95,7 @@ func (s *Server) GetProjectGraphsHandler(w http.ResponseWriter, r *http.Request)
 	}
 	graphs, err := s.providerGraph.GetProjectGraphs(r.Context(), project_id)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	body, err := json.Marshal(omniconv.ConvertSlice(graphs, ProviderGraph2ServerGraph))
@@ -114,6 +108,29 @@ func (s *Server) GetProjectGraphsHandler(w http.ResponseWriter, r *http.Request)
 	w.Write(body)
 }
 
+func (s *Server) getGraphHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	graph_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	graph, err := s.providerGraph.GetGraph(r.Context(), graph_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(ProviderGraph2ServerGraph(graph))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
 func (s *Server) updateGraphHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	graph_id, err := strconv.Atoi(vars["id"])
@@ -125,14 +142,13 @@ func (s *Server) updateGraphHandler(w http.ResponseWriter, r *http.Request) {
 	var graph Graph
 	err = json.NewDecoder(r.Body).Decode(&graph)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	err = s.providerGraph.UpdateGraph(r.Context(), graph_id, ServerGraph2ProviderGraph(graph))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -148,8 +164,7 @@ func (s *Server) deleteGraphHandler(w http.ResponseWriter, r *http.Request) {
 
 	err = s.providerGraph.DeleteGraph(r.Context(), graph_id)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -167,10 +182,20 @@ func (s *Server) updateGraphServicesHandler(w http.ResponseWriter, r *http.Reque
 		http.Error(w, "ID must be a number", http.StatusBadRequest)
 		return
 	}
+	if !s.checkBatch(w, len(services)) {
+		return
+	}
+	if err := validateServices(services...); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	err = s.providerService.UpdateGraphServices(r.Context(), graph_id, omniconv.ConvertSlice(services, ServerService2ProviderService))
+	if isClientError(err) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -188,10 +213,16 @@ func (s *Server) updateGraphRelationsHandler(w http.ResponseWriter, r *http.Requ
 		http.Error(w, "ID must be a number", http.StatusBadRequest)
 		return
 	}
+	if !s.checkBatch(w, len(relations)) {
+		return
+	}
+	if err := validateRelations(relations...); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	err = s.providerRelation.UpdateGraphRelations(r.Context(), graph_id, omniconv.ConvertSlice(relations, ServerRelation2ProviderRelation))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -201,20 +232,18 @@ func (s *Server) createGraphHandler(w http.ResponseWriter, r *http.Request) {
 	var graph Graph
 	err := json.NewDecoder(r.Body).Decode(&graph)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	newgraph, err := s.providerGraph.CreateGraph(r.Context(), ServerGraph2ProviderGraph(graph))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	body, err := json.Marshal(ProviderGraph2ServerGraph(newgraph))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -229,10 +258,15 @@ func (s *Server) getGraphServicesHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	services, err := s.providerService.GetGraphServices(r.Context(), graph_id)
+	filter, err := serviceFilterFromQuery(r.URL.Query())
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	services, err := s.providerService.GetGraphServices(r.Context(), graph_id, filter)
+	if err != nil {
+		writeProviderError(w, err)
 		return
 	}
 	body, err := json.Marshal(omniconv.ConvertSlice(services, ProviderService2ServerService))
@@ -255,8 +289,7 @@ func (s *Server) getGraphRelationsHandler(w http.ResponseWriter, r *http.Request
 
 	relations, err := s.providerRelation.GetGraphRelations(r.Context(), graph_id)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	body, err := json.Marshal(omniconv.ConvertSlice(relations, ProviderRelation2ServerRelation))
@@ -278,21 +311,33 @@ func (s *Server) createGraphServicesHandler(w http.ResponseWriter, r *http.Reque
 	}
 	var services []Service
 	if err := json.NewDecoder(r.Body).Decode(&services); err != nil {
-		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !s.checkBatch(w, len(services)) {
+		return
+	}
+	if err := validateServices(services...); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	ids, err := s.providerService.CreateServices(r.Context(), graph_id, omniconv.ConvertSlice(services, ServerService2ProviderService))
+	if isClientError(err) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
-		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		writeProviderError(w, err)
 		return
 	}
 	body, err := json.Marshal(ids)
 	if err != nil {
-		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
 		return
 	}
-	w.Write(body)
 	w.WriteHeader(http.StatusCreated)
+	w.Write(body)
 }
 
 func (s *Server) createGraphRelationsHandler(w http.ResponseWriter, r *http.Request) {
@@ -304,12 +349,19 @@ func (s *Server) createGraphRelationsHandler(w http.ResponseWriter, r *http.Requ
 	}
 	var relations []Relation
 	if err := json.NewDecoder(r.Body).Decode(&relations); err != nil {
-		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !s.checkBatch(w, len(relations)) {
+		return
+	}
+	if err := validateRelations(relations...); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	err = s.providerRelation.CreateRelations(r.Context(), graph_id, omniconv.ConvertSlice(relations, ServerRelation2ProviderRelation))
 	if err != nil {
-		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
@@ -325,8 +377,7 @@ func (s *Server) getServiceHandler(w http.ResponseWriter, r *http.Request) {
 
 	service, err := s.providerService.GetService(r.Context(), service_id)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	body, err := json.Marshal(ProviderService2ServerService(service))
@@ -349,8 +400,7 @@ func (s *Server) deleteServiceHandler(w http.ResponseWriter, r *http.Request) {
 
 	err = s.providerService.DeleteService(r.Context(), service_id)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -367,14 +417,21 @@ func (s *Server) updateServiceHandler(w http.ResponseWriter, r *http.Request) {
 	var service Service
 	err = json.NewDecoder(r.Body).Decode(&service)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateServices(service); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	err = s.providerService.UpdateService(r.Context(), service_id, ServerService2ProviderService(service))
+	if isClientError(err) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -384,20 +441,26 @@ func (s *Server) createServiceHandler(w http.ResponseWriter, r *http.Request) {
 	var service Service
 	err := json.NewDecoder(r.Body).Decode(&service)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateServices(service); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	newservice, err := s.providerService.CreateService(r.Context(), ServerService2ProviderService(service))
+	if isClientError(err) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	body, err := json.Marshal(ProviderService2ServerService(newservice))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
@@ -414,8 +477,7 @@ func (s *Server) getRelationHandler(w http.ResponseWriter, r *http.Request) {
 
 	relation, err := s.providerRelation.GetRelation(r.Context(), relation_id)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	body, err := json.Marshal(ProviderRelation2ServerRelation(relation))
@@ -438,8 +500,7 @@ func (s *Server) deleteRelationHandler(w http.ResponseWriter, r *http.Request) {
 
 	err = s.providerRelation.DeleteRelation(r.Context(), relation_id)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
@@ -456,14 +517,17 @@ func (s *Server) updateRelationHandler(w http.ResponseWriter, r *http.Request) {
 	var relation Relation
 	err = json.NewDecoder(r.Body).Decode(&relation)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateRelations(relation); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	err = s.providerRelation.UpdateRelation(r.Context(), relation_id, ServerRelation2ProviderRelation(relation))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
@@ -473,20 +537,22 @@ func (s *Server) createRelationHandler(w http.ResponseWriter, r *http.Request) {
 	var relation Relation
 	err := json.NewDecoder(r.Body).Decode(&relation)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateRelations(relation); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	newrelation, err := s.providerRelation.CreateRelation(r.Context(), ServerRelation2ProviderRelation(relation))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	body, err := json.Marshal(ProviderRelation2ServerRelation(newrelation))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Something went wrong: " + err.Error()))
+		writeProviderError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
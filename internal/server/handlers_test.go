@@ -0,0 +1,495 @@
+package server_test
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/config"
+	"github.com/hse-telescope/core/internal/providers/catalog"
+	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/providers/group"
+	"github.com/hse-telescope/core/internal/providers/idempotency"
+	"github.com/hse-telescope/core/internal/providers/manifest"
+	"github.com/hse-telescope/core/internal/providers/project"
+	"github.com/hse-telescope/core/internal/providers/relation"
+	"github.com/hse-telescope/core/internal/providers/service"
+	"github.com/hse-telescope/core/internal/providers/webhook"
+	"github.com/hse-telescope/core/internal/server"
+)
+
+var errProvider = errors.New("provider is down")
+
+// newServer builds a server with fake as every provider
+func newServer(fake *Fake) *server.Server {
+	return server.New(config.Config{}, fake, fake, fake, fake, fake, fake, fake, fake, fake)
+}
+
+// Case is a request served with fake providers and what it has to produce.
+// Body is a substring of the response, Call is the only provider call
+// expected, nil when the handler has to reject the request before that.
+// Calls lists the calls in order instead for handlers calling several
+// providers.
+type Case struct {
+	Name    string
+	Method  string
+	Path    string
+	Request string
+	Header  map[string]string
+	Setup   func(f *Fake)
+	Status  int
+	Body    string
+	Call    *Call
+	Calls   []Call
+}
+
+// Serve serves the case and checks the response and the provider calls
+func (c Case) Serve(t *testing.T) {
+	t.Helper()
+	fake := newFake()
+	if c.Setup != nil {
+		c.Setup(fake)
+	}
+	req := httptest.NewRequest(c.Method, c.Path, strings.NewReader(c.Request))
+	for name, value := range c.Header {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	newServer(fake).ServeHTTP(rec, req)
+
+	if rec.Code != c.Status {
+		t.Errorf("%s %s: status %d, want %d: %s", c.Method, c.Path, rec.Code, c.Status, rec.Body)
+	}
+	if !strings.Contains(rec.Body.String(), c.Body) {
+		t.Errorf("%s %s: body %q does not contain %q", c.Method, c.Path, rec.Body, c.Body)
+	}
+	want := c.Calls
+	if c.Call != nil {
+		want = []Call{*c.Call}
+	}
+	switch {
+	case len(want) == 0 && len(fake.Calls) != 0:
+		t.Errorf("%s %s: providers are called: %+v", c.Method, c.Path, fake.Calls)
+	case len(want) != 0 && !reflect.DeepEqual(fake.Calls, want):
+		t.Errorf("%s %s: calls %+v, want %+v", c.Method, c.Path, fake.Calls, want)
+	}
+}
+
+// TestHandlers serves every case of the suite
+func TestHandlers(t *testing.T) {
+	for _, c := range cases() {
+		t.Run(c.Name, c.Serve)
+	}
+}
+
+func results(method string, res any) func(f *Fake) {
+	return func(f *Fake) {
+		f.Results[method] = res
+	}
+}
+
+func fails(method string, err error) func(f *Fake) {
+	return func(f *Fake) {
+		f.Errors[method] = err
+	}
+}
+
+func cases() []Case {
+	return []Case{
+		{
+			Name:   "GetProjects",
+			Method: http.MethodGet, Path: "/projects",
+			Setup:  results("GetProjects", []project.Project{{ID: 1, Name: "shop"}}),
+			Status: http.StatusOK, Body: `[{"id":1,"name":"shop"}]`,
+			Call: &Call{Method: "GetProjects"},
+		},
+		{
+			Name:   "GetProjectsFailure",
+			Method: http.MethodGet, Path: "/projects",
+			Setup:  fails("GetProjects", errProvider),
+			Status: http.StatusInternalServerError, Body: "Something went wrong",
+			Call: &Call{Method: "GetProjects"},
+		},
+		{
+			Name:   "CreateProject",
+			Method: http.MethodPost, Path: "/projects", Request: `{"name":"shop"}`,
+			Setup:  results("CreateProject", project.Project{ID: 3, Name: "shop"}),
+			Status: http.StatusOK, Body: `{"id":3,"name":"shop"}`,
+			Call: &Call{Method: "CreateProject", Args: []any{project.Project{Name: "shop"}}},
+		},
+		{
+			Name:   "CreateProjectReplay",
+			Method: http.MethodPost, Path: "/projects", Request: `{"name":"shop"}`,
+			Header: map[string]string{"Idempotency-Key": "retry"},
+			Setup:  results("Begin", &idempotency.Response{Status: http.StatusOK, ContentType: "application/json", Body: []byte(`{"id":3,"name":"shop"}`)}),
+			Status: http.StatusOK, Body: `{"id":3,"name":"shop"}`,
+			Call: &Call{Method: "Begin", Args: []any{"retry"}},
+		},
+		{
+			Name:   "CreateProjectKeyReused",
+			Method: http.MethodPost, Path: "/projects", Request: `{"name":"store"}`,
+			Header: map[string]string{"Idempotency-Key": "retry"},
+			Setup:  fails("Begin", idempotency.ErrKeyReused),
+			Status: http.StatusUnprocessableEntity,
+			Call:   &Call{Method: "Begin", Args: []any{"retry"}},
+		},
+		{
+			Name:   "UpdateProject",
+			Method: http.MethodPut, Path: "/projects/7", Request: `{"name":"store"}`,
+			Status: http.StatusOK,
+			Call:   &Call{Method: "UpdateProject", Args: []any{7, project.Project{Name: "store"}}},
+		},
+		{
+			Name:   "UpdateProjectNotFound",
+			Method: http.MethodPut, Path: "/projects/7", Request: `{"name":"store"}`,
+			Setup:  fails("UpdateProject", sql.ErrNoRows),
+			Status: http.StatusNotFound, Body: "Not found",
+			Call: &Call{Method: "UpdateProject", Args: []any{7, project.Project{Name: "store"}}},
+		},
+		{
+			Name:   "CreateProjectInvalidBody",
+			Method: http.MethodPost, Path: "/projects", Request: `{"name":`,
+			Status: http.StatusBadRequest, Body: "Invalid body",
+		},
+		{
+			Name:   "UpdateProjectInvalidBody",
+			Method: http.MethodPut, Path: "/projects/7", Request: `{"name":`,
+			Status: http.StatusBadRequest, Body: "Invalid body",
+		},
+		{
+			Name:   "CreateGraphInvalidBody",
+			Method: http.MethodPost, Path: "/graphs", Request: `{"project_id":2,"name":`,
+			Status: http.StatusBadRequest, Body: "Invalid body",
+		},
+		{
+			Name:   "UpdateGraphInvalidBody",
+			Method: http.MethodPut, Path: "/graphs/4", Request: `{"name":`,
+			Status: http.StatusBadRequest, Body: "Invalid body",
+		},
+		{
+			Name:   "DeleteProjectBadID",
+			Method: http.MethodDelete, Path: "/projects/seven",
+			Status: http.StatusBadRequest, Body: "ID must be a number",
+		},
+		{
+			Name:   "GetProjectGraphs",
+			Method: http.MethodGet, Path: "/projects/2/graphs",
+			Setup:  results("GetProjectGraphs", []graph.Graph{{ID: 4, ProjectID: 2, Name: "prod"}}),
+			Status: http.StatusOK, Body: `[{"id":4,"project_id":2,"name":"prod"}]`,
+			Call: &Call{Method: "GetProjectGraphs", Args: []any{2}},
+		},
+		{
+			Name:   "GetGraphFailure",
+			Method: http.MethodGet, Path: "/graphs/4",
+			Setup:  fails("GetGraph", errProvider),
+			Status: http.StatusInternalServerError, Body: errProvider.Error(),
+			Call: &Call{Method: "GetGraph", Args: []any{4}},
+		},
+		{
+			Name:   "GetGraphNotFound",
+			Method: http.MethodGet, Path: "/graphs/4",
+			Setup:  fails("GetGraph", sql.ErrNoRows),
+			Status: http.StatusNotFound, Body: "Not found",
+			Call: &Call{Method: "GetGraph", Args: []any{4}},
+		},
+		{
+			Name:   "GetGraphServicesFilter",
+			Method: http.MethodGet, Path: "/graphs/4/services?kind=api&owner_team=core&tag=go&tag=http&attr=tier:1",
+			Setup:  results("GetGraphServices", []service.Service{{ID: 5, GraphID: 4, Name: "api", Kind: service.KindAPI}}),
+			Status: http.StatusOK, Body: `"name":"api"`,
+			Call: &Call{Method: "GetGraphServices", Args: []any{4, service.Filter{
+				Kind:       service.KindAPI,
+				OwnerTeam:  "core",
+				Tags:       []string{"go", "http"},
+				Attributes: map[string]string{"tier": "1"},
+			}}},
+		},
+		{
+			Name:   "GetGraphServicesBadFilter",
+			Method: http.MethodGet, Path: "/graphs/4/services?attr=tier",
+			Status: http.StatusBadRequest, Body: "key:value",
+		},
+		{
+			Name:   "CreateServiceUnknownKind",
+			Method: http.MethodPost, Path: "/services", Request: `{"graph_id":4,"name":"api","kind":"mainframe"}`,
+			Status: http.StatusBadRequest, Body: "unknown service kind",
+		},
+		{
+			Name:   "CreateServiceForeignCatalog",
+			Method: http.MethodPost, Path: "/services", Request: `{"graph_id":4,"name":"api","catalog_id":9}`,
+			Setup:  fails("CreateService", service.ErrForeignCatalogService),
+			Status: http.StatusBadRequest, Body: service.ErrForeignCatalogService.Error(),
+			Call: &Call{Method: "CreateService", Args: []any{service.Service{GraphID: 4, Name: "api", CatalogID: ptr(9)}}},
+		},
+		{
+			Name:   "CreateServiceInvalidBody",
+			Method: http.MethodPost, Path: "/services", Request: `{"graph_id":4,"name":`,
+			Status: http.StatusBadRequest, Body: "Invalid body",
+		},
+		{
+			Name:   "UpdateServiceInvalidBody",
+			Method: http.MethodPut, Path: "/services/5", Request: `{"graph_id":"4"}`,
+			Status: http.StatusBadRequest, Body: "Invalid body",
+		},
+		{
+			Name:   "CreateGraphServices",
+			Method: http.MethodPost, Path: "/graphs/4/services", Request: `[{"name":"api"},{"name":"web"}]`,
+			Setup:  results("CreateServices", []int{5, 6}),
+			Status: http.StatusCreated, Body: `[5,6]`,
+			Call: &Call{Method: "CreateServices", Args: []any{4, []service.Service{{Name: "api"}, {Name: "web"}}}},
+		},
+		{
+			Name:   "CreateGraphServicesFailure",
+			Method: http.MethodPost, Path: "/graphs/4/services", Request: `[{"name":"api"}]`,
+			Setup:  fails("CreateServices", errProvider),
+			Status: http.StatusInternalServerError, Body: errProvider.Error(),
+			Call: &Call{Method: "CreateServices", Args: []any{4, []service.Service{{Name: "api"}}}},
+		},
+		{
+			Name:   "UpdateGraphServices",
+			Method: http.MethodPut, Path: "/graphs/4/services", Request: `[{"id":5,"name":"api","x":10}]`,
+			Status: http.StatusOK,
+			Call:   &Call{Method: "UpdateGraphServices", Args: []any{4, []service.Service{{ID: 5, Name: "api", X: 10}}}},
+		},
+		{
+			Name:   "UpdateGraphServicesTooMany",
+			Method: http.MethodPut, Path: "/graphs/4/services", Request: "[" + strings.Repeat(`{"id":5,"name":"api"},`, 1000) + `{"id":5,"name":"api"}]`,
+			Status: http.StatusRequestEntityTooLarge, Body: "At most 1000 items",
+		},
+		{
+			Name:   "CreateProjectBodyTooLarge",
+			Method: http.MethodPost, Path: "/projects", Request: `{"name":"` + strings.Repeat("a", 4<<20) + `"}`,
+			Status: http.StatusRequestEntityTooLarge, Body: "Body is larger than 4194304 bytes",
+		},
+		{
+			Name:   "PatchServiceMerge",
+			Method: http.MethodPatch, Path: "/services/5", Request: `{"name":"api","x":10,"y":20}`,
+			Header: map[string]string{"Content-Type": "application/merge-patch+json"},
+			Setup:  results("PatchService", service.Service{ID: 5, GraphID: 4, Name: "api", Description: "entrypoint", Kind: service.KindAPI, Tags: []string{"go"}, Attributes: map[string]string{"tier": "0"}}),
+			Status: http.StatusOK, Body: `"description":"entrypoint","x":10,"y":20`,
+			Call: &Call{Method: "PatchService", Args: []any{5,
+				service.Service{ID: 5, GraphID: 4, Name: "api", Description: "entrypoint", Kind: service.KindAPI, Tags: []string{"go"}, Attributes: map[string]string{"tier": "0"}, X: 10, Y: 20},
+				[]string{"name", "x", "y"},
+			}},
+		},
+		{
+			Name:   "PatchServiceLinks",
+			Method: http.MethodPatch, Path: "/services/5", Request: `[{"op":"test","path":"/name","value":"api"},{"op":"add","path":"/links/runbook","value":"https://runbooks.example.com/api"},{"op":"add","path":"/tags/-","value":"http"}]`,
+			Header: map[string]string{"Content-Type": "application/json-patch+json"},
+			Setup:  results("PatchService", service.Service{ID: 5, GraphID: 4, Name: "api", Tags: []string{"go"}}),
+			Status: http.StatusOK, Body: `"runbook":"https://runbooks.example.com/api"`,
+			Call: &Call{Method: "PatchService", Args: []any{5,
+				service.Service{ID: 5, GraphID: 4, Name: "api", Tags: []string{"go", "http"}, Attributes: map[string]string{}, RunbookURL: "https://runbooks.example.com/api"},
+				[]string{"runbook_url", "tags"},
+			}},
+		},
+		{
+			Name:   "PatchServiceTestFailed",
+			Method: http.MethodPatch, Path: "/services/5", Request: `[{"op":"test","path":"/name","value":"web"},{"op":"replace","path":"/name","value":"gateway"}]`,
+			Header: map[string]string{"Content-Type": "application/json-patch+json"},
+			Setup:  results("PatchService", service.Service{ID: 5, GraphID: 4, Name: "api"}),
+			Status: http.StatusConflict, Body: "test operation failed",
+			Call: &Call{Method: "PatchService", Args: []any{5}},
+		},
+		{
+			Name:   "PatchServiceNotFound",
+			Method: http.MethodPatch, Path: "/services/5", Request: `{"x":10}`,
+			Header: map[string]string{"Content-Type": "application/merge-patch+json"},
+			Setup:  fails("PatchService", sql.ErrNoRows),
+			Status: http.StatusNotFound, Body: "Not found",
+			Call: &Call{Method: "PatchService", Args: []any{5}},
+		},
+		{
+			Name:   "PatchProjectUnsupportedType",
+			Method: http.MethodPatch, Path: "/projects/2", Request: `name=store`,
+			Header: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+			Status: http.StatusUnsupportedMediaType, Body: "application/merge-patch+json",
+		},
+		{
+			Name:   "PatchProjectRemovesUnknownMember",
+			Method: http.MethodPatch, Path: "/projects/2", Request: `{"name":"store","owner":null}`,
+			Header: map[string]string{"Content-Type": "application/merge-patch+json"},
+			Setup:  results("PatchProject", project.Project{ID: 2, Name: "shop"}),
+			Status: http.StatusOK, Body: `"name":"store"`,
+			Call: &Call{Method: "PatchProject", Args: []any{2, project.Project{ID: 2, Name: "store"}, []string{"name"}}},
+		},
+		{
+			Name:   "PatchGraphUnknownField",
+			Method: http.MethodPatch, Path: "/graphs/4", Request: `{"owner":"core"}`,
+			Header: map[string]string{"Content-Type": "application/merge-patch+json"},
+			Setup:  results("PatchGraph", graph.Graph{ID: 4, ProjectID: 2, Name: "prod"}),
+			Status: http.StatusUnprocessableEntity, Body: "unknown field",
+			Call: &Call{Method: "PatchGraph", Args: []any{4}},
+		},
+		{
+			Name:   "PatchRelationID",
+			Method: http.MethodPatch, Path: "/relations/8", Request: `[{"op":"replace","path":"/id","value":9}]`,
+			Header: map[string]string{"Content-Type": "application/json-patch+json"},
+			Setup:  results("PatchRelation", relation.Relation{ID: 8, FromService: 5, ToService: 6}),
+			Status: http.StatusUnprocessableEntity, Body: "id cannot be patched",
+			Call: &Call{Method: "PatchRelation", Args: []any{8}},
+		},
+		{
+			Name:   "CreateRelationUnknownProtocol",
+			Method: http.MethodPost, Path: "/relations", Request: `{"graph_id":4,"from_service":5,"to_service":6,"protocol":"smtp"}`,
+			Status: http.StatusBadRequest, Body: "unknown relation protocol",
+		},
+		{
+			Name:   "CreateRelationNegativeRPS",
+			Method: http.MethodPost, Path: "/relations", Request: `{"graph_id":4,"from_service":5,"to_service":6,"expected_rps":-1}`,
+			Status: http.StatusBadRequest, Body: "must not be negative",
+		},
+		{
+			Name:   "CreateRelationInvalidBody",
+			Method: http.MethodPost, Path: "/relations", Request: `{"graph_id":4,"from_service":`,
+			Status: http.StatusBadRequest, Body: "Invalid body",
+		},
+		{
+			Name:   "UpdateRelationInvalidBody",
+			Method: http.MethodPut, Path: "/relations/8", Request: `{"async":"yes"}`,
+			Status: http.StatusBadRequest, Body: "Invalid body",
+		},
+		{
+			Name:   "CreateGraphRelationsFailure",
+			Method: http.MethodPost, Path: "/graphs/4/relations", Request: `[{"from_service":5,"to_service":6}]`,
+			Setup:  fails("CreateRelations", errProvider),
+			Status: http.StatusInternalServerError, Body: errProvider.Error(),
+			Call: &Call{Method: "CreateRelations", Args: []any{4, []relation.Relation{{FromService: 5, ToService: 6}}}},
+		},
+		{
+			Name:   "GetRelation",
+			Method: http.MethodGet, Path: "/relations/8",
+			Setup:  results("GetRelation", relation.Relation{ID: 8, FromService: 5, ToService: 6, Protocol: relation.ProtocolGRPC}),
+			Status: http.StatusOK, Body: `"protocol":"grpc"`,
+			Call: &Call{Method: "GetRelation", Args: []any{8}},
+		},
+		{
+			Name:   "CreateCatalogServiceEmptyName",
+			Method: http.MethodPost, Path: "/projects/2/catalog", Request: `{"kind":"api"}`,
+			Status: http.StatusBadRequest, Body: "name must not be empty",
+		},
+		{
+			Name:   "GetCatalogServiceGraphs",
+			Method: http.MethodGet, Path: "/catalog/9/graphs",
+			Setup:  results("GetCatalogServiceGraphs", []graph.Graph{{ID: 4, ProjectID: 2, Name: "prod"}}),
+			Status: http.StatusOK, Body: `"name":"prod"`,
+			Call: &Call{Method: "GetCatalogServiceGraphs", Args: []any{9}},
+		},
+		{
+			Name:   "CreateCatalogService",
+			Method: http.MethodPost, Path: "/projects/2/catalog", Request: `{"name":"auth","kind":"api"}`,
+			Setup:  results("CreateCatalogService", catalog.Service{ID: 9, ProjectID: 2, Name: "auth", Kind: service.KindAPI}),
+			Status: http.StatusCreated, Body: `"id":9`,
+			Call: &Call{Method: "CreateCatalogService", Args: []any{catalog.Service{ProjectID: 2, Name: "auth", Kind: service.KindAPI}}},
+		},
+		{
+			Name:   "CreateGroup",
+			Method: http.MethodPost, Path: "/groups", Request: `{"graph_id":4,"name":"platform"}`,
+			Setup:  results("CreateGroup", group.Group{ID: 10, GraphID: 4, Name: "platform"}),
+			Status: http.StatusCreated, Body: `"id":10`,
+			Call: &Call{Method: "CreateGroup", Args: []any{group.Group{GraphID: 4, Name: "platform"}}},
+		},
+		{
+			Name:   "UpdateGroupCycle",
+			Method: http.MethodPut, Path: "/groups/10", Request: `{"graph_id":4,"parent_id":10,"name":"platform"}`,
+			Setup:  fails("UpdateGroup", group.ErrCycle),
+			Status: http.StatusBadRequest, Body: group.ErrCycle.Error(),
+			Call: &Call{Method: "UpdateGroup", Args: []any{10, group.Group{GraphID: 4, ParentID: ptr(10), Name: "platform"}}},
+		},
+		{
+			Name:   "CreateWebhookBadURL",
+			Method: http.MethodPost, Path: "/projects/2/webhooks", Request: `{"url":"ftp://hooks.example.com","events":[]}`,
+			Status: http.StatusBadRequest, Body: "absolute http(s) URL",
+		},
+		{
+			Name:   "CreateWebhook",
+			Method: http.MethodPost, Path: "/projects/2/webhooks", Request: `{"url":"https://hooks.example.com","events":["graph.created"]}`,
+			Setup:  results("CreateWebhook", webhook.Webhook{ID: 11, ProjectID: 2, URL: "https://hooks.example.com", Events: []string{"graph.created"}}),
+			Status: http.StatusCreated, Body: `"id":11`,
+			Call: &Call{Method: "CreateWebhook", Args: []any{webhook.Webhook{ProjectID: 2, URL: "https://hooks.example.com", Events: []string{"graph.created"}}}},
+		},
+		{
+			Name:   "PlanYAML",
+			Method: http.MethodPost, Path: "/projects/2/plan", Request: "graphs:\n  - name: prod\n",
+			Setup: results("Plan", manifest.Plan{Changes: []manifest.Change{
+				{Action: manifest.ActionCreate, Type: manifest.TypeGraph, Graph: "prod", Key: "prod"},
+			}}),
+			Status: http.StatusOK, Body: `"action":"create"`,
+			Call: &Call{Method: "Plan", Args: []any{2, manifest.Manifest{Graphs: []manifest.Graph{{Name: "prod", Services: []manifest.Service{}, Relations: []manifest.Relation{}}}}}},
+		},
+		{
+			Name:   "PlanUnknownField",
+			Method: http.MethodPost, Path: "/projects/2/plan", Request: `{"graphs":[],"services":[]}`,
+			Status: http.StatusBadRequest, Body: "Invalid body",
+		},
+		{
+			Name:   "ApplyInvalidManifest",
+			Method: http.MethodPost, Path: "/projects/2/apply", Request: `{"graphs":[{"name":""}]}`,
+			Setup:  fails("Apply", manifest.ErrInvalidManifest),
+			Status: http.StatusBadRequest, Body: manifest.ErrInvalidManifest.Error(),
+			Call: &Call{Method: "Apply", Args: []any{2, manifest.Manifest{Graphs: []manifest.Graph{{Services: []manifest.Service{}, Relations: []manifest.Relation{}}}}}},
+		},
+		{
+			Name:   "ApplyAmbiguousKey",
+			Method: http.MethodPost, Path: "/projects/2/apply", Request: `{"graphs":[]}`,
+			Setup:  fails("Apply", manifest.ErrAmbiguousKey),
+			Status: http.StatusConflict, Body: manifest.ErrAmbiguousKey.Error(),
+			Call: &Call{Method: "Apply", Args: []any{2, manifest.Manifest{Graphs: []manifest.Graph{}}}},
+		},
+		{
+			Name:   "GraphQL",
+			Method: http.MethodPost, Path: "/graphql", Request: `{"query":"{ projects { id } }"}`,
+			Setup:  results("GetProjects", []project.Project{{ID: 1, Name: "shop"}}),
+			Status: http.StatusOK, Body: `{"data":{"projects":[{"id":1}]}}`,
+			Call: &Call{Method: "GetProjects"},
+		},
+		{
+			Name:   "GraphQLLoadsEveryLevelAtOnce",
+			Method: http.MethodPost, Path: "/graphql",
+			Request: `{"query":"{ projects { id graphs { id services { id relations { id to_service } } } } }"}`,
+			Setup: func(f *Fake) {
+				f.Results["GetProjects"] = []project.Project{{ID: 1}, {ID: 2}}
+				f.Results["GetProjectsGraphs"] = []graph.Graph{{ID: 10, ProjectID: 1}, {ID: 11, ProjectID: 2}, {ID: 12, ProjectID: 1}}
+				f.Results["GetGraphsServices"] = []service.Service{{ID: 100, GraphID: 10}, {ID: 101, GraphID: 12}, {ID: 102, GraphID: 12}}
+				f.Results["GetServicesRelations"] = []relation.Relation{{ID: 1000, FromService: 101, ToService: 102}, {ID: 1001, FromService: 100, ToService: 101}}
+			},
+			Status: http.StatusOK,
+			Body:   `{"data":{"projects":[{"graphs":[{"id":10,"services":[{"id":100,"relations":[{"id":1001,"to_service":101}]}]},{"id":12,"services":[{"id":101,"relations":[{"id":1000,"to_service":102}]},{"id":102,"relations":[]}]}],"id":1},{"graphs":[{"id":11,"services":[]}],"id":2}]}}`,
+			Calls: []Call{
+				{Method: "GetProjects"},
+				{Method: "GetProjectsGraphs", Args: []any{[]int{1, 2}}},
+				{Method: "GetGraphsServices", Args: []any{[]int{10, 12, 11}}},
+				{Method: "GetServicesRelations", Args: []any{[]int{100, 101, 102}}},
+			},
+		},
+		{
+			Name:   "GraphQLLevelFails",
+			Method: http.MethodPost, Path: "/graphql",
+			Request: `{"query":"{ projects { graphs { services { relations { id } } } } }"}`,
+			Setup: func(f *Fake) {
+				f.Results["GetProjects"] = []project.Project{{ID: 1}, {ID: 2}}
+				f.Results["GetProjectsGraphs"] = []graph.Graph{{ID: 10, ProjectID: 1}, {ID: 11, ProjectID: 2}}
+				f.Errors["GetGraphsServices"] = errProvider
+			},
+			Status: http.StatusOK, Body: `"message":"provider is down"`,
+			Calls: []Call{
+				{Method: "GetProjects"},
+				{Method: "GetProjectsGraphs", Args: []any{[]int{1, 2}}},
+				{Method: "GetGraphsServices", Args: []any{[]int{10, 11}}},
+			},
+		},
+		{
+			Name:   "OpenAPI",
+			Method: http.MethodGet, Path: "/openapi.json",
+			Status: http.StatusOK, Body: `"/projects/{id}/apply"`,
+		},
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/hse-telescope/core/internal/health"
+)
+
+func (s *Server) healthzHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, s.health.Run(r.Context(), health.Liveness))
+}
+
+func (s *Server) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, s.health.Run(r.Context(), health.Readiness))
+}
+
+// checkShutdown fails once the server is draining
+func (s *Server) checkShutdown(ctx context.Context) (string, error) {
+	if s.draining.Load() {
+		return "", errors.New("the instance is shutting down")
+	}
+	return "", nil
+}
+
+func writeHealthReport(w http.ResponseWriter, report health.Report) {
+	body, err := json.Marshal(report)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong: " + err.Error()))
+		return
+	}
+	status := http.StatusOK
+	if report.Status != health.StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	w.Write(body)
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/hse-telescope/core/internal/providers/idempotency"
+)
+
+const (
+	// idempotencyKeyHeader makes a POST request safe to retry, the retries
+	// get the response of the first request
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader marks a response stored for an earlier request
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// idempotencyMiddleware replays the stored response of POST requests sent
+// again with the same Idempotency-Key. Failures of the server are not stored,
+// a retry runs the request again.
+func (s *Server) idempotencyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			http.Error(w, fmt.Sprintf("Idempotency-Key must be at most %d characters", maxIdempotencyKeyLength), http.StatusBadRequest)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := requestFingerprint(r, body)
+
+		res, err := s.providerIdempotency.Begin(r.Context(), key, fingerprint)
+		switch {
+		case errors.Is(err, idempotency.ErrKeyReused):
+			http.Error(w, "Idempotency-Key is already used by a different request", http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, idempotency.ErrInProgress):
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "A request with the same Idempotency-Key is in progress", http.StatusConflict)
+			return
+		case err != nil:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("Something went wrong: " + err.Error()))
+			return
+		case res != nil:
+			w.Header().Set(idempotentReplayedHeader, "true")
+			if res.ContentType != "" {
+				w.Header().Set("Content-Type", res.ContentType)
+			}
+			w.WriteHeader(res.Status)
+			w.Write(res.Body)
+			return
+		}
+
+		recorder := &bodyRecorder{statusRecorder: statusRecorder{ResponseWriter: w, status: http.StatusOK}}
+		next.ServeHTTP(recorder, r)
+
+		// The client may be gone already, it is the one retrying then
+		ctx := context.WithoutCancel(r.Context())
+		if recorder.status >= http.StatusInternalServerError {
+			err = s.providerIdempotency.Release(ctx, key, fingerprint)
+		} else {
+			contentType := w.Header().Get("Content-Type")
+			if contentType == "" && recorder.body.Len() > 0 {
+				contentType = http.DetectContentType(recorder.body.Bytes())
+			}
+			err = s.providerIdempotency.Complete(ctx, key, fingerprint, idempotency.Response{
+				Status:      recorder.status,
+				ContentType: contentType,
+				Body:        recorder.body.Bytes(),
+			})
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to store the idempotency key", "error", err)
+		}
+	})
+}
+
+// requestFingerprint tells requests sent with the same key apart
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", r.Method, r.URL.RequestURI())
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bodyRecorder keeps a copy of the response body besides its status
+type bodyRecorder struct {
+	statusRecorder
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(body []byte) (int, error) {
+	r.body.Write(body)
+	return r.statusRecorder.Write(body)
+}
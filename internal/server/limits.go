@@ -0,0 +1,77 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/hse-telescope/core/internal/config"
+)
+
+// unlimitedRoutes are left out of rate limiting, probes and scrapes must get
+// through however busy the instance is
+var unlimitedRoutes = []string{"/healthz", "/readyz", "/metrics"}
+
+// SetLimits applies the limits to the next requests, the buckets of the rate
+// limited clients are kept
+func (s *Server) SetLimits(limits config.Limits) {
+	limits = limits.WithDefaults()
+	s.limits.Store(&limits)
+	s.limiter.SetConfig(limits.RateLimit)
+}
+
+// limitsMiddleware rate limits clients and reads the body up to the largest
+// size accepted, so handlers never see a truncated one
+func (s *Server) limitsMiddleware(next http.Handler) http.Handler {
+	unlimited := make(map[string]bool, len(unlimitedRoutes))
+	for _, route := range unlimitedRoutes {
+		unlimited[route] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits := s.limits.Load()
+
+		if !unlimited[r.URL.Path] {
+			ok, retryAfter := s.limiter.Allow(limits.RateLimit.Client(r))
+			if !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				http.Error(w, "Too many requests", http.StatusTooManyRequests)
+				return
+			}
+		}
+
+		if r.ContentLength > limits.MaxBodyBytes {
+			http.Error(w, fmt.Sprintf("Body is larger than %d bytes", limits.MaxBodyBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if r.Body != nil && r.Body != http.NoBody {
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limits.MaxBodyBytes))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, fmt.Sprintf("Body is larger than %d bytes", limits.MaxBodyBytes), http.StatusRequestEntityTooLarge)
+				return
+			}
+			if err != nil {
+				http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkBatch rejects bulk requests carrying more items than the limit
+func (s *Server) checkBatch(w http.ResponseWriter, items int) bool {
+	limit := s.limits.Load().MaxBatchItems
+	if items > limit {
+		http.Error(w, fmt.Sprintf("At most %d items are accepted at once, got %d", limit, items), http.StatusRequestEntityTooLarge)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package server_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/config"
+	"github.com/hse-telescope/core/internal/ratelimit"
+)
+
+func serve(s http.Handler, method string, path string, body io.Reader, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, body)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit(t *testing.T) {
+	s := newServer(newFake())
+	s.SetLimits(config.Limits{RateLimit: ratelimit.Config{Rate: 0.5, Burst: 2}})
+
+	for i := range 2 {
+		rec := serve(s, http.MethodGet, "/projects", nil, "192.0.2.1:4711")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d of the burst: status %d: %s", i+1, rec.Code, rec.Body)
+		}
+	}
+	rec := serve(s, http.MethodGet, "/projects", nil, "192.0.2.1:4712")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("request over the burst: status %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	// A token takes 2s at half a request per second
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Fatalf("Retry-After %q, want 2", got)
+	}
+
+	// Other clients and the probes get through
+	rec = serve(s, http.MethodGet, "/projects", nil, "192.0.2.2:4711")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("another client: status %d: %s", rec.Code, rec.Body)
+	}
+	for _, path := range []string{"/healthz", "/readyz", "/metrics"} {
+		rec = serve(s, http.MethodGet, path, nil, "192.0.2.1:4711")
+		if rec.Code == http.StatusTooManyRequests {
+			t.Fatalf("%s is rate limited", path)
+		}
+	}
+
+	// Turning rate limiting off applies to the next request
+	s.SetLimits(config.Limits{})
+	rec = serve(s, http.MethodGet, "/projects", nil, "192.0.2.1:4711")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("after rate limiting is off: status %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestBodyLimitWithoutContentLength(t *testing.T) {
+	s := newServer(newFake())
+	s.SetLimits(config.Limits{MaxBodyBytes: 16})
+
+	// A reader of unknown length is sent chunked
+	body := io.MultiReader(strings.NewReader(`{"name":"`), strings.NewReader(strings.Repeat("a", 32)+`"}`))
+	rec := serve(s, http.MethodPost, "/projects", body, "192.0.2.1:4711")
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status %d, want %d: %s", rec.Code, http.StatusRequestEntityTooLarge, rec.Body)
+	}
+}
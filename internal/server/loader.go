@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"sync"
+)
+
+// batchLoader collects the parent IDs requested while one level of a GraphQL
+// query is resolved and fetches their children in a single call. Load only
+// registers the ID and returns a thunk, the executor runs the thunks after
+// the whole level was walked, so the first of them fetches every ID
+// registered so far.
+type batchLoader[V any] struct {
+	mu      sync.Mutex
+	fetch   func(ctx context.Context, ids []int) ([]V, error)
+	parent  func(V) int
+	pending []int
+	loaded  map[int]bool
+	results map[int][]V
+	errs    map[int]error
+}
+
+func newBatchLoader[V any](fetch func(ctx context.Context, ids []int) ([]V, error), parent func(V) int) *batchLoader[V] {
+	return &batchLoader[V]{
+		fetch:   fetch,
+		parent:  parent,
+		loaded:  make(map[int]bool),
+		results: make(map[int][]V),
+		errs:    make(map[int]error),
+	}
+}
+
+func (l *batchLoader[V]) Load(ctx context.Context, id int) func() (any, error) {
+	l.mu.Lock()
+	if _, ok := l.loaded[id]; !ok {
+		l.loaded[id] = false
+		l.pending = append(l.pending, id)
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if !l.loaded[id] {
+			l.flush(ctx)
+		}
+		if err := l.errs[id]; err != nil {
+			return nil, err
+		}
+		if res, ok := l.results[id]; ok {
+			return res, nil
+		}
+		return make([]V, 0), nil
+	}
+}
+
+func (l *batchLoader[V]) flush(ctx context.Context) {
+	ids := l.pending
+	l.pending = nil
+
+	items, err := l.fetch(ctx, ids)
+	for _, id := range ids {
+		l.loaded[id] = true
+		if err != nil {
+			l.errs[id] = err
+		}
+	}
+	if err != nil {
+		return
+	}
+	for _, item := range items {
+		id := l.parent(item)
+		l.results[id] = append(l.results[id], item)
+	}
+}
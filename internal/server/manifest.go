@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/olegdayo/omniconv"
+	"gopkg.in/yaml.v3"
+
+	"github.com/hse-telescope/core/internal/providers/manifest"
+)
+
+// Manifest is the declared state of a project, accepted as JSON or YAML.
+// Graphs are matched by name, services by name within their graph and
+// relations by name and endpoints.
+type Manifest struct {
+	Graphs []ManifestGraph `json:"graphs" yaml:"graphs"`
+}
+
+type ManifestGraph struct {
+	Name      string             `json:"name" yaml:"name"`
+	Services  []ManifestService  `json:"services" yaml:"services"`
+	Relations []ManifestRelation `json:"relations" yaml:"relations"`
+}
+
+type ManifestService struct {
+	Name        string            `json:"name" yaml:"name"`
+	Description string            `json:"description" yaml:"description"`
+	X           float32           `json:"x" yaml:"x"`
+	Y           float32           `json:"y" yaml:"y"`
+	Kind        string            `json:"kind" yaml:"kind"`
+	OwnerTeam   string            `json:"owner_team" yaml:"owner_team"`
+	Tags        []string          `json:"tags" yaml:"tags"`
+	Attributes  map[string]string `json:"attributes" yaml:"attributes"`
+	Links       ServiceLinks      `json:"links" yaml:"links"`
+}
+
+type ManifestRelation struct {
+	Name              string  `json:"name" yaml:"name"`
+	Description       string  `json:"description" yaml:"description"`
+	From              string  `json:"from" yaml:"from"`
+	To                string  `json:"to" yaml:"to"`
+	Protocol          string  `json:"protocol" yaml:"protocol"`
+	Async             bool    `json:"async" yaml:"async"`
+	Bidirectional     bool    `json:"bidirectional" yaml:"bidirectional"`
+	Criticality       string  `json:"criticality" yaml:"criticality"`
+	ExpectedRPS       float64 `json:"expected_rps" yaml:"expected_rps"`
+	ExpectedLatencyMS float64 `json:"expected_latency_ms" yaml:"expected_latency_ms"`
+}
+
+type PlanChange struct {
+	Action string   `json:"action"`
+	Type   string   `json:"type"`
+	Graph  string   `json:"graph"`
+	Key    string   `json:"key"`
+	Fields []string `json:"fields,omitempty"`
+}
+
+type Plan struct {
+	Changes []PlanChange `json:"changes"`
+}
+
+func ServerManifest2ProviderManifest(man Manifest) manifest.Manifest {
+	return manifest.Manifest{
+		Graphs: omniconv.ConvertSlice(man.Graphs, func(gr ManifestGraph) manifest.Graph {
+			return manifest.Graph{
+				Name: gr.Name,
+				Services: omniconv.ConvertSlice(gr.Services, func(serv ManifestService) manifest.Service {
+					return manifest.Service{
+						Name:        serv.Name,
+						Description: serv.Description,
+						X:           serv.X,
+						Y:           serv.Y,
+						Kind:        serv.Kind,
+						OwnerTeam:   serv.OwnerTeam,
+						Tags:        serv.Tags,
+						Attributes:  serv.Attributes,
+						DocsURL:     serv.Links.Documentation,
+						RunbookURL:  serv.Links.Runbook,
+						RepoURL:     serv.Links.Repository,
+					}
+				}),
+				Relations: omniconv.ConvertSlice(gr.Relations, func(rel ManifestRelation) manifest.Relation {
+					return manifest.Relation{
+						Name:              rel.Name,
+						Description:       rel.Description,
+						From:              rel.From,
+						To:                rel.To,
+						Protocol:          rel.Protocol,
+						Async:             rel.Async,
+						Bidirectional:     rel.Bidirectional,
+						Criticality:       rel.Criticality,
+						ExpectedRPS:       rel.ExpectedRPS,
+						ExpectedLatencyMS: rel.ExpectedLatencyMS,
+					}
+				}),
+			}
+		}),
+	}
+}
+
+func ProviderPlan2ServerPlan(plan manifest.Plan) Plan {
+	return Plan{
+		Changes: omniconv.ConvertSlice(plan.Changes, func(change manifest.Change) PlanChange {
+			return PlanChange{
+				Action: change.Action,
+				Type:   change.Type,
+				Graph:  change.Graph,
+				Key:    change.Key,
+				Fields: change.Fields,
+			}
+		}),
+	}
+}
+
+// items counts the entities declared by the manifest
+func (man Manifest) items() int {
+	items := len(man.Graphs)
+	for _, gr := range man.Graphs {
+		items += len(gr.Services) + len(gr.Relations)
+	}
+	return items
+}
+
+// decodeManifest reads a JSON or YAML manifest, JSON documents are valid
+// YAML so a single decoder handles both
+func decodeManifest(r io.Reader) (Manifest, error) {
+	var man Manifest
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	err := decoder.Decode(&man)
+	if errors.Is(err, io.EOF) {
+		return Manifest{}, errors.New("manifest is empty")
+	}
+	return man, err
+}
+
+func (s *Server) planProjectHandler(w http.ResponseWriter, r *http.Request) {
+	s.manifestHandler(w, r, s.providerManifest.Plan)
+}
+
+func (s *Server) applyProjectHandler(w http.ResponseWriter, r *http.Request) {
+	s.manifestHandler(w, r, s.providerManifest.Apply)
+}
+
+func (s *Server) manifestHandler(w http.ResponseWriter, r *http.Request, run func(ctx context.Context, project_id int, man manifest.Manifest) (manifest.Plan, error)) {
+	vars := mux.Vars(r)
+	project_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	man, err := decodeManifest(r.Body)
+	if err != nil {
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !s.checkBatch(w, man.items()) {
+		return
+	}
+
+	plan, err := run(r.Context(), project_id, ServerManifest2ProviderManifest(man))
+	if isClientError(err) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// The manifest is fine, the project has to be fixed before it can be matched
+	if errors.Is(err, manifest.ErrAmbiguousKey) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(ProviderPlan2ServerPlan(plan))
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by route template and status code.",
+	}, []string{"method", "route", "code"})
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "core",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+	httpInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "HTTP requests being served.",
+	})
+)
+
+// unmatchedRoute labels the requests no route matches: 404 and 405
+// responses and the CORS preflights answered ahead of the router
+const unmatchedRoute = "unmatched"
+
+type routeKey struct{}
+
+// metricsMiddleware counts and times requests by the template of the matched
+// route, so /graphs/1 and /graphs/2 share their series. It wraps the router
+// to see the requests the router rejects, routeMiddleware reports the
+// template from within it.
+func metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := unmatchedRoute
+		r = r.WithContext(context.WithValue(r.Context(), routeKey{}, &route))
+
+		httpInFlight.Inc()
+		defer httpInFlight.Dec()
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		httpDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+		httpRequests.WithLabelValues(r.Method, route, strconv.Itoa(recorder.status)).Inc()
+	})
+}
+
+// routeMiddleware hands the template of the matched route to
+// metricsMiddleware
+func routeMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, ok := r.Context().Value(routeKey{}).(*string)
+		if current := mux.CurrentRoute(r); ok && current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				*route = template
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(body []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(body)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
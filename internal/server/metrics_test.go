@@ -0,0 +1,66 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// requests returns how many requests are counted with the labels so far
+func requests(t *testing.T, method string, route string, code string) float64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"method": method, "route": route, "code": code}
+	for _, family := range families {
+		if family.GetName() != "core_http_requests_total" {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if want[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			return metric.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
+
+func TestMetricsLabelRoutes(t *testing.T) {
+	conf := corsConfig(config.CORS{AllowedOrigins: []string{"https://app.example"}})
+	preflight := httptest.NewRequest(http.MethodOptions, "/projects/1", nil)
+	preflight.Header.Set("Origin", "https://app.example")
+	preflight.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+
+	for _, c := range []struct {
+		name  string
+		req   *http.Request
+		route string
+		code  string
+	}{
+		{name: "Matched", req: httptest.NewRequest(http.MethodGet, "/projects/1/graphs", nil), route: "/projects/{id}/graphs", code: "200"},
+		{name: "NotFound", req: httptest.NewRequest(http.MethodGet, "/unknown", nil), route: "unmatched", code: "404"},
+		{name: "MethodNotAllowed", req: httptest.NewRequest(http.MethodPut, "/projects", nil), route: "unmatched", code: "405"},
+		{name: "Preflight", req: preflight, route: "unmatched", code: "204"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			before := requests(t, c.req.Method, c.route, c.code)
+			rec, _ := serveWith(conf, c.req)
+			if got := strconv.Itoa(rec.Code); got != c.code {
+				t.Fatalf("status %s, want %s", got, c.code)
+			}
+			if got := requests(t, c.req.Method, c.route, c.code) - before; got != 1 {
+				t.Fatalf("%s %s is counted %v times under %q", c.req.Method, c.req.URL.Path, got, c.route)
+			}
+		})
+	}
+}
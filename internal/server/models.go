@@ -1,10 +1,16 @@
 package server
 
 import (
+	"time"
+
+	"github.com/hse-telescope/core/internal/providers/catalog"
 	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/providers/group"
 	"github.com/hse-telescope/core/internal/providers/project"
 	"github.com/hse-telescope/core/internal/providers/relation"
 	"github.com/hse-telescope/core/internal/providers/service"
+	"github.com/hse-telescope/core/internal/providers/webhook"
+	"github.com/olegdayo/omniconv"
 )
 
 type Project struct {
@@ -18,22 +24,109 @@ type Graph struct {
 	Name      string `json:"name"`
 }
 
+type ServiceLinks struct {
+	Documentation string `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	Runbook       string `json:"runbook,omitempty" yaml:"runbook,omitempty"`
+	Repository    string `json:"repository,omitempty" yaml:"repository,omitempty"`
+}
+
 type Service struct {
+	ID          int               `json:"id"`
+	GraphID     int               `json:"graph_id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	X           float32           `json:"x"`
+	Y           float32           `json:"y"`
+	GroupID     *int              `json:"group_id"`
+	CatalogID   *int              `json:"catalog_id"`
+	Kind        string            `json:"kind"`
+	OwnerTeam   string            `json:"owner_team"`
+	Tags        []string          `json:"tags"`
+	Attributes  map[string]string `json:"attributes"`
+	Links       ServiceLinks      `json:"links"`
+}
+
+type Relation struct {
+	ID                int     `json:"id"`
+	GraphID           int     `json:"graph_id"`
+	Name              string  `json:"name"`
+	Description       string  `json:"description"`
+	FromService       int     `json:"from_service"`
+	ToService         int     `json:"to_service"`
+	Protocol          string  `json:"protocol"`
+	Async             bool    `json:"async"`
+	Bidirectional     bool    `json:"bidirectional"`
+	Criticality       string  `json:"criticality"`
+	ExpectedRPS       float64 `json:"expected_rps"`
+	ExpectedLatencyMS float64 `json:"expected_latency_ms"`
+}
+
+type CatalogService struct {
+	ID          int               `json:"id"`
+	ProjectID   int               `json:"project_id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Kind        string            `json:"kind"`
+	OwnerTeam   string            `json:"owner_team"`
+	Tags        []string          `json:"tags"`
+	Attributes  map[string]string `json:"attributes"`
+	Links       ServiceLinks      `json:"links"`
+}
+
+type Group struct {
 	ID          int     `json:"id"`
 	GraphID     int     `json:"graph_id"`
+	ParentID    *int    `json:"parent_id"`
 	Name        string  `json:"name"`
 	Description string  `json:"description"`
+	Kind        string  `json:"kind"`
 	X           float32 `json:"x"`
 	Y           float32 `json:"y"`
+	Width       float32 `json:"width"`
+	Height      float32 `json:"height"`
 }
 
-type Relation struct {
-	ID          int    `json:"id"`
-	GraphID     int    `json:"graph_id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	FromService int    `json:"from_service"`
-	ToService   int    `json:"to_service"`
+type CollapsedNode struct {
+	Key      string  `json:"key"`
+	Type     string  `json:"type"`
+	ID       int     `json:"id"`
+	Name     string  `json:"name"`
+	X        float32 `json:"x"`
+	Y        float32 `json:"y"`
+	Width    float32 `json:"width,omitempty"`
+	Height   float32 `json:"height,omitempty"`
+	Services int     `json:"services,omitempty"`
+}
+
+type CollapsedEdge struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Relations []int  `json:"relations"`
+}
+
+type CollapsedGraph struct {
+	Nodes []CollapsedNode `json:"nodes"`
+	Edges []CollapsedEdge `json:"edges"`
+}
+
+type Webhook struct {
+	ID        int      `json:"id"`
+	ProjectID int      `json:"project_id"`
+	URL       string   `json:"url"`
+	Secret    string   `json:"secret,omitempty"`
+	Events    []string `json:"events"`
+}
+
+type WebhookDelivery struct {
+	ID         int       `json:"id"`
+	WebhookID  int       `json:"webhook_id"`
+	EventID    int       `json:"event_id"`
+	Event      string    `json:"event"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 func ServerProject2ProviderProject(pr Project) project.Project {
@@ -74,10 +167,27 @@ func ServerService2ProviderService(serv Service) service.Service {
 		Description: serv.Description,
 		X:           serv.X,
 		Y:           serv.Y,
+		GroupID:     serv.GroupID,
+		CatalogID:   serv.CatalogID,
+		Kind:        serv.Kind,
+		OwnerTeam:   serv.OwnerTeam,
+		Tags:        serv.Tags,
+		Attributes:  serv.Attributes,
+		DocsURL:     serv.Links.Documentation,
+		RunbookURL:  serv.Links.Runbook,
+		RepoURL:     serv.Links.Repository,
 	}
 }
 
 func ProviderService2ServerService(serv service.Service) Service {
+	tags := serv.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	attributes := serv.Attributes
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
 	return Service{
 		ID:          serv.ID,
 		GraphID:     serv.GraphID,
@@ -85,27 +195,185 @@ func ProviderService2ServerService(serv service.Service) Service {
 		Description: serv.Description,
 		X:           serv.X,
 		Y:           serv.Y,
+		GroupID:     serv.GroupID,
+		CatalogID:   serv.CatalogID,
+		Kind:        serv.Kind,
+		OwnerTeam:   serv.OwnerTeam,
+		Tags:        tags,
+		Attributes:  attributes,
+		Links: ServiceLinks{
+			Documentation: serv.DocsURL,
+			Runbook:       serv.RunbookURL,
+			Repository:    serv.RepoURL,
+		},
 	}
 }
 
 func ServerRelation2ProviderRelation(rel Relation) relation.Relation {
 	return relation.Relation{
-		ID:          rel.ID,
-		GraphID:     rel.GraphID,
-		Name:        rel.Name,
-		Description: rel.Description,
-		FromService: rel.FromService,
-		ToService:   rel.ToService,
+		ID:                rel.ID,
+		GraphID:           rel.GraphID,
+		Name:              rel.Name,
+		Description:       rel.Description,
+		FromService:       rel.FromService,
+		ToService:         rel.ToService,
+		Protocol:          rel.Protocol,
+		Async:             rel.Async,
+		Bidirectional:     rel.Bidirectional,
+		Criticality:       rel.Criticality,
+		ExpectedRPS:       rel.ExpectedRPS,
+		ExpectedLatencyMS: rel.ExpectedLatencyMS,
 	}
 }
 
 func ProviderRelation2ServerRelation(rel relation.Relation) Relation {
 	return Relation{
-		ID:          rel.ID,
-		GraphID:     rel.GraphID,
-		Name:        rel.Name,
-		Description: rel.Description,
-		FromService: rel.FromService,
-		ToService:   rel.ToService,
+		ID:                rel.ID,
+		GraphID:           rel.GraphID,
+		Name:              rel.Name,
+		Description:       rel.Description,
+		FromService:       rel.FromService,
+		ToService:         rel.ToService,
+		Protocol:          rel.Protocol,
+		Async:             rel.Async,
+		Bidirectional:     rel.Bidirectional,
+		Criticality:       rel.Criticality,
+		ExpectedRPS:       rel.ExpectedRPS,
+		ExpectedLatencyMS: rel.ExpectedLatencyMS,
+	}
+}
+
+func ServerWebhook2ProviderWebhook(wh Webhook) webhook.Webhook {
+	return webhook.Webhook{
+		ID:        wh.ID,
+		ProjectID: wh.ProjectID,
+		URL:       wh.URL,
+		Secret:    wh.Secret,
+		Events:    wh.Events,
+	}
+}
+
+// ProviderWebhook2ServerWebhook leaves the secret out: it is write-only
+func ProviderWebhook2ServerWebhook(wh webhook.Webhook) Webhook {
+	events := wh.Events
+	if events == nil {
+		events = []string{}
+	}
+	return Webhook{
+		ID:        wh.ID,
+		ProjectID: wh.ProjectID,
+		URL:       wh.URL,
+		Events:    events,
+	}
+}
+
+func ProviderDelivery2ServerDelivery(del webhook.Delivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:         del.ID,
+		WebhookID:  del.WebhookID,
+		EventID:    del.EventID,
+		Event:      del.Event,
+		Attempt:    del.Attempt,
+		StatusCode: del.StatusCode,
+		Error:      del.Error,
+		DurationMS: del.Duration.Milliseconds(),
+		CreatedAt:  del.CreatedAt,
+	}
+}
+
+func ServerCatalogService2ProviderCatalogService(serv CatalogService) catalog.Service {
+	return catalog.Service{
+		ID:          serv.ID,
+		ProjectID:   serv.ProjectID,
+		Name:        serv.Name,
+		Description: serv.Description,
+		Kind:        serv.Kind,
+		OwnerTeam:   serv.OwnerTeam,
+		Tags:        serv.Tags,
+		Attributes:  serv.Attributes,
+		DocsURL:     serv.Links.Documentation,
+		RunbookURL:  serv.Links.Runbook,
+		RepoURL:     serv.Links.Repository,
+	}
+}
+
+func ProviderCatalogService2ServerCatalogService(serv catalog.Service) CatalogService {
+	tags := serv.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	attributes := serv.Attributes
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	return CatalogService{
+		ID:          serv.ID,
+		ProjectID:   serv.ProjectID,
+		Name:        serv.Name,
+		Description: serv.Description,
+		Kind:        serv.Kind,
+		OwnerTeam:   serv.OwnerTeam,
+		Tags:        tags,
+		Attributes:  attributes,
+		Links: ServiceLinks{
+			Documentation: serv.DocsURL,
+			Runbook:       serv.RunbookURL,
+			Repository:    serv.RepoURL,
+		},
+	}
+}
+
+func ServerGroup2ProviderGroup(gr Group) group.Group {
+	return group.Group{
+		ID:          gr.ID,
+		GraphID:     gr.GraphID,
+		ParentID:    gr.ParentID,
+		Name:        gr.Name,
+		Description: gr.Description,
+		Kind:        gr.Kind,
+		X:           gr.X,
+		Y:           gr.Y,
+		Width:       gr.Width,
+		Height:      gr.Height,
+	}
+}
+
+func ProviderGroup2ServerGroup(gr group.Group) Group {
+	return Group{
+		ID:          gr.ID,
+		GraphID:     gr.GraphID,
+		ParentID:    gr.ParentID,
+		Name:        gr.Name,
+		Description: gr.Description,
+		Kind:        gr.Kind,
+		X:           gr.X,
+		Y:           gr.Y,
+		Width:       gr.Width,
+		Height:      gr.Height,
+	}
+}
+
+func ProviderCollapsedGraph2ServerCollapsedGraph(gr group.CollapsedGraph) CollapsedGraph {
+	return CollapsedGraph{
+		Nodes: omniconv.ConvertSlice(gr.Nodes, func(node group.Node) CollapsedNode {
+			return CollapsedNode{
+				Key:      node.Key,
+				Type:     node.Type,
+				ID:       node.ID,
+				Name:     node.Name,
+				X:        node.X,
+				Y:        node.Y,
+				Width:    node.Width,
+				Height:   node.Height,
+				Services: node.Services,
+			}
+		}),
+		Edges: omniconv.ConvertSlice(gr.Edges, func(edge group.Edge) CollapsedEdge {
+			return CollapsedEdge{
+				From:      edge.From,
+				To:        edge.To,
+				Relations: edge.Relations,
+			}
+		}),
 	}
 }
@@ -0,0 +1,418 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hse-telescope/core/internal/health"
+)
+
+// apiOperation documents a route of setRouter. Request and Response hold a
+// value of the body type, schemas are derived from their JSON tags, so they
+// follow the models without being maintained by hand.
+type apiOperation struct {
+	Method   string
+	Path     string
+	Summary  string
+	Tag      string
+	Query    []apiParameter
+	Request  any
+	Status   int
+	Response any
+	// ContentType of the response, application/json when empty
+	ContentType string
+	// Errors describes responses besides the common ones, the descriptions
+	// of the common statuses are joined
+	Errors map[int]string
+	// ErrorResponse holds a value of the body of the Errors responses, they
+	// are plain text when it is nil
+	ErrorResponse any
+}
+
+type apiParameter struct {
+	Name        string
+	Description string
+	Schema      map[string]any
+}
+
+var (
+	integerSchema = map[string]any{"type": "integer"}
+	stringSchema  = map[string]any{"type": "string"}
+)
+
+// jsonPatchOperationSchema documents an operation of RFC 6902
+var jsonPatchOperationSchema = map[string]any{
+	"type":     "object",
+	"required": []string{"op", "path"},
+	"properties": map[string]any{
+		"op":    map[string]any{"type": "string", "enum": []string{"add", "remove", "replace", "move", "copy", "test"}},
+		"path":  map[string]any{"type": "string", "description": "JSON Pointer to the target member"},
+		"from":  map[string]any{"type": "string", "description": "JSON Pointer to the source member of move and copy"},
+		"value": map[string]any{"description": "Value of add, replace and test"},
+	},
+}
+
+func arraySchema(items map[string]any) map[string]any {
+	return map[string]any{"type": "array", "items": items}
+}
+
+// undocumentedRoutes are not part of the API
+var undocumentedRoutes = []string{"/metrics"}
+
+var apiOperations = []apiOperation{
+	{Method: http.MethodGet, Path: "/healthz", Summary: "Whether the process is alive, with the result of every liveness check", Tag: "meta", Status: http.StatusOK, Response: health.Report{}, Errors: map[int]string{http.StatusServiceUnavailable: "A liveness check failed"}, ErrorResponse: health.Report{}},
+	{Method: http.MethodGet, Path: "/readyz", Summary: "Whether the instance takes traffic, with the result of every readiness check", Tag: "meta", Status: http.StatusOK, Response: health.Report{}, Errors: map[int]string{http.StatusServiceUnavailable: "The instance is shutting down or a dependency is unavailable"}, ErrorResponse: health.Report{}},
+	{Method: http.MethodGet, Path: "/openapi.json", Summary: "OpenAPI document of this API", Tag: "meta", Status: http.StatusOK, Response: map[string]any{}},
+	{Method: http.MethodGet, Path: "/graphql", Summary: "Run a GraphQL query", Tag: "graphql", Query: []apiParameter{
+		{Name: "query", Description: "GraphQL query", Schema: stringSchema},
+		{Name: "operationName", Description: "Operation to run when the query has several", Schema: stringSchema},
+		{Name: "variables", Description: "JSON object of query variables", Schema: stringSchema},
+	}, Status: http.StatusOK, Response: graphQLResponse{}},
+	{Method: http.MethodPost, Path: "/graphql", Summary: "Run a GraphQL query", Tag: "graphql", Request: graphQLRequest{}, Status: http.StatusOK, Response: graphQLResponse{}},
+
+	{Method: http.MethodPost, Path: "/projects", Summary: "Create a project", Tag: "projects", Request: Project{}, Status: http.StatusOK, Response: Project{}},
+	{Method: http.MethodGet, Path: "/projects", Summary: "List projects", Tag: "projects", Status: http.StatusOK, Response: []Project{}},
+	{Method: http.MethodDelete, Path: "/projects/{id}", Summary: "Delete a project with its graphs", Tag: "projects", Status: http.StatusOK},
+	{Method: http.MethodPut, Path: "/projects/{id}", Summary: "Update a project", Tag: "projects", Request: Project{}, Status: http.StatusOK},
+	{Method: http.MethodPatch, Path: "/projects/{id}", Summary: "Change only the given fields of a project, with a JSON Merge Patch or a JSON Patch", Tag: "projects", Request: Project{}, Status: http.StatusOK, Response: Project{}},
+	{Method: http.MethodGet, Path: "/projects/{id}/graphs", Summary: "List graphs of a project", Tag: "graphs", Status: http.StatusOK, Response: []Graph{}},
+	{Method: http.MethodPost, Path: "/projects/{id}/catalog", Summary: "Add a service to the project catalog", Tag: "catalog", Request: CatalogService{}, Status: http.StatusCreated, Response: CatalogService{}},
+	{Method: http.MethodGet, Path: "/projects/{id}/catalog", Summary: "List the project catalog", Tag: "catalog", Status: http.StatusOK, Response: []CatalogService{}},
+	{Method: http.MethodPost, Path: "/projects/{id}/webhooks", Summary: "Register a project webhook", Tag: "webhooks", Request: Webhook{}, Status: http.StatusCreated, Response: Webhook{}},
+	{Method: http.MethodGet, Path: "/projects/{id}/webhooks", Summary: "List project webhooks", Tag: "webhooks", Status: http.StatusOK, Response: []Webhook{}},
+	{Method: http.MethodPost, Path: "/projects/{id}/plan", Summary: "List the changes applying a manifest would make, JSON or YAML", Tag: "manifests", Request: Manifest{}, Status: http.StatusOK, Response: Plan{}, Errors: map[int]string{http.StatusConflict: "Existing graphs, services or relations share a name"}},
+	{Method: http.MethodPost, Path: "/projects/{id}/apply", Summary: "Bring the project to the state of a manifest in one transaction", Tag: "manifests", Request: Manifest{}, Status: http.StatusOK, Response: Plan{}, Errors: map[int]string{http.StatusConflict: "Existing graphs, services or relations share a name"}},
+
+	{Method: http.MethodPost, Path: "/graphs", Summary: "Create a graph", Tag: "graphs", Request: Graph{}, Status: http.StatusOK, Response: Graph{}},
+	{Method: http.MethodGet, Path: "/graphs/{id}", Summary: "Get a graph", Tag: "graphs", Status: http.StatusOK, Response: Graph{}},
+	{Method: http.MethodPut, Path: "/graphs/{id}", Summary: "Update a graph", Tag: "graphs", Request: Graph{}, Status: http.StatusOK},
+	{Method: http.MethodPatch, Path: "/graphs/{id}", Summary: "Change only the given fields of a graph, with a JSON Merge Patch or a JSON Patch", Tag: "graphs", Request: Graph{}, Status: http.StatusOK, Response: Graph{}},
+	{Method: http.MethodDelete, Path: "/graphs/{id}", Summary: "Delete a graph", Tag: "graphs", Status: http.StatusOK},
+	{Method: http.MethodPut, Path: "/graphs/{id}/services", Summary: "Update services of a graph", Tag: "services", Request: []Service{}, Status: http.StatusOK},
+	{Method: http.MethodPut, Path: "/graphs/{id}/relations", Summary: "Update relations of a graph", Tag: "relations", Request: []Relation{}, Status: http.StatusOK},
+	{Method: http.MethodGet, Path: "/graphs/{id}/services", Summary: "List services of a graph", Tag: "services", Query: []apiParameter{
+		{Name: "kind", Description: "Service kind", Schema: stringSchema},
+		{Name: "owner_team", Description: "Owner team", Schema: stringSchema},
+		{Name: "tag", Description: "Tag every returned service has, may be repeated", Schema: arraySchema(stringSchema)},
+		{Name: "attr", Description: "Attribute as key:value every returned service has, may be repeated", Schema: arraySchema(stringSchema)},
+	}, Status: http.StatusOK, Response: []Service{}},
+	{Method: http.MethodGet, Path: "/graphs/{id}/relations", Summary: "List relations of a graph", Tag: "relations", Status: http.StatusOK, Response: []Relation{}},
+	{Method: http.MethodPost, Path: "/graphs/{id}/services", Summary: "Create services in a graph", Tag: "services", Request: []Service{}, Status: http.StatusCreated, Response: []int{}},
+	{Method: http.MethodPost, Path: "/graphs/{id}/relations", Summary: "Create relations in a graph", Tag: "relations", Request: []Relation{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/graphs/{id}/groups", Summary: "List groups of a graph", Tag: "groups", Status: http.StatusOK, Response: []Group{}},
+	{Method: http.MethodGet, Path: "/graphs/{id}/collapsed", Summary: "Render a graph with groups collapsed", Tag: "groups", Query: []apiParameter{
+		{Name: "group", Description: "Group to collapse, may be repeated, top level groups are collapsed when omitted", Schema: arraySchema(integerSchema)},
+	}, Status: http.StatusOK, Response: CollapsedGraph{}},
+
+	{Method: http.MethodPost, Path: "/services", Summary: "Create a service", Tag: "services", Request: Service{}, Status: http.StatusCreated, Response: Service{}},
+	{Method: http.MethodPut, Path: "/services/{id}", Summary: "Update a service", Tag: "services", Request: Service{}, Status: http.StatusOK},
+	{Method: http.MethodPatch, Path: "/services/{id}", Summary: "Change only the given fields of a service, with a JSON Merge Patch or a JSON Patch", Tag: "services", Request: Service{}, Status: http.StatusOK, Response: Service{}},
+	{Method: http.MethodDelete, Path: "/services/{id}", Summary: "Delete a service", Tag: "services", Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/services/{id}", Summary: "Get a service", Tag: "services", Status: http.StatusOK, Response: Service{}},
+
+	{Method: http.MethodPost, Path: "/relations", Summary: "Create a relation", Tag: "relations", Request: Relation{}, Status: http.StatusCreated, Response: Relation{}},
+	{Method: http.MethodPut, Path: "/relations/{id}", Summary: "Update a relation", Tag: "relations", Request: Relation{}, Status: http.StatusCreated},
+	{Method: http.MethodPatch, Path: "/relations/{id}", Summary: "Change only the given fields of a relation, with a JSON Merge Patch or a JSON Patch", Tag: "relations", Request: Relation{}, Status: http.StatusOK, Response: Relation{}},
+	{Method: http.MethodDelete, Path: "/relations/{id}", Summary: "Delete a relation", Tag: "relations", Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/relations/{id}", Summary: "Get a relation", Tag: "relations", Status: http.StatusOK, Response: Relation{}},
+
+	{Method: http.MethodPut, Path: "/catalog/{id}", Summary: "Update a catalog service and the graph services referencing it", Tag: "catalog", Request: CatalogService{}, Status: http.StatusOK},
+	{Method: http.MethodDelete, Path: "/catalog/{id}", Summary: "Delete a catalog service", Tag: "catalog", Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/catalog/{id}", Summary: "Get a catalog service", Tag: "catalog", Status: http.StatusOK, Response: CatalogService{}},
+	{Method: http.MethodGet, Path: "/catalog/{id}/graphs", Summary: "List graphs using a catalog service", Tag: "catalog", Status: http.StatusOK, Response: []Graph{}},
+
+	{Method: http.MethodPost, Path: "/groups", Summary: "Create a group", Tag: "groups", Request: Group{}, Status: http.StatusCreated, Response: Group{}},
+	{Method: http.MethodPut, Path: "/groups/{id}", Summary: "Update a group", Tag: "groups", Request: Group{}, Status: http.StatusOK},
+	{Method: http.MethodDelete, Path: "/groups/{id}", Summary: "Delete a group", Tag: "groups", Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/groups/{id}", Summary: "Get a group", Tag: "groups", Status: http.StatusOK, Response: Group{}},
+
+	{Method: http.MethodGet, Path: "/webhooks/{id}", Summary: "Get a webhook", Tag: "webhooks", Status: http.StatusOK, Response: Webhook{}},
+	{Method: http.MethodPut, Path: "/webhooks/{id}", Summary: "Update a webhook, an empty secret keeps the current one", Tag: "webhooks", Request: Webhook{}, Status: http.StatusOK},
+	{Method: http.MethodDelete, Path: "/webhooks/{id}", Summary: "Delete a webhook", Tag: "webhooks", Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/webhooks/{id}/deliveries", Summary: "List delivery attempts of a webhook", Tag: "webhooks", Status: http.StatusOK, Response: []WebhookDelivery{}},
+}
+
+// graphQLResponse documents the result of graphql.Do
+type graphQLResponse struct {
+	Data   map[string]any   `json:"data,omitempty"`
+	Errors []map[string]any `json:"errors,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type openAPIBuilder struct {
+	schemas map[string]any
+}
+
+// schema returns the schema of t, exported structs are put into components
+// and referenced
+func (b *openAPIBuilder) schema(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		res := b.schema(t.Elem())
+		if _, ok := res["$ref"]; ok {
+			return map[string]any{"allOf": []any{res}, "nullable": true}
+		}
+		res["nullable"] = true
+		return res
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Int32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Float32:
+		return map[string]any{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]any{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Interface:
+		return map[string]any{}
+	case reflect.Struct:
+		if t.Name() != "" && isExported(t.Name()) {
+			if _, ok := b.schemas[t.Name()]; !ok {
+				b.schemas[t.Name()] = map[string]any{} // breaks recursion
+				b.schemas[t.Name()] = b.object(t)
+			}
+			return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+		}
+		return b.object(t)
+	}
+	panic(fmt.Sprintf("openapi: unsupported type %s", t))
+}
+
+func (b *openAPIBuilder) object(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = b.schema(field.Type)
+	}
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+func isExported(name string) bool {
+	return name[0] >= 'A' && name[0] <= 'Z'
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// openAPISpec builds the OpenAPI 3 document of apiOperations
+func openAPISpec() map[string]any {
+	b := &openAPIBuilder{schemas: map[string]any{
+		"Error": map[string]any{"type": "string", "description": "Plain text error message"},
+	}}
+	errorResponse := func(description string) map[string]any {
+		return map[string]any{
+			"description": description,
+			"content": map[string]any{
+				"text/plain": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Error"}},
+			},
+		}
+	}
+
+	paths := make(map[string]any)
+	for _, op := range apiOperations {
+		operation := map[string]any{
+			"summary":     op.Summary,
+			"operationId": operationID(op),
+			"tags":        []string{op.Tag},
+		}
+
+		parameters := make([]any, 0)
+		if strings.Contains(op.Path, "{id}") {
+			parameters = append(parameters, map[string]any{
+				"name": "id", "in": "path", "required": true, "schema": integerSchema,
+			})
+		}
+		for _, p := range op.Query {
+			param := map[string]any{"name": p.Name, "in": "query", "description": p.Description, "schema": p.Schema}
+			if p.Schema["type"] == "array" {
+				param["style"] = "form"
+				param["explode"] = true
+			}
+			parameters = append(parameters, param)
+		}
+		if op.Method == http.MethodPost {
+			parameters = append(parameters, map[string]any{
+				"name": idempotencyKeyHeader, "in": "header",
+				"description": "Makes the request safe to retry: a retry with the same key gets the response of the first request, marked by the " + idempotentReplayedHeader + " header",
+				"schema":      map[string]any{"type": "string", "maxLength": maxIdempotencyKeyLength},
+			})
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if op.Request != nil && op.Method == http.MethodPatch {
+			// A merge patch looks like the entity with fewer members
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					mergePatchContentType: map[string]any{"schema": b.schema(reflect.TypeOf(op.Request))},
+					jsonPatchContentType:  map[string]any{"schema": arraySchema(jsonPatchOperationSchema)},
+				},
+			}
+		} else if op.Request != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content":  jsonContent(b.schema(reflect.TypeOf(op.Request))),
+			}
+		}
+
+		success := map[string]any{"description": http.StatusText(op.Status)}
+		if op.Response != nil && op.ContentType != "" {
+			success["content"] = map[string]any{op.ContentType: map[string]any{"schema": b.schema(reflect.TypeOf(op.Response))}}
+		} else if op.Response != nil {
+			success["content"] = jsonContent(b.schema(reflect.TypeOf(op.Response)))
+		}
+		responses := map[string]any{
+			strconv.Itoa(op.Status): success,
+			"500":                   errorResponse("Storage or internal failure"),
+		}
+		if strings.Contains(op.Path, "{id}") || op.Request != nil || len(op.Query) > 0 {
+			responses["400"] = errorResponse("Malformed ID, query or body, or invalid input")
+		}
+		if strings.Contains(op.Path, "{id}") {
+			responses["404"] = errorResponse("Entity of the ID is not found")
+		}
+		if op.Request != nil {
+			responses["413"] = errorResponse("Body or batch larger than the configured limits")
+		}
+		if !slices.Contains(unlimitedRoutes, op.Path) {
+			tooMany := errorResponse("Rate limit of the client exceeded")
+			tooMany["headers"] = map[string]any{
+				"Retry-After": map[string]any{"description": "Seconds until the next request is accepted", "schema": integerSchema},
+			}
+			responses["429"] = tooMany
+		}
+		if op.Method == http.MethodPost {
+			responses["409"] = errorResponse("A request with the same Idempotency-Key is in progress")
+			responses["422"] = errorResponse("Idempotency-Key is already used by a different request")
+		}
+		if op.Method == http.MethodPatch {
+			responses["409"] = errorResponse("A test operation of the JSON Patch failed")
+			unsupported := errorResponse("Content-Type is not a supported patch format")
+			unsupported["headers"] = map[string]any{
+				"Accept-Patch": map[string]any{"description": "Supported patch formats", "schema": stringSchema},
+			}
+			responses["415"] = unsupported
+			responses["422"] = errorResponse("Patch does not apply to the entity, changes its id or leaves an invalid entity")
+		}
+		for status, description := range op.Errors {
+			if common, ok := responses[strconv.Itoa(status)].(map[string]any); ok {
+				description = common["description"].(string) + ", or " + strings.ToLower(description[:1]) + description[1:]
+			}
+			if op.ErrorResponse != nil {
+				responses[strconv.Itoa(status)] = map[string]any{
+					"description": description,
+					"content":     jsonContent(b.schema(reflect.TypeOf(op.ErrorResponse))),
+				}
+				continue
+			}
+			responses[strconv.Itoa(status)] = errorResponse(description)
+		}
+		operation["responses"] = responses
+
+		item, ok := paths[op.Path].(map[string]any)
+		if !ok {
+			item = make(map[string]any)
+			paths[op.Path] = item
+		}
+		item[strings.ToLower(op.Method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Telescope core API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": b.schemas,
+		},
+	}
+}
+
+// operationID turns "GET /graphs/{id}/services" into "getGraphsIdServices"
+func operationID(op apiOperation) string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(op.Method))
+	for _, part := range strings.FieldsFunc(op.Path, func(r rune) bool { return r == '/' || r == '{' || r == '}' || r == '.' }) {
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return sb.String()
+}
+
+// checkOpenAPIRoutes returns an error listing the routes of router missing
+// from apiOperations, the tests keep every route documented
+func checkOpenAPIRoutes(router *mux.Router) error {
+	documented := make(map[string]bool, len(apiOperations))
+	for _, op := range apiOperations {
+		documented[op.Method+" "+op.Path] = true
+	}
+	for _, path := range undocumentedRoutes {
+		documented[path] = true
+	}
+
+	missing := make([]string, 0)
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		if documented[path] {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			missing = append(missing, path)
+			return nil
+		}
+		for _, method := range methods {
+			if !documented[method+" "+path] {
+				missing = append(missing, method+" "+path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("routes missing from the OpenAPI document: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (s *Server) openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(s.openapi)
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestEveryRouteIsDocumented(t *testing.T) {
+	err := checkOpenAPIRoutes(new(Server).setRouter())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUndocumentedRouteIsReported(t *testing.T) {
+	router := new(Server).setRouter()
+	router.HandleFunc("/projects/{id}/archive", func(http.ResponseWriter, *http.Request) {}).Methods(http.MethodPost)
+	err := checkOpenAPIRoutes(router)
+	if err == nil || !strings.Contains(err.Error(), "POST /projects/{id}/archive") {
+		t.Fatalf("missing route is not reported: %v", err)
+	}
+}
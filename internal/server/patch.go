@@ -0,0 +1,276 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hse-telescope/core/internal/jsonpatch"
+	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/providers/project"
+	"github.com/hse-telescope/core/internal/providers/relation"
+	"github.com/hse-telescope/core/internal/providers/service"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// patchFormat applies a patch body and tells the paths the patch writes
+type patchFormat struct {
+	apply func(doc, patch []byte) ([]byte, error)
+	paths func(patch []byte) ([][]string, error)
+}
+
+// patchFormats by content type, plain JSON is taken for a merge patch
+var patchFormats = map[string]patchFormat{
+	mergePatchContentType: {jsonpatch.Merge, jsonpatch.MergePaths},
+	jsonPatchContentType:  {jsonpatch.Apply, jsonpatch.PatchPaths},
+	"application/json":    {jsonpatch.Merge, jsonpatch.MergePaths},
+}
+
+// acceptPatch is sent with 415 responses as the Accept-Patch header
+const acceptPatch = mergePatchContentType + ", " + jsonPatchContentType
+
+// patchError is the response to a patch that does not apply to the stored
+// entity, it aborts the transaction the patch runs in
+type patchError struct {
+	status  int
+	message string
+}
+
+func (e patchError) Error() string {
+	return e.message
+}
+
+// servePatch hands patch a function applying the patch of the request to the
+// stored entity, which patch calls in the transaction writing the result.
+// Only the fields the patch writes are stored, see patchedFields. The
+// patched entity is answered.
+func servePatch[T any](
+	w http.ResponseWriter,
+	r *http.Request,
+	nested map[string]map[string]string,
+	validate func(T) error,
+	patch func(ctx context.Context, id int, apply func(T) (T, []string, error)) (T, error),
+) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	format, ok := patchFormats[contentType]
+	if !ok {
+		w.Header().Set("Accept-Patch", acceptPatch)
+		http.Error(w, "Content-Type must be one of "+acceptPatch, http.StatusUnsupportedMediaType)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	apply := func(before T) (T, []string, error) {
+		var after T
+		doc, err := json.Marshal(before)
+		if err != nil {
+			return after, nil, err
+		}
+		patched, err := format.apply(doc, body)
+		switch {
+		case errors.Is(err, jsonpatch.ErrInvalidPatch):
+			return after, nil, patchError{http.StatusBadRequest, err.Error()}
+		case errors.Is(err, jsonpatch.ErrTestFailed):
+			return after, nil, patchError{http.StatusConflict, err.Error()}
+		case errors.Is(err, jsonpatch.ErrUnapplicable):
+			return after, nil, patchError{http.StatusUnprocessableEntity, err.Error()}
+		case err != nil:
+			return after, nil, err
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(patched))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&after)
+		if err != nil {
+			return after, nil, patchError{http.StatusUnprocessableEntity, "Patched entity is invalid: " + err.Error()}
+		}
+		if !sameID(doc, patched) {
+			return after, nil, patchError{http.StatusUnprocessableEntity, "The id cannot be patched"}
+		}
+		if validate != nil {
+			err = validate(after)
+			if err != nil {
+				return after, nil, patchError{http.StatusBadRequest, err.Error()}
+			}
+		}
+		paths, err := format.paths(body)
+		if err != nil {
+			return after, nil, patchError{http.StatusBadRequest, err.Error()}
+		}
+		return after, patchedFields[T](paths, nested), nil
+	}
+
+	res, err := patch(r.Context(), id, apply)
+	var patchErr patchError
+	if errors.As(err, &patchErr) {
+		http.Error(w, patchErr.message, patchErr.status)
+		return
+	}
+	if isClientError(err) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err = json.Marshal(res)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// patchedFields turns the paths a patch writes into the fields to store. A
+// member of T is the field of its JSON name, the members of an object in
+// nested map to the fields named there. The empty path writes every member.
+// The id and members T does not have are left out, a patch can only remove
+// those without a change.
+func patchedFields[T any](paths [][]string, nested map[string]map[string]string) []string {
+	members := jsonMembers(reflect.TypeFor[T]())
+	var fields []string
+	add := func(field string) {
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	var write func(path []string)
+	write = func(path []string) {
+		if len(path) == 0 {
+			for _, member := range members {
+				write([]string{member})
+			}
+			return
+		}
+		if path[0] == "id" || !slices.Contains(members, path[0]) {
+			return
+		}
+		columns, ok := nested[path[0]]
+		switch {
+		case !ok:
+			add(path[0])
+		case len(path) == 1:
+			for _, name := range slices.Sorted(maps.Keys(columns)) {
+				add(columns[name])
+			}
+		default:
+			if column, ok := columns[path[1]]; ok {
+				add(column)
+			}
+		}
+	}
+	for _, path := range paths {
+		write(path)
+	}
+	return fields
+}
+
+// jsonMembers lists the JSON names of the fields of a struct
+func jsonMembers(t reflect.Type) []string {
+	var members []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			members = append(members, name)
+		}
+	}
+	return members
+}
+
+// sameID tells whether the id member of both documents is the same, a patch
+// removing it changes it as well
+func sameID(before, after []byte) bool {
+	var a, b struct {
+		ID *float64 `json:"id"`
+	}
+	if json.Unmarshal(before, &a) != nil || json.Unmarshal(after, &b) != nil {
+		return false
+	}
+	return a.ID != nil && b.ID != nil && *a.ID == *b.ID
+}
+
+// serviceLinks are the fields of the members of the links of a service
+var serviceLinks = map[string]map[string]string{
+	"links": {"documentation": "docs_url", "runbook": "runbook_url", "repository": "repo_url"},
+}
+
+func (s *Server) patchProjectHandler(w http.ResponseWriter, r *http.Request) {
+	servePatch(w, r, nil, nil,
+		func(ctx context.Context, id int, apply func(Project) (Project, []string, error)) (Project, error) {
+			pr, err := s.providerProject.PatchProject(ctx, id, func(pr project.Project) (project.Project, []string, error) {
+				after, fields, err := apply(ProviderProject2ServerProject(pr))
+				return ServerProject2ProviderProject(after), fields, err
+			})
+			return ProviderProject2ServerProject(pr), err
+		},
+	)
+}
+
+func (s *Server) patchGraphHandler(w http.ResponseWriter, r *http.Request) {
+	servePatch(w, r, nil, nil,
+		func(ctx context.Context, id int, apply func(Graph) (Graph, []string, error)) (Graph, error) {
+			gr, err := s.providerGraph.PatchGraph(ctx, id, func(gr graph.Graph) (graph.Graph, []string, error) {
+				after, fields, err := apply(ProviderGraph2ServerGraph(gr))
+				return ServerGraph2ProviderGraph(after), fields, err
+			})
+			return ProviderGraph2ServerGraph(gr), err
+		},
+	)
+}
+
+func (s *Server) patchServiceHandler(w http.ResponseWriter, r *http.Request) {
+	servePatch(w, r, serviceLinks,
+		func(serv Service) error {
+			return validateServices(serv)
+		},
+		func(ctx context.Context, id int, apply func(Service) (Service, []string, error)) (Service, error) {
+			serv, err := s.providerService.PatchService(ctx, id, func(serv service.Service) (service.Service, []string, error) {
+				after, fields, err := apply(ProviderService2ServerService(serv))
+				return ServerService2ProviderService(after), fields, err
+			})
+			return ProviderService2ServerService(serv), err
+		},
+	)
+}
+
+func (s *Server) patchRelationHandler(w http.ResponseWriter, r *http.Request) {
+	servePatch(w, r, nil,
+		func(rel Relation) error {
+			return validateRelations(rel)
+		},
+		func(ctx context.Context, id int, apply func(Relation) (Relation, []string, error)) (Relation, error) {
+			rel, err := s.providerRelation.PatchRelation(ctx, id, func(rel relation.Relation) (relation.Relation, []string, error) {
+				after, fields, err := apply(ProviderRelation2ServerRelation(rel))
+				return ServerRelation2ProviderRelation(after), fields, err
+			})
+			return ProviderRelation2ServerRelation(rel), err
+		},
+	)
+}
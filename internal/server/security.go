@@ -0,0 +1,115 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hse-telescope/core/internal/config"
+)
+
+// defaultSecurityHeaders suit a JSON API that no page embeds
+var defaultSecurityHeaders = map[string]string{
+	"X-Content-Type-Options":    "nosniff",
+	"X-Frame-Options":           "DENY",
+	"Referrer-Policy":           "no-referrer",
+	"Content-Security-Policy":   "default-src 'none'; frame-ancestors 'none'",
+	"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+}
+
+// hstsHeader is only sent over TLS, browsers ignore it on plain HTTP
+const hstsHeader = "Strict-Transport-Security"
+
+// securityHeadersMiddleware sets the default headers overridden by the
+// configured ones on every response
+func securityHeadersMiddleware(overrides map[string]string) func(http.Handler) http.Handler {
+	headers := make(map[string]string, len(defaultSecurityHeaders))
+	for name, value := range defaultSecurityHeaders {
+		headers[name] = value
+	}
+	for name, value := range overrides {
+		name = http.CanonicalHeaderKey(name)
+		if value == "" {
+			delete(headers, name)
+			continue
+		}
+		headers[name] = value
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for name, value := range headers {
+				if name == hstsHeader && r.TLS == nil {
+					continue
+				}
+				w.Header().Set(name, value)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// corsMiddleware answers preflight requests and lets the allowed origins
+// read the responses. It wraps the router, which would refuse the OPTIONS
+// method of a preflight.
+func corsMiddleware(conf config.CORS) func(http.Handler) http.Handler {
+	conf = conf.WithDefaults()
+	anyOrigin := false
+	origins := make(map[string]bool, len(conf.AllowedOrigins))
+	for _, origin := range conf.AllowedOrigins {
+		if origin == "*" {
+			anyOrigin = true
+		}
+		origins[normalizeOrigin(origin)] = true
+	}
+	methods := strings.Join(conf.AllowedMethods, ", ")
+	headers := strings.Join(conf.AllowedHeaders, ", ")
+	exposed := strings.Join(conf.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(conf.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		if len(origins) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Add("Vary", "Origin")
+			origin := r.Header.Get("Origin")
+			if origin == "" || !anyOrigin && !origins[normalizeOrigin(origin)] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if anyOrigin {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+			if conf.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				h.Add("Vary", "Access-Control-Request-Method")
+				h.Add("Vary", "Access-Control-Request-Headers")
+				h.Set("Access-Control-Allow-Methods", methods)
+				if headers != "" {
+					h.Set("Access-Control-Allow-Headers", headers)
+				}
+				h.Set("Access-Control-Max-Age", maxAge)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			if exposed != "" {
+				h.Set("Access-Control-Expose-Headers", exposed)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// normalizeOrigin compares origins the way browsers send them, lower case
+// without a trailing slash
+func normalizeOrigin(origin string) string {
+	return strings.ToLower(strings.TrimSuffix(origin, "/"))
+}
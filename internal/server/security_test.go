@@ -0,0 +1,159 @@
+package server_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hse-telescope/core/internal/config"
+	"github.com/hse-telescope/core/internal/server"
+)
+
+func serveWith(conf config.Config, req *http.Request) (*httptest.ResponseRecorder, *Fake) {
+	fake := newFake()
+	rec := httptest.NewRecorder()
+	server.New(conf, fake, fake, fake, fake, fake, fake, fake, fake, fake).ServeHTTP(rec, req)
+	return rec, fake
+}
+
+func corsConfig(cors config.CORS) config.Config {
+	return config.Config{HTTP: config.HTTP{CORS: cors}}
+}
+
+func wantHeaders(t *testing.T, h http.Header, want map[string]string) {
+	t.Helper()
+	for name, value := range want {
+		if got := h.Get(name); got != value {
+			t.Errorf("%s is %q, want %q", name, got, value)
+		}
+	}
+}
+
+func corsHeaders(h http.Header) []string {
+	names := make([]string, 0)
+	for name := range h {
+		if strings.HasPrefix(name, "Access-Control-") {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func TestCORSPreflight(t *testing.T) {
+	req := httptest.NewRequest(http.MethodOptions, "/projects/1", nil)
+	req.Header.Set("Origin", "https://app.example")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+	rec, fake := serveWith(corsConfig(config.CORS{AllowedOrigins: []string{"https://App.example/"}}), req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	wantHeaders(t, rec.Header(), map[string]string{
+		"Access-Control-Allow-Origin":  "https://app.example",
+		"Access-Control-Allow-Methods": "GET, POST, PUT, PATCH, DELETE",
+		"Access-Control-Allow-Headers": "Content-Type, Idempotency-Key",
+		"Access-Control-Max-Age":       "600",
+	})
+	vary := rec.Header().Values("Vary")
+	for _, name := range []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"} {
+		if !slices.Contains(vary, name) {
+			t.Errorf("Vary %q does not list %s", vary, name)
+		}
+	}
+	if len(fake.Calls) != 0 {
+		t.Errorf("providers are called: %+v", fake.Calls)
+	}
+}
+
+func TestCORSRequest(t *testing.T) {
+	conf := corsConfig(config.CORS{AllowedOrigins: []string{"https://app.example"}, AllowCredentials: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+	req.Header.Set("Origin", "https://app.example")
+	rec, _ := serveWith(conf, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	wantHeaders(t, rec.Header(), map[string]string{
+		"Access-Control-Allow-Origin":      "https://app.example",
+		"Access-Control-Allow-Credentials": "true",
+		"Access-Control-Expose-Headers":    "Retry-After, Idempotent-Replayed",
+		"Vary":                             "Origin",
+	})
+
+	// The response depends on the origin even when it is not allowed, so
+	// caches must not hand it to an allowed one
+	for _, method := range []string{http.MethodGet, http.MethodOptions} {
+		req = httptest.NewRequest(method, "/projects", nil)
+		req.Header.Set("Origin", "https://evil.example")
+		req.Header.Set("Access-Control-Request-Method", http.MethodDelete)
+		rec, _ = serveWith(conf, req)
+		if names := corsHeaders(rec.Header()); len(names) != 0 {
+			t.Errorf("%s from a disallowed origin gets %v", method, names)
+		}
+		if rec.Code == http.StatusNoContent {
+			t.Errorf("%s from a disallowed origin is answered as a preflight", method)
+		}
+		wantHeaders(t, rec.Header(), map[string]string{"Vary": "Origin"})
+	}
+}
+
+func TestCORSAnyOrigin(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+	req.Header.Set("Origin", "https://app.example")
+	rec, _ := serveWith(corsConfig(config.CORS{AllowedOrigins: []string{"*"}}), req)
+	wantHeaders(t, rec.Header(), map[string]string{"Access-Control-Allow-Origin": "*"})
+}
+
+func TestCORSOff(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+	req.Header.Set("Origin", "https://app.example")
+	rec, _ := serveWith(config.Config{}, req)
+	if names := corsHeaders(rec.Header()); len(names) != 0 {
+		t.Errorf("CORS is off but the response has %v", names)
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	defaults := map[string]string{
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "DENY",
+		"Referrer-Policy":           "no-referrer",
+		"Content-Security-Policy":   "default-src 'none'; frame-ancestors 'none'",
+		"Strict-Transport-Security": "",
+	}
+	rec, _ := serveWith(config.Config{}, httptest.NewRequest(http.MethodGet, "/projects", nil))
+	wantHeaders(t, rec.Header(), defaults)
+
+	// HSTS is only sent over TLS
+	req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+	req.TLS = &tls.ConnectionState{}
+	rec, _ = serveWith(config.Config{}, req)
+	defaults["Strict-Transport-Security"] = "max-age=31536000; includeSubDomains"
+	wantHeaders(t, rec.Header(), defaults)
+
+	// Configured values replace the defaults, an empty one drops the header
+	conf := config.Config{HTTP: config.HTTP{SecurityHeaders: map[string]string{
+		"x-frame-options":           "SAMEORIGIN",
+		"Content-Security-Policy":   "",
+		"Strict-Transport-Security": "max-age=60",
+		"Permissions-Policy":        "camera=()",
+	}}}
+	rec, _ = serveWith(conf, req)
+	wantHeaders(t, rec.Header(), map[string]string{
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "SAMEORIGIN",
+		"Strict-Transport-Security": "max-age=60",
+		"Permissions-Policy":        "camera=()",
+	})
+	if _, ok := rec.Header()["Content-Security-Policy"]; ok {
+		t.Errorf("Content-Security-Policy is sent although it is configured empty")
+	}
+
+	// Error responses of the router carry them too
+	rec, _ = serveWith(config.Config{}, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	wantHeaders(t, rec.Header(), map[string]string{"X-Content-Type-Options": "nosniff"})
+}
@@ -2,25 +2,39 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/gorilla/mux"
+	"github.com/graphql-go/graphql"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/hse-telescope/core/internal/config"
+	"github.com/hse-telescope/core/internal/health"
+	"github.com/hse-telescope/core/internal/providers/catalog"
 	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/providers/group"
+	"github.com/hse-telescope/core/internal/providers/idempotency"
+	"github.com/hse-telescope/core/internal/providers/manifest"
 	"github.com/hse-telescope/core/internal/providers/project"
 	"github.com/hse-telescope/core/internal/providers/relation"
 	"github.com/hse-telescope/core/internal/providers/service"
+	"github.com/hse-telescope/core/internal/providers/webhook"
+	"github.com/hse-telescope/core/internal/ratelimit"
+	"github.com/hse-telescope/core/internal/tlsreload"
 	"github.com/hse-telescope/logger"
 	"github.com/hse-telescope/tracer"
 )
 
 type ProviderProject interface {
 	GetProjects(ctx context.Context) ([]project.Project, error)
+	GetProject(ctx context.Context, project_id int) (project.Project, error)
 	CreateProject(ctx context.Context, project project.Project) (project.Project, error)
 	UpdateProject(ctx context.Context, project_id int, project project.Project) error
+	PatchProject(ctx context.Context, project_id int, patch func(project.Project) (project.Project, []string, error)) (project.Project, error)
 	DeleteProject(ctx context.Context, project_id int) error
 }
 
@@ -28,63 +42,165 @@ type ProviderGraph interface {
 	CreateGraph(ctx context.Context, graph graph.Graph) (graph.Graph, error)
 	DeleteGraph(ctx context.Context, graph_id int) error
 	UpdateGraph(ctx context.Context, graph_id int, graph graph.Graph) error
+	PatchGraph(ctx context.Context, graph_id int, patch func(graph.Graph) (graph.Graph, []string, error)) (graph.Graph, error)
+	GetGraph(ctx context.Context, graph_id int) (graph.Graph, error)
 	GetProjectGraphs(ctx context.Context, project_id int) ([]graph.Graph, error)
+	GetProjectsGraphs(ctx context.Context, project_ids []int) ([]graph.Graph, error)
 }
 
 type ProviderService interface {
 	GetService(ctx context.Context, service_id int) (service.Service, error)
-	GetGraphServices(ctx context.Context, graph_id int) ([]service.Service, error)
+	GetGraphServices(ctx context.Context, graph_id int, filter service.Filter) ([]service.Service, error)
+	GetGraphsServices(ctx context.Context, graph_ids []int) ([]service.Service, error)
 	CreateService(ctx context.Context, service service.Service) (service.Service, error)
 	CreateServices(ctx context.Context, graph_id int, service []service.Service) ([]int, error)
 	UpdateGraphServices(ctx context.Context, graph_id int, service []service.Service) error
 	UpdateService(ctx context.Context, service_id int, service service.Service) error
+	PatchService(ctx context.Context, service_id int, patch func(service.Service) (service.Service, []string, error)) (service.Service, error)
 	DeleteService(ctx context.Context, service_id int) error
 }
 
 type ProviderRelation interface {
 	GetRelation(ctx context.Context, relation_id int) (relation.Relation, error)
 	GetGraphRelations(ctx context.Context, graph_id int) ([]relation.Relation, error)
+	GetServicesRelations(ctx context.Context, service_ids []int) ([]relation.Relation, error)
 	CreateRelation(ctx context.Context, relation relation.Relation) (relation.Relation, error)
 	CreateRelations(ctx context.Context, graph_id int, relations []relation.Relation) error
 	UpdateGraphRelations(ctx context.Context, graph_id int, relation []relation.Relation) error
 	UpdateRelation(ctx context.Context, relation_id int, relation relation.Relation) error
+	PatchRelation(ctx context.Context, relation_id int, patch func(relation.Relation) (relation.Relation, []string, error)) (relation.Relation, error)
 	DeleteRelation(ctx context.Context, relation_id int) error
 }
 
+type ProviderCatalog interface {
+	GetCatalogService(ctx context.Context, catalog_id int) (catalog.Service, error)
+	GetProjectCatalog(ctx context.Context, project_id int) ([]catalog.Service, error)
+	CreateCatalogService(ctx context.Context, service catalog.Service) (catalog.Service, error)
+	UpdateCatalogService(ctx context.Context, catalog_id int, service catalog.Service) error
+	DeleteCatalogService(ctx context.Context, catalog_id int) error
+	GetCatalogServiceGraphs(ctx context.Context, catalog_id int) ([]graph.Graph, error)
+}
+
+type ProviderGroup interface {
+	GetGroup(ctx context.Context, group_id int) (group.Group, error)
+	GetGraphGroups(ctx context.Context, graph_id int) ([]group.Group, error)
+	CreateGroup(ctx context.Context, group group.Group) (group.Group, error)
+	UpdateGroup(ctx context.Context, group_id int, group group.Group) error
+	DeleteGroup(ctx context.Context, group_id int) error
+	GetCollapsedGraph(ctx context.Context, graph_id int, collapse []int) (group.CollapsedGraph, error)
+}
+
+type ProviderWebhook interface {
+	GetWebhook(ctx context.Context, webhook_id int) (webhook.Webhook, error)
+	GetProjectWebhooks(ctx context.Context, project_id int) ([]webhook.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook webhook.Webhook) (webhook.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook_id int, webhook webhook.Webhook) error
+	DeleteWebhook(ctx context.Context, webhook_id int) error
+	GetWebhookDeliveries(ctx context.Context, webhook_id int) ([]webhook.Delivery, error)
+}
+
+type ProviderManifest interface {
+	Plan(ctx context.Context, project_id int, manifest manifest.Manifest) (manifest.Plan, error)
+	Apply(ctx context.Context, project_id int, manifest manifest.Manifest) (manifest.Plan, error)
+}
+
+type ProviderIdempotency interface {
+	Begin(ctx context.Context, key string, fingerprint string) (*idempotency.Response, error)
+	Complete(ctx context.Context, key string, fingerprint string, res idempotency.Response) error
+	Release(ctx context.Context, key string, fingerprint string) error
+}
+
 type Server struct {
-	server           http.Server
-	providerProject  ProviderProject
-	providerGraph    ProviderGraph
-	providerService  ProviderService
-	providerRelation ProviderRelation
+	server              http.Server
+	providerProject     ProviderProject
+	providerGraph       ProviderGraph
+	providerService     ProviderService
+	providerRelation    ProviderRelation
+	providerCatalog     ProviderCatalog
+	providerGroup       ProviderGroup
+	providerWebhook     ProviderWebhook
+	providerManifest    ProviderManifest
+	providerIdempotency ProviderIdempotency
+	graphqlSchema       graphql.Schema
+	openapi             []byte
+	health              *health.Registry
+	draining            atomic.Bool
+	limits              atomic.Pointer[config.Limits]
+	limiter             *ratelimit.Limiter
+	certs               *tlsreload.Reloader
 }
 
-func New(conf config.Config, provideProject ProviderProject, provideGraph ProviderGraph, provideService ProviderService, providerRelation ProviderRelation) *Server {
+func New(conf config.Config, provideProject ProviderProject, provideGraph ProviderGraph, provideService ProviderService, providerRelation ProviderRelation, providerCatalog ProviderCatalog, providerGroup ProviderGroup, providerWebhook ProviderWebhook, providerManifest ProviderManifest, providerIdempotency ProviderIdempotency) *Server {
 	s := new(Server)
 	s.server.Addr = fmt.Sprintf(":%d", conf.Port)
-	s.server.Handler = s.setRouter()
+	router := s.setRouter()
+	s.server.Handler = metricsMiddleware(securityHeadersMiddleware(conf.HTTP.SecurityHeaders)(corsMiddleware(conf.HTTP.CORS)(router)))
 	s.providerProject = provideProject
 	s.providerGraph = provideGraph
 	s.providerService = provideService
 	s.providerRelation = providerRelation
+	s.providerCatalog = providerCatalog
+	s.providerGroup = providerGroup
+	s.providerWebhook = providerWebhook
+	s.providerManifest = providerManifest
+	s.providerIdempotency = providerIdempotency
+	s.health = health.NewRegistry(conf.Health)
+	s.health.Register("shutdown", health.Readiness, health.CheckerFunc(s.checkShutdown))
+	s.limiter = ratelimit.New(conf.Runtime.Limits.RateLimit)
+	s.SetLimits(conf.Runtime.Limits)
+
+	if conf.HTTP.TLS.Enabled() {
+		certs, err := tlsreload.New(conf.HTTP.TLS)
+		if err != nil {
+			panic(err)
+		}
+		s.certs = certs
+		s.server.TLSConfig = certs.TLSConfig()
+		s.health.Register("certificate", health.Informational, certs)
+	}
+
+	schema, err := s.newGraphQLSchema()
+	if err != nil {
+		panic(err)
+	}
+	s.graphqlSchema = schema
+
+	s.openapi, err = json.Marshal(openAPISpec())
+	if err != nil {
+		panic(err)
+	}
 	return s
 }
 
 func (s *Server) setRouter() *mux.Router {
 	mux := mux.NewRouter()
 
-	mux.Use(logger.AddLoggingMiddleware, tracer.AddTracingMiddleware)
+	mux.Use(routeMiddleware, logger.AddLoggingMiddleware, tracer.AddTracingMiddleware, s.limitsMiddleware, s.idempotencyMiddleware)
 
 	mux.Handle("/metrics", promhttp.Handler())
 
+	mux.HandleFunc("/healthz", s.healthzHandler).Methods(http.MethodGet)
+	mux.HandleFunc("/readyz", s.readyzHandler).Methods(http.MethodGet)
+	mux.HandleFunc("/openapi.json", s.openAPIHandler).Methods(http.MethodGet)
+	mux.HandleFunc("/graphql", s.graphQLHandler).Methods(http.MethodGet, http.MethodPost)
+
 	mux.HandleFunc("/projects", s.createProjectHandler).Methods(http.MethodPost)
 	mux.HandleFunc("/projects", s.getProjectsHanlder).Methods(http.MethodGet)
 	mux.HandleFunc("/projects/{id}", s.deleteProjectHandler).Methods(http.MethodDelete)
 	mux.HandleFunc("/projects/{id}", s.updateProjectHandler).Methods(http.MethodPut)
+	mux.HandleFunc("/projects/{id}", s.patchProjectHandler).Methods(http.MethodPatch)
 	mux.HandleFunc("/projects/{id}/graphs", s.GetProjectGraphsHandler).Methods(http.MethodGet)
+	mux.HandleFunc("/projects/{id}/catalog", s.createCatalogServiceHandler).Methods(http.MethodPost)
+	mux.HandleFunc("/projects/{id}/catalog", s.getProjectCatalogHandler).Methods(http.MethodGet)
+	mux.HandleFunc("/projects/{id}/webhooks", s.createProjectWebhookHandler).Methods(http.MethodPost)
+	mux.HandleFunc("/projects/{id}/webhooks", s.getProjectWebhooksHandler).Methods(http.MethodGet)
+	mux.HandleFunc("/projects/{id}/plan", s.planProjectHandler).Methods(http.MethodPost)
+	mux.HandleFunc("/projects/{id}/apply", s.applyProjectHandler).Methods(http.MethodPost)
 
 	mux.HandleFunc("/graphs", s.createGraphHandler).Methods(http.MethodPost)
+	mux.HandleFunc("/graphs/{id}", s.getGraphHandler).Methods(http.MethodGet)
 	mux.HandleFunc("/graphs/{id}", s.updateGraphHandler).Methods(http.MethodPut)
+	mux.HandleFunc("/graphs/{id}", s.patchGraphHandler).Methods(http.MethodPatch)
 	mux.HandleFunc("/graphs/{id}", s.deleteGraphHandler).Methods(http.MethodDelete)
 	mux.HandleFunc("/graphs/{id}/services", s.updateGraphServicesHandler).Methods(http.MethodPut)
 	mux.HandleFunc("/graphs/{id}/relations", s.updateGraphRelationsHandler).Methods(http.MethodPut)
@@ -92,20 +208,76 @@ func (s *Server) setRouter() *mux.Router {
 	mux.HandleFunc("/graphs/{id}/relations", s.getGraphRelationsHandler).Methods(http.MethodGet)
 	mux.HandleFunc("/graphs/{id}/services", s.createGraphServicesHandler).Methods(http.MethodPost)
 	mux.HandleFunc("/graphs/{id}/relations", s.createGraphRelationsHandler).Methods(http.MethodPost)
+	mux.HandleFunc("/graphs/{id}/groups", s.getGraphGroupsHandler).Methods(http.MethodGet)
+	mux.HandleFunc("/graphs/{id}/collapsed", s.getCollapsedGraphHandler).Methods(http.MethodGet)
 
 	mux.HandleFunc("/services", s.createServiceHandler).Methods(http.MethodPost)
 	mux.HandleFunc("/services/{id}", s.updateServiceHandler).Methods(http.MethodPut)
+	mux.HandleFunc("/services/{id}", s.patchServiceHandler).Methods(http.MethodPatch)
 	mux.HandleFunc("/services/{id}", s.deleteServiceHandler).Methods(http.MethodDelete)
 	mux.HandleFunc("/services/{id}", s.getServiceHandler).Methods(http.MethodGet)
 
 	mux.HandleFunc("/relations", s.createRelationHandler).Methods(http.MethodPost)
 	mux.HandleFunc("/relations/{id}", s.updateRelationHandler).Methods(http.MethodPut)
+	mux.HandleFunc("/relations/{id}", s.patchRelationHandler).Methods(http.MethodPatch)
 	mux.HandleFunc("/relations/{id}", s.deleteRelationHandler).Methods(http.MethodDelete)
 	mux.HandleFunc("/relations/{id}", s.getRelationHandler).Methods(http.MethodGet)
 
+	mux.HandleFunc("/catalog/{id}", s.updateCatalogServiceHandler).Methods(http.MethodPut)
+	mux.HandleFunc("/catalog/{id}", s.deleteCatalogServiceHandler).Methods(http.MethodDelete)
+	mux.HandleFunc("/catalog/{id}", s.getCatalogServiceHandler).Methods(http.MethodGet)
+	mux.HandleFunc("/catalog/{id}/graphs", s.getCatalogServiceGraphsHandler).Methods(http.MethodGet)
+
+	mux.HandleFunc("/groups", s.createGroupHandler).Methods(http.MethodPost)
+	mux.HandleFunc("/groups/{id}", s.updateGroupHandler).Methods(http.MethodPut)
+	mux.HandleFunc("/groups/{id}", s.deleteGroupHandler).Methods(http.MethodDelete)
+	mux.HandleFunc("/groups/{id}", s.getGroupHandler).Methods(http.MethodGet)
+
+	mux.HandleFunc("/webhooks/{id}", s.getWebhookHandler).Methods(http.MethodGet)
+	mux.HandleFunc("/webhooks/{id}", s.updateWebhookHandler).Methods(http.MethodPut)
+	mux.HandleFunc("/webhooks/{id}", s.deleteWebhookHandler).Methods(http.MethodDelete)
+	mux.HandleFunc("/webhooks/{id}/deliveries", s.getWebhookDeliveriesHandler).Methods(http.MethodGet)
+
 	return mux
 }
 
+// ServeHTTP serves a request with the routes of the server without starting
+// a listener
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.server.Handler.ServeHTTP(w, r)
+}
+
+// Start serves TLS when certificates are configured, plain HTTP otherwise
 func (s *Server) Start() error {
+	if s.certs != nil {
+		return s.server.ListenAndServeTLS("", "")
+	}
 	return s.server.ListenAndServe()
 }
+
+// Certificates returns the reloader of the TLS certificates, nil when the
+// server serves plain HTTP
+func (s *Server) Certificates() *tlsreload.Reloader {
+	return s.certs
+}
+
+// Health returns the registry of the checks behind /healthz and /readyz
+func (s *Server) Health() *health.Registry {
+	return s.health
+}
+
+// Drain makes /readyz fail, so the instance gets no new traffic while it is
+// shutting down
+func (s *Server) Drain() {
+	s.draining.Store(true)
+}
+
+// Shutdown stops accepting connections and waits for the requests in flight
+// until ctx is done, the connections left are closed then
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		s.server.Close() // nolint:errcheck
+	}
+	return err
+}
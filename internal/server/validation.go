@@ -0,0 +1,92 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hse-telescope/core/internal/providers/group"
+	"github.com/hse-telescope/core/internal/providers/manifest"
+	"github.com/hse-telescope/core/internal/providers/relation"
+	"github.com/hse-telescope/core/internal/providers/service"
+)
+
+// isClientError reports provider errors caused by invalid input
+func isClientError(err error) bool {
+	return errors.Is(err, service.ErrForeignCatalogService) ||
+		errors.Is(err, group.ErrParentGraph) ||
+		errors.Is(err, group.ErrCycle) ||
+		errors.Is(err, manifest.ErrInvalidManifest)
+}
+
+// writeProviderError answers a provider error that isClientError does not
+// cover: 404 for a missing entity, 500 for everything else
+func writeProviderError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Not found: "+err.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusInternalServerError)
+	w.Write([]byte("Something went wrong: " + err.Error()))
+}
+
+func validateServices(services ...Service) error {
+	for _, serv := range services {
+		if !service.ValidKind(serv.Kind) {
+			return fmt.Errorf("unknown service kind %q", serv.Kind)
+		}
+	}
+	return nil
+}
+
+func validateRelations(relations ...Relation) error {
+	for _, rel := range relations {
+		if !relation.ValidProtocol(rel.Protocol) {
+			return fmt.Errorf("unknown relation protocol %q", rel.Protocol)
+		}
+		if !relation.ValidCriticality(rel.Criticality) {
+			return fmt.Errorf("unknown relation criticality %q", rel.Criticality)
+		}
+		if rel.ExpectedRPS < 0 || rel.ExpectedLatencyMS < 0 {
+			return fmt.Errorf("expected rps and latency must not be negative")
+		}
+	}
+	return nil
+}
+
+func validateCatalogService(serv CatalogService) error {
+	if serv.Name == "" {
+		return fmt.Errorf("catalog service name must not be empty")
+	}
+	if !service.ValidKind(serv.Kind) {
+		return fmt.Errorf("unknown service kind %q", serv.Kind)
+	}
+	return nil
+}
+
+// serviceFilterFromQuery reads ?kind=&owner_team=&tag=&attr=key:value, tag and
+// attr may be repeated.
+func serviceFilterFromQuery(query url.Values) (service.Filter, error) {
+	filter := service.Filter{
+		Kind:      query.Get("kind"),
+		OwnerTeam: query.Get("owner_team"),
+		Tags:      query["tag"],
+	}
+	if !service.ValidKind(filter.Kind) {
+		return service.Filter{}, fmt.Errorf("unknown service kind %q", filter.Kind)
+	}
+	for _, attr := range query["attr"] {
+		key, value, ok := strings.Cut(attr, ":")
+		if !ok || key == "" {
+			return service.Filter{}, fmt.Errorf("attribute filter %q must look like key:value", attr)
+		}
+		if filter.Attributes == nil {
+			filter.Attributes = make(map[string]string)
+		}
+		filter.Attributes[key] = value
+	}
+	return filter, nil
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/olegdayo/omniconv"
+)
+
+func validWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func (s *Server) createProjectWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	project_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	var webhook Webhook
+	err = json.NewDecoder(r.Body).Decode(&webhook)
+	if err != nil {
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !validWebhookURL(webhook.URL) {
+		http.Error(w, "URL must be an absolute http(s) URL", http.StatusBadRequest)
+		return
+	}
+	webhook.ProjectID = project_id
+
+	newwebhook, err := s.providerWebhook.CreateWebhook(r.Context(), ServerWebhook2ProviderWebhook(webhook))
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(ProviderWebhook2ServerWebhook(newwebhook))
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Write(body)
+}
+
+func (s *Server) getProjectWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	project_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	webhooks, err := s.providerWebhook.GetProjectWebhooks(r.Context(), project_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(omniconv.ConvertSlice(webhooks, ProviderWebhook2ServerWebhook))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func (s *Server) getWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	webhook_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := s.providerWebhook.GetWebhook(r.Context(), webhook_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(ProviderWebhook2ServerWebhook(webhook))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func (s *Server) updateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	webhook_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	var webhook Webhook
+	err = json.NewDecoder(r.Body).Decode(&webhook)
+	if err != nil {
+		http.Error(w, "Invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !validWebhookURL(webhook.URL) {
+		http.Error(w, "URL must be an absolute http(s) URL", http.StatusBadRequest)
+		return
+	}
+
+	err = s.providerWebhook.UpdateWebhook(r.Context(), webhook_id, ServerWebhook2ProviderWebhook(webhook))
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	webhook_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	err = s.providerWebhook.DeleteWebhook(r.Context(), webhook_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getWebhookDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	webhook_id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := s.providerWebhook.GetWebhookDeliveries(r.Context(), webhook_id)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	body, err := json.Marshal(omniconv.ConvertSlice(deliveries, ProviderDelivery2ServerDelivery))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Something went wrong"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
@@ -0,0 +1,206 @@
+// Package tlsreload serves TLS with certificates read from files and read
+// again whenever the files change, so a renewed certificate is picked up
+// without a restart.
+package tlsreload
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// pollInterval is how often the files are read, polling follows the symlink
+// swaps of mounted Kubernetes secrets
+const pollInterval = 2 * time.Second
+
+// expiryWarning is how long before its expiry the certificate fails Check
+const expiryWarning = 7 * 24 * time.Hour
+
+type Config struct {
+	// CertFile and KeyFile hold the PEM certificate chain and key the server
+	// presents, TLS is off when they are empty
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// ClientCAFile holds the PEM certificates of the authorities clients are
+	// verified against, every client has to present a certificate when set
+	ClientCAFile string `yaml:"client_ca_file"`
+	// MinVersion is the oldest TLS version accepted, 1.2 or 1.3, 1.2 when
+	// empty
+	MinVersion string `yaml:"min_version"`
+}
+
+// Enabled tells whether the server serves TLS
+func (c Config) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// Validate reports every invalid value at once
+func (c Config) Validate() error {
+	var errs []error
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		errs = append(errs, errors.New("cert_file and key_file are set together"))
+	}
+	if c.ClientCAFile != "" && !c.Enabled() {
+		errs = append(errs, errors.New("client_ca_file requires cert_file and key_file"))
+	}
+	_, err := c.minVersion()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) minVersion() (uint16, error) {
+	switch c.MinVersion {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("min_version %q is not one of 1.2, 1.3", c.MinVersion)
+	}
+}
+
+// Reloader holds the TLS config of the files last read successfully, a
+// broken file is logged and the previous certificates are kept
+type Reloader struct {
+	conf       Config
+	minVersion uint16
+	current    atomic.Pointer[tls.Config]
+}
+
+// New reads the files of conf, they have to be valid at startup
+func New(conf Config) (*Reloader, error) {
+	minVersion, err := conf.minVersion()
+	if err != nil {
+		return nil, err
+	}
+	r := &Reloader{
+		conf:       conf,
+		minVersion: minVersion,
+	}
+	config, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.current.Store(config)
+	return r, nil
+}
+
+// TLSConfig returns the config for http.Server, every handshake gets the
+// certificates read last
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: r.minVersion,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current.Load(), nil
+		},
+		// Unused while GetConfigForClient answers, older releases of
+		// net/http only look for it to tell the server has a certificate
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return &r.current.Load().Certificates[0], nil
+		},
+	}
+}
+
+// Run reads the files again whenever their content changes, until ctx is
+// done
+func (r *Reloader) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	digest := r.digest()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		digest = r.reload(ctx, digest)
+	}
+}
+
+// reload reads the files again when their digest differs from the one they
+// were last read with and returns the new digest
+func (r *Reloader) reload(ctx context.Context, digest [sha256.Size]byte) [sha256.Size]byte {
+	next := r.digest()
+	if next == digest {
+		return digest
+	}
+
+	config, err := r.load()
+	if err != nil {
+		slog.ErrorContext(ctx, "certificate reload failed, keeping the previous one", "error", err)
+		return next
+	}
+	r.current.Store(config)
+	slog.InfoContext(ctx, "certificates reloaded", "cert_file", r.conf.CertFile, "expires", config.Certificates[0].Leaf.NotAfter)
+	return next
+}
+
+// Check fails when the certificate expires within a week
+func (r *Reloader) Check(ctx context.Context) (string, error) {
+	leaf := r.current.Load().Certificates[0].Leaf
+	detail := "expires at " + leaf.NotAfter.Format(time.RFC3339)
+	left := time.Until(leaf.NotAfter)
+	if left <= 0 {
+		return detail, errors.New("certificate has expired")
+	}
+	if left < expiryWarning {
+		return detail, fmt.Errorf("certificate expires in %s", left.Round(time.Minute))
+	}
+	return detail, nil
+}
+
+// load reads the files into the config of a handshake
+func (r *Reloader) load() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(r.conf.CertFile, r.conf.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", r.conf.CertFile, err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   r.minVersion,
+		Certificates: []tls.Certificate{cert},
+		// The config replaces the one of http.Server, which would offer both
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+	if r.conf.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.conf.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no PEM certificates", r.conf.ClientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// digest hashes the content of the files, a file that cannot be read hashes
+// as empty
+func (r *Reloader) digest() [sha256.Size]byte {
+	h := sha256.New()
+	for _, path := range []string{r.conf.CertFile, r.conf.KeyFile, r.conf.ClientCAFile} {
+		if path == "" {
+			continue
+		}
+		data, _ := os.ReadFile(path) // nolint:gosec
+		h.Write(data)
+	}
+	return [sha256.Size]byte(h.Sum(nil))
+}
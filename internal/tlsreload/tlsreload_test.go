@@ -0,0 +1,178 @@
+package tlsreload
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// keyPair is a self-signed certificate and its key in PEM
+type keyPair struct {
+	cert []byte
+	key  []byte
+}
+
+func newKeyPair(t *testing.T, serial int64, notAfter time.Time) keyPair {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "core.test"},
+		DNSNames:     []string{"core.test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyPair{
+		cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeFiles(t *testing.T, conf Config, cert []byte, key []byte) {
+	t.Helper()
+	err := os.WriteFile(conf.CertFile, cert, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(conf.KeyFile, key, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newConfig(t *testing.T, pair keyPair) Config {
+	dir := t.TempDir()
+	conf := Config{CertFile: filepath.Join(dir, "tls.crt"), KeyFile: filepath.Join(dir, "tls.key")}
+	writeFiles(t, conf, pair.cert, pair.key)
+	return conf
+}
+
+// served returns the serial number of the certificate a client is shown
+func served(t *testing.T, r *Reloader) int64 {
+	t.Helper()
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close() // nolint:errcheck
+	go func() {
+		// The client reports a failed handshake
+		tls.Server(serverConn, r.TLSConfig()).Handshake() // nolint:errcheck
+		serverConn.Close()                                // nolint:errcheck
+	}()
+
+	client := tls.Client(clientConn, &tls.Config{ServerName: "core.test", InsecureSkipVerify: true}) // nolint:gosec
+	err := client.Handshake()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+}
+
+func TestReloadPicksUpRotatedCertificate(t *testing.T) {
+	ctx := context.Background()
+	conf := newConfig(t, newKeyPair(t, 1, time.Now().Add(90*24*time.Hour)))
+	r, err := New(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := r.digest()
+	if got := served(t, r); got != 1 {
+		t.Fatalf("serves certificate %d, want 1", got)
+	}
+
+	rotated := newKeyPair(t, 2, time.Now().Add(90*24*time.Hour))
+	writeFiles(t, conf, rotated.cert, rotated.key)
+	digest = r.reload(ctx, digest)
+	if got := served(t, r); got != 2 {
+		t.Fatalf("serves certificate %d after the rotation, want 2", got)
+	}
+	if next := r.reload(ctx, digest); next != digest {
+		t.Fatal("digest changes while the files do not")
+	}
+}
+
+func TestReloadKeepsCertificateOfInvalidPair(t *testing.T) {
+	ctx := context.Background()
+	first := newKeyPair(t, 1, time.Now().Add(90*24*time.Hour))
+	conf := newConfig(t, first)
+	r, err := New(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := r.digest()
+
+	// The certificate is renewed before its key is written
+	rotated := newKeyPair(t, 2, time.Now().Add(90*24*time.Hour))
+	writeFiles(t, conf, rotated.cert, first.key)
+	digest = r.reload(ctx, digest)
+	if got := served(t, r); got != 1 {
+		t.Fatalf("serves certificate %d of a mismatched pair, want 1", got)
+	}
+
+	writeFiles(t, conf, []byte("not a certificate"), rotated.key)
+	digest = r.reload(ctx, digest)
+	if got := served(t, r); got != 1 {
+		t.Fatalf("serves certificate %d of a broken file, want 1", got)
+	}
+
+	writeFiles(t, conf, rotated.cert, rotated.key)
+	r.reload(ctx, digest)
+	if got := served(t, r); got != 2 {
+		t.Fatalf("serves certificate %d once the pair is valid, want 2", got)
+	}
+}
+
+func TestNewRejectsInvalidPair(t *testing.T) {
+	conf := newConfig(t, newKeyPair(t, 1, time.Now().Add(time.Hour)))
+	writeFiles(t, conf, newKeyPair(t, 2, time.Now().Add(time.Hour)).cert, newKeyPair(t, 3, time.Now().Add(time.Hour)).key)
+	_, err := New(conf)
+	if err == nil {
+		t.Fatal("a mismatched pair is accepted at startup")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	for _, c := range []struct {
+		name     string
+		notAfter time.Time
+		fails    bool
+	}{
+		{name: "Valid", notAfter: time.Now().Add(30 * 24 * time.Hour)},
+		{name: "ExpiresSoon", notAfter: time.Now().Add(3 * 24 * time.Hour), fails: true},
+		{name: "Expired", notAfter: time.Now().Add(-time.Minute), fails: true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := New(newConfig(t, newKeyPair(t, 1, c.notAfter)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = r.Check(ctx)
+			if (err != nil) != c.fails {
+				t.Fatalf("Check returned %v", err)
+			}
+		})
+	}
+}
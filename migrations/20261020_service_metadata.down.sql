@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS services_attributes_idx;
+DROP INDEX IF EXISTS services_tags_idx;
+
+ALTER TABLE services
+    DROP COLUMN IF EXISTS kind,
+    DROP COLUMN IF EXISTS owner_team,
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS docs_url,
+    DROP COLUMN IF EXISTS runbook_url,
+    DROP COLUMN IF EXISTS repo_url;
@@ -0,0 +1,11 @@
+ALTER TABLE services
+    ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS owner_team TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS docs_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS runbook_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS repo_url TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS services_tags_idx ON services USING GIN (tags);
+CREATE INDEX IF NOT EXISTS services_attributes_idx ON services USING GIN (attributes);
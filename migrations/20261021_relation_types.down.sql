@@ -0,0 +1,7 @@
+ALTER TABLE relations
+    DROP COLUMN IF EXISTS protocol,
+    DROP COLUMN IF EXISTS async,
+    DROP COLUMN IF EXISTS bidirectional,
+    DROP COLUMN IF EXISTS criticality,
+    DROP COLUMN IF EXISTS expected_rps,
+    DROP COLUMN IF EXISTS expected_latency_ms;
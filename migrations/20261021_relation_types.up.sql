@@ -0,0 +1,7 @@
+ALTER TABLE relations
+    ADD COLUMN IF NOT EXISTS protocol TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS async BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS bidirectional BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS criticality TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS expected_rps DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS expected_latency_ms DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+ALTER TABLE services
+    DROP CONSTRAINT IF EXISTS services_group_fkey,
+    DROP COLUMN IF EXISTS group_id;
+
+DROP TABLE IF EXISTS service_groups;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS service_groups (
+    id SERIAL PRIMARY KEY,
+    graph_id INTEGER NOT NULL REFERENCES graphs(id) ON DELETE CASCADE,
+    parent_id INTEGER,
+    name TEXT,
+    description TEXT NOT NULL DEFAULT '',
+    kind TEXT NOT NULL DEFAULT '',
+    x REAL NOT NULL DEFAULT 0,
+    y REAL NOT NULL DEFAULT 0,
+    width REAL NOT NULL DEFAULT 0,
+    height REAL NOT NULL DEFAULT 0,
+    UNIQUE (id, graph_id),
+    FOREIGN KEY (parent_id, graph_id) REFERENCES service_groups(id, graph_id) ON DELETE SET NULL (parent_id)
+);
+
+ALTER TABLE services
+    ADD COLUMN IF NOT EXISTS group_id INTEGER,
+    ADD CONSTRAINT services_group_fkey FOREIGN KEY (group_id, graph_id)
+        REFERENCES service_groups(id, graph_id) ON DELETE SET NULL (group_id);
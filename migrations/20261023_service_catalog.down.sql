@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS services_catalog_id_idx;
+
+ALTER TABLE services DROP COLUMN IF EXISTS catalog_id;
+
+DROP TABLE IF EXISTS catalog_services;
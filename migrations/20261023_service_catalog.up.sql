@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS catalog_services (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    kind TEXT NOT NULL DEFAULT '',
+    owner_team TEXT NOT NULL DEFAULT '',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    attributes JSONB NOT NULL DEFAULT '{}',
+    docs_url TEXT NOT NULL DEFAULT '',
+    runbook_url TEXT NOT NULL DEFAULT '',
+    repo_url TEXT NOT NULL DEFAULT '',
+    UNIQUE (project_id, name)
+);
+
+ALTER TABLE services
+    ADD COLUMN IF NOT EXISTS catalog_id INTEGER REFERENCES catalog_services(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS services_catalog_id_idx ON services (catalog_id);
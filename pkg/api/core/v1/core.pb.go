@@ -0,0 +1,2296 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: core/v1/core.proto
+
+package corev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Project struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_core_v1_core_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Project) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Graph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId     int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Graph) Reset() {
+	*x = Graph{}
+	mi := &file_core_v1_core_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Graph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Graph) ProtoMessage() {}
+
+func (x *Graph) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Graph.ProtoReflect.Descriptor instead.
+func (*Graph) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Graph) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Graph) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *Graph) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ServiceLinks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Documentation string                 `protobuf:"bytes,1,opt,name=documentation,proto3" json:"documentation,omitempty"`
+	Runbook       string                 `protobuf:"bytes,2,opt,name=runbook,proto3" json:"runbook,omitempty"`
+	Repository    string                 `protobuf:"bytes,3,opt,name=repository,proto3" json:"repository,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceLinks) Reset() {
+	*x = ServiceLinks{}
+	mi := &file_core_v1_core_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceLinks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceLinks) ProtoMessage() {}
+
+func (x *ServiceLinks) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceLinks.ProtoReflect.Descriptor instead.
+func (*ServiceLinks) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ServiceLinks) GetDocumentation() string {
+	if x != nil {
+		return x.Documentation
+	}
+	return ""
+}
+
+func (x *ServiceLinks) GetRunbook() string {
+	if x != nil {
+		return x.Runbook
+	}
+	return ""
+}
+
+func (x *ServiceLinks) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+type Service struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	GraphId       int64                  `protobuf:"varint,2,opt,name=graph_id,json=graphId,proto3" json:"graph_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	X             float32                `protobuf:"fixed32,5,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float32                `protobuf:"fixed32,6,opt,name=y,proto3" json:"y,omitempty"`
+	GroupId       *int64                 `protobuf:"varint,7,opt,name=group_id,json=groupId,proto3,oneof" json:"group_id,omitempty"`
+	CatalogId     *int64                 `protobuf:"varint,8,opt,name=catalog_id,json=catalogId,proto3,oneof" json:"catalog_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,9,opt,name=kind,proto3" json:"kind,omitempty"`
+	OwnerTeam     string                 `protobuf:"bytes,10,opt,name=owner_team,json=ownerTeam,proto3" json:"owner_team,omitempty"`
+	Tags          []string               `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,12,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Links         *ServiceLinks          `protobuf:"bytes,13,opt,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Service) Reset() {
+	*x = Service{}
+	mi := &file_core_v1_core_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Service) ProtoMessage() {}
+
+func (x *Service) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Service.ProtoReflect.Descriptor instead.
+func (*Service) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Service) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Service) GetGraphId() int64 {
+	if x != nil {
+		return x.GraphId
+	}
+	return 0
+}
+
+func (x *Service) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Service) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Service) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Service) GetY() float32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Service) GetGroupId() int64 {
+	if x != nil && x.GroupId != nil {
+		return *x.GroupId
+	}
+	return 0
+}
+
+func (x *Service) GetCatalogId() int64 {
+	if x != nil && x.CatalogId != nil {
+		return *x.CatalogId
+	}
+	return 0
+}
+
+func (x *Service) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Service) GetOwnerTeam() string {
+	if x != nil {
+		return x.OwnerTeam
+	}
+	return ""
+}
+
+func (x *Service) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Service) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Service) GetLinks() *ServiceLinks {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type Relation struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	GraphId           int64                  `protobuf:"varint,2,opt,name=graph_id,json=graphId,proto3" json:"graph_id,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	FromService       int64                  `protobuf:"varint,5,opt,name=from_service,json=fromService,proto3" json:"from_service,omitempty"`
+	ToService         int64                  `protobuf:"varint,6,opt,name=to_service,json=toService,proto3" json:"to_service,omitempty"`
+	Protocol          string                 `protobuf:"bytes,7,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Async             bool                   `protobuf:"varint,8,opt,name=async,proto3" json:"async,omitempty"`
+	Bidirectional     bool                   `protobuf:"varint,9,opt,name=bidirectional,proto3" json:"bidirectional,omitempty"`
+	Criticality       string                 `protobuf:"bytes,10,opt,name=criticality,proto3" json:"criticality,omitempty"`
+	ExpectedRps       float64                `protobuf:"fixed64,11,opt,name=expected_rps,json=expectedRps,proto3" json:"expected_rps,omitempty"`
+	ExpectedLatencyMs float64                `protobuf:"fixed64,12,opt,name=expected_latency_ms,json=expectedLatencyMs,proto3" json:"expected_latency_ms,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Relation) Reset() {
+	*x = Relation{}
+	mi := &file_core_v1_core_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Relation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relation) ProtoMessage() {}
+
+func (x *Relation) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relation.ProtoReflect.Descriptor instead.
+func (*Relation) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Relation) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Relation) GetGraphId() int64 {
+	if x != nil {
+		return x.GraphId
+	}
+	return 0
+}
+
+func (x *Relation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Relation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Relation) GetFromService() int64 {
+	if x != nil {
+		return x.FromService
+	}
+	return 0
+}
+
+func (x *Relation) GetToService() int64 {
+	if x != nil {
+		return x.ToService
+	}
+	return 0
+}
+
+func (x *Relation) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *Relation) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *Relation) GetBidirectional() bool {
+	if x != nil {
+		return x.Bidirectional
+	}
+	return false
+}
+
+func (x *Relation) GetCriticality() string {
+	if x != nil {
+		return x.Criticality
+	}
+	return ""
+}
+
+func (x *Relation) GetExpectedRps() float64 {
+	if x != nil {
+		return x.ExpectedRps
+	}
+	return 0
+}
+
+func (x *Relation) GetExpectedLatencyMs() float64 {
+	if x != nil {
+		return x.ExpectedLatencyMs
+	}
+	return 0
+}
+
+type ListProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{5}
+}
+
+type ListProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_core_v1_core_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type CreateProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateProjectRequest) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+type UpdateProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Project       *Project               `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectRequest) Reset() {
+	*x = UpdateProjectRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectRequest) ProtoMessage() {}
+
+func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateProjectRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+type DeleteProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteProjectRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListProjectGraphsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectGraphsRequest) Reset() {
+	*x = ListProjectGraphsRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectGraphsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectGraphsRequest) ProtoMessage() {}
+
+func (x *ListProjectGraphsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectGraphsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectGraphsRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListProjectGraphsRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+type ListProjectGraphsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Graphs        []*Graph               `protobuf:"bytes,1,rep,name=graphs,proto3" json:"graphs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectGraphsResponse) Reset() {
+	*x = ListProjectGraphsResponse{}
+	mi := &file_core_v1_core_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectGraphsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectGraphsResponse) ProtoMessage() {}
+
+func (x *ListProjectGraphsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectGraphsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectGraphsResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListProjectGraphsResponse) GetGraphs() []*Graph {
+	if x != nil {
+		return x.Graphs
+	}
+	return nil
+}
+
+type CreateGraphRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Graph         *Graph                 `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGraphRequest) Reset() {
+	*x = CreateGraphRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGraphRequest) ProtoMessage() {}
+
+func (x *CreateGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGraphRequest.ProtoReflect.Descriptor instead.
+func (*CreateGraphRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateGraphRequest) GetGraph() *Graph {
+	if x != nil {
+		return x.Graph
+	}
+	return nil
+}
+
+type UpdateGraphRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Graph         *Graph                 `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGraphRequest) Reset() {
+	*x = UpdateGraphRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGraphRequest) ProtoMessage() {}
+
+func (x *UpdateGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGraphRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGraphRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateGraphRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateGraphRequest) GetGraph() *Graph {
+	if x != nil {
+		return x.Graph
+	}
+	return nil
+}
+
+type DeleteGraphRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGraphRequest) Reset() {
+	*x = DeleteGraphRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGraphRequest) ProtoMessage() {}
+
+func (x *DeleteGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGraphRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGraphRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteGraphRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServiceRequest) Reset() {
+	*x = GetServiceRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceRequest) ProtoMessage() {}
+
+func (x *GetServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceRequest.ProtoReflect.Descriptor instead.
+func (*GetServiceRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetServiceRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ServiceFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	OwnerTeam     string                 `protobuf:"bytes,2,opt,name=owner_team,json=ownerTeam,proto3" json:"owner_team,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceFilter) Reset() {
+	*x = ServiceFilter{}
+	mi := &file_core_v1_core_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceFilter) ProtoMessage() {}
+
+func (x *ServiceFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceFilter.ProtoReflect.Descriptor instead.
+func (*ServiceFilter) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ServiceFilter) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ServiceFilter) GetOwnerTeam() string {
+	if x != nil {
+		return x.OwnerTeam
+	}
+	return ""
+}
+
+func (x *ServiceFilter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ServiceFilter) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type ListGraphServicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GraphId       int64                  `protobuf:"varint,1,opt,name=graph_id,json=graphId,proto3" json:"graph_id,omitempty"`
+	Filter        *ServiceFilter         `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGraphServicesRequest) Reset() {
+	*x = ListGraphServicesRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGraphServicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGraphServicesRequest) ProtoMessage() {}
+
+func (x *ListGraphServicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGraphServicesRequest.ProtoReflect.Descriptor instead.
+func (*ListGraphServicesRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListGraphServicesRequest) GetGraphId() int64 {
+	if x != nil {
+		return x.GraphId
+	}
+	return 0
+}
+
+func (x *ListGraphServicesRequest) GetFilter() *ServiceFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ListGraphServicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Services      []*Service             `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGraphServicesResponse) Reset() {
+	*x = ListGraphServicesResponse{}
+	mi := &file_core_v1_core_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGraphServicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGraphServicesResponse) ProtoMessage() {}
+
+func (x *ListGraphServicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGraphServicesResponse.ProtoReflect.Descriptor instead.
+func (*ListGraphServicesResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListGraphServicesResponse) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type CreateServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       *Service               `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServiceRequest) Reset() {
+	*x = CreateServiceRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceRequest) ProtoMessage() {}
+
+func (x *CreateServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceRequest.ProtoReflect.Descriptor instead.
+func (*CreateServiceRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateServiceRequest) GetService() *Service {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+type CreateServicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GraphId       int64                  `protobuf:"varint,1,opt,name=graph_id,json=graphId,proto3" json:"graph_id,omitempty"`
+	Services      []*Service             `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServicesRequest) Reset() {
+	*x = CreateServicesRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServicesRequest) ProtoMessage() {}
+
+func (x *CreateServicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServicesRequest.ProtoReflect.Descriptor instead.
+func (*CreateServicesRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateServicesRequest) GetGraphId() int64 {
+	if x != nil {
+		return x.GraphId
+	}
+	return 0
+}
+
+func (x *CreateServicesRequest) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type CreateServicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServicesResponse) Reset() {
+	*x = CreateServicesResponse{}
+	mi := &file_core_v1_core_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServicesResponse) ProtoMessage() {}
+
+func (x *CreateServicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServicesResponse.ProtoReflect.Descriptor instead.
+func (*CreateServicesResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateServicesResponse) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type UpdateServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Service       *Service               `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateServiceRequest) Reset() {
+	*x = UpdateServiceRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateServiceRequest) ProtoMessage() {}
+
+func (x *UpdateServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateServiceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateServiceRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateServiceRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateServiceRequest) GetService() *Service {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+type UpdateGraphServicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GraphId       int64                  `protobuf:"varint,1,opt,name=graph_id,json=graphId,proto3" json:"graph_id,omitempty"`
+	Services      []*Service             `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGraphServicesRequest) Reset() {
+	*x = UpdateGraphServicesRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGraphServicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGraphServicesRequest) ProtoMessage() {}
+
+func (x *UpdateGraphServicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGraphServicesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGraphServicesRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateGraphServicesRequest) GetGraphId() int64 {
+	if x != nil {
+		return x.GraphId
+	}
+	return 0
+}
+
+func (x *UpdateGraphServicesRequest) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type DeleteServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteServiceRequest) Reset() {
+	*x = DeleteServiceRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteServiceRequest) ProtoMessage() {}
+
+func (x *DeleteServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteServiceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteServiceRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteServiceRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetRelationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationRequest) Reset() {
+	*x = GetRelationRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationRequest) ProtoMessage() {}
+
+func (x *GetRelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationRequest.ProtoReflect.Descriptor instead.
+func (*GetRelationRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetRelationRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListGraphRelationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GraphId       int64                  `protobuf:"varint,1,opt,name=graph_id,json=graphId,proto3" json:"graph_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGraphRelationsRequest) Reset() {
+	*x = ListGraphRelationsRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGraphRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGraphRelationsRequest) ProtoMessage() {}
+
+func (x *ListGraphRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGraphRelationsRequest.ProtoReflect.Descriptor instead.
+func (*ListGraphRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListGraphRelationsRequest) GetGraphId() int64 {
+	if x != nil {
+		return x.GraphId
+	}
+	return 0
+}
+
+type ListGraphRelationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relations     []*Relation            `protobuf:"bytes,1,rep,name=relations,proto3" json:"relations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGraphRelationsResponse) Reset() {
+	*x = ListGraphRelationsResponse{}
+	mi := &file_core_v1_core_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGraphRelationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGraphRelationsResponse) ProtoMessage() {}
+
+func (x *ListGraphRelationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGraphRelationsResponse.ProtoReflect.Descriptor instead.
+func (*ListGraphRelationsResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListGraphRelationsResponse) GetRelations() []*Relation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+type CreateRelationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relation      *Relation              `protobuf:"bytes,1,opt,name=relation,proto3" json:"relation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRelationRequest) Reset() {
+	*x = CreateRelationRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRelationRequest) ProtoMessage() {}
+
+func (x *CreateRelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRelationRequest.ProtoReflect.Descriptor instead.
+func (*CreateRelationRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateRelationRequest) GetRelation() *Relation {
+	if x != nil {
+		return x.Relation
+	}
+	return nil
+}
+
+type CreateRelationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GraphId       int64                  `protobuf:"varint,1,opt,name=graph_id,json=graphId,proto3" json:"graph_id,omitempty"`
+	Relations     []*Relation            `protobuf:"bytes,2,rep,name=relations,proto3" json:"relations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRelationsRequest) Reset() {
+	*x = CreateRelationsRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRelationsRequest) ProtoMessage() {}
+
+func (x *CreateRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRelationsRequest.ProtoReflect.Descriptor instead.
+func (*CreateRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateRelationsRequest) GetGraphId() int64 {
+	if x != nil {
+		return x.GraphId
+	}
+	return 0
+}
+
+func (x *CreateRelationsRequest) GetRelations() []*Relation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+type UpdateRelationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Relation      *Relation              `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRelationRequest) Reset() {
+	*x = UpdateRelationRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRelationRequest) ProtoMessage() {}
+
+func (x *UpdateRelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRelationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRelationRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateRelationRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateRelationRequest) GetRelation() *Relation {
+	if x != nil {
+		return x.Relation
+	}
+	return nil
+}
+
+type UpdateGraphRelationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GraphId       int64                  `protobuf:"varint,1,opt,name=graph_id,json=graphId,proto3" json:"graph_id,omitempty"`
+	Relations     []*Relation            `protobuf:"bytes,2,rep,name=relations,proto3" json:"relations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGraphRelationsRequest) Reset() {
+	*x = UpdateGraphRelationsRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGraphRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGraphRelationsRequest) ProtoMessage() {}
+
+func (x *UpdateGraphRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGraphRelationsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGraphRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateGraphRelationsRequest) GetGraphId() int64 {
+	if x != nil {
+		return x.GraphId
+	}
+	return 0
+}
+
+func (x *UpdateGraphRelationsRequest) GetRelations() []*Relation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+type DeleteRelationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRelationRequest) Reset() {
+	*x = DeleteRelationRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRelationRequest) ProtoMessage() {}
+
+func (x *DeleteRelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRelationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRelationRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteRelationRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type SubscribeGraphChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GraphId       int64                  `protobuf:"varint,1,opt,name=graph_id,json=graphId,proto3" json:"graph_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeGraphChangesRequest) Reset() {
+	*x = SubscribeGraphChangesRequest{}
+	mi := &file_core_v1_core_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeGraphChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeGraphChangesRequest) ProtoMessage() {}
+
+func (x *SubscribeGraphChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeGraphChangesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeGraphChangesRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SubscribeGraphChangesRequest) GetGraphId() int64 {
+	if x != nil {
+		return x.GraphId
+	}
+	return 0
+}
+
+type GraphChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event name, the same as in webhook payloads, e.g. "service.updated".
+	Event      string                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	ProjectId  int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	GraphId    int64                  `protobuf:"varint,3,opt,name=graph_id,json=graphId,proto3" json:"graph_id,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Entity:
+	//
+	//	*GraphChange_Graph
+	//	*GraphChange_Service
+	//	*GraphChange_Relation
+	Entity        isGraphChange_Entity `protobuf_oneof:"entity"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphChange) Reset() {
+	*x = GraphChange{}
+	mi := &file_core_v1_core_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphChange) ProtoMessage() {}
+
+func (x *GraphChange) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphChange.ProtoReflect.Descriptor instead.
+func (*GraphChange) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GraphChange) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *GraphChange) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *GraphChange) GetGraphId() int64 {
+	if x != nil {
+		return x.GraphId
+	}
+	return 0
+}
+
+func (x *GraphChange) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *GraphChange) GetEntity() isGraphChange_Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *GraphChange) GetGraph() *Graph {
+	if x != nil {
+		if x, ok := x.Entity.(*GraphChange_Graph); ok {
+			return x.Graph
+		}
+	}
+	return nil
+}
+
+func (x *GraphChange) GetService() *Service {
+	if x != nil {
+		if x, ok := x.Entity.(*GraphChange_Service); ok {
+			return x.Service
+		}
+	}
+	return nil
+}
+
+func (x *GraphChange) GetRelation() *Relation {
+	if x != nil {
+		if x, ok := x.Entity.(*GraphChange_Relation); ok {
+			return x.Relation
+		}
+	}
+	return nil
+}
+
+type isGraphChange_Entity interface {
+	isGraphChange_Entity()
+}
+
+type GraphChange_Graph struct {
+	Graph *Graph `protobuf:"bytes,5,opt,name=graph,proto3,oneof"`
+}
+
+type GraphChange_Service struct {
+	Service *Service `protobuf:"bytes,6,opt,name=service,proto3,oneof"`
+}
+
+type GraphChange_Relation struct {
+	Relation *Relation `protobuf:"bytes,7,opt,name=relation,proto3,oneof"`
+}
+
+func (*GraphChange_Graph) isGraphChange_Entity() {}
+
+func (*GraphChange_Service) isGraphChange_Entity() {}
+
+func (*GraphChange_Relation) isGraphChange_Entity() {}
+
+var File_core_v1_core_proto protoreflect.FileDescriptor
+
+const file_core_v1_core_proto_rawDesc = "" +
+	"\n" +
+	"\x12core/v1/core.proto\x12\acore.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"-\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"J\n" +
+	"\x05Graph\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x03R\tprojectId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"n\n" +
+	"\fServiceLinks\x12$\n" +
+	"\rdocumentation\x18\x01 \x01(\tR\rdocumentation\x12\x18\n" +
+	"\arunbook\x18\x02 \x01(\tR\arunbook\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x03 \x01(\tR\n" +
+	"repository\"\xdb\x03\n" +
+	"\aService\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bgraph_id\x18\x02 \x01(\x03R\agraphId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\f\n" +
+	"\x01x\x18\x05 \x01(\x02R\x01x\x12\f\n" +
+	"\x01y\x18\x06 \x01(\x02R\x01y\x12\x1e\n" +
+	"\bgroup_id\x18\a \x01(\x03H\x00R\agroupId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"catalog_id\x18\b \x01(\x03H\x01R\tcatalogId\x88\x01\x01\x12\x12\n" +
+	"\x04kind\x18\t \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"owner_team\x18\n" +
+	" \x01(\tR\townerTeam\x12\x12\n" +
+	"\x04tags\x18\v \x03(\tR\x04tags\x12@\n" +
+	"\n" +
+	"attributes\x18\f \x03(\v2 .core.v1.Service.AttributesEntryR\n" +
+	"attributes\x12+\n" +
+	"\x05links\x18\r \x01(\v2\x15.core.v1.ServiceLinksR\x05links\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
+	"\t_group_idB\r\n" +
+	"\v_catalog_id\"\xfa\x02\n" +
+	"\bRelation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bgraph_id\x18\x02 \x01(\x03R\agraphId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12!\n" +
+	"\ffrom_service\x18\x05 \x01(\x03R\vfromService\x12\x1d\n" +
+	"\n" +
+	"to_service\x18\x06 \x01(\x03R\ttoService\x12\x1a\n" +
+	"\bprotocol\x18\a \x01(\tR\bprotocol\x12\x14\n" +
+	"\x05async\x18\b \x01(\bR\x05async\x12$\n" +
+	"\rbidirectional\x18\t \x01(\bR\rbidirectional\x12 \n" +
+	"\vcriticality\x18\n" +
+	" \x01(\tR\vcriticality\x12!\n" +
+	"\fexpected_rps\x18\v \x01(\x01R\vexpectedRps\x12.\n" +
+	"\x13expected_latency_ms\x18\f \x01(\x01R\x11expectedLatencyMs\"\x15\n" +
+	"\x13ListProjectsRequest\"D\n" +
+	"\x14ListProjectsResponse\x12,\n" +
+	"\bprojects\x18\x01 \x03(\v2\x10.core.v1.ProjectR\bprojects\"B\n" +
+	"\x14CreateProjectRequest\x12*\n" +
+	"\aproject\x18\x01 \x01(\v2\x10.core.v1.ProjectR\aproject\"R\n" +
+	"\x14UpdateProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12*\n" +
+	"\aproject\x18\x02 \x01(\v2\x10.core.v1.ProjectR\aproject\"&\n" +
+	"\x14DeleteProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"9\n" +
+	"\x18ListProjectGraphsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\"C\n" +
+	"\x19ListProjectGraphsResponse\x12&\n" +
+	"\x06graphs\x18\x01 \x03(\v2\x0e.core.v1.GraphR\x06graphs\":\n" +
+	"\x12CreateGraphRequest\x12$\n" +
+	"\x05graph\x18\x01 \x01(\v2\x0e.core.v1.GraphR\x05graph\"J\n" +
+	"\x12UpdateGraphRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12$\n" +
+	"\x05graph\x18\x02 \x01(\v2\x0e.core.v1.GraphR\x05graph\"$\n" +
+	"\x12DeleteGraphRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"#\n" +
+	"\x11GetServiceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xdd\x01\n" +
+	"\rServiceFilter\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"owner_team\x18\x02 \x01(\tR\townerTeam\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12F\n" +
+	"\n" +
+	"attributes\x18\x04 \x03(\v2&.core.v1.ServiceFilter.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"e\n" +
+	"\x18ListGraphServicesRequest\x12\x19\n" +
+	"\bgraph_id\x18\x01 \x01(\x03R\agraphId\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.core.v1.ServiceFilterR\x06filter\"I\n" +
+	"\x19ListGraphServicesResponse\x12,\n" +
+	"\bservices\x18\x01 \x03(\v2\x10.core.v1.ServiceR\bservices\"B\n" +
+	"\x14CreateServiceRequest\x12*\n" +
+	"\aservice\x18\x01 \x01(\v2\x10.core.v1.ServiceR\aservice\"`\n" +
+	"\x15CreateServicesRequest\x12\x19\n" +
+	"\bgraph_id\x18\x01 \x01(\x03R\agraphId\x12,\n" +
+	"\bservices\x18\x02 \x03(\v2\x10.core.v1.ServiceR\bservices\"*\n" +
+	"\x16CreateServicesResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"R\n" +
+	"\x14UpdateServiceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12*\n" +
+	"\aservice\x18\x02 \x01(\v2\x10.core.v1.ServiceR\aservice\"e\n" +
+	"\x1aUpdateGraphServicesRequest\x12\x19\n" +
+	"\bgraph_id\x18\x01 \x01(\x03R\agraphId\x12,\n" +
+	"\bservices\x18\x02 \x03(\v2\x10.core.v1.ServiceR\bservices\"&\n" +
+	"\x14DeleteServiceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"$\n" +
+	"\x12GetRelationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"6\n" +
+	"\x19ListGraphRelationsRequest\x12\x19\n" +
+	"\bgraph_id\x18\x01 \x01(\x03R\agraphId\"M\n" +
+	"\x1aListGraphRelationsResponse\x12/\n" +
+	"\trelations\x18\x01 \x03(\v2\x11.core.v1.RelationR\trelations\"F\n" +
+	"\x15CreateRelationRequest\x12-\n" +
+	"\brelation\x18\x01 \x01(\v2\x11.core.v1.RelationR\brelation\"d\n" +
+	"\x16CreateRelationsRequest\x12\x19\n" +
+	"\bgraph_id\x18\x01 \x01(\x03R\agraphId\x12/\n" +
+	"\trelations\x18\x02 \x03(\v2\x11.core.v1.RelationR\trelations\"V\n" +
+	"\x15UpdateRelationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12-\n" +
+	"\brelation\x18\x02 \x01(\v2\x11.core.v1.RelationR\brelation\"i\n" +
+	"\x1bUpdateGraphRelationsRequest\x12\x19\n" +
+	"\bgraph_id\x18\x01 \x01(\x03R\agraphId\x12/\n" +
+	"\trelations\x18\x02 \x03(\v2\x11.core.v1.RelationR\trelations\"'\n" +
+	"\x15DeleteRelationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"9\n" +
+	"\x1cSubscribeGraphChangesRequest\x12\x19\n" +
+	"\bgraph_id\x18\x01 \x01(\x03R\agraphId\"\xab\x02\n" +
+	"\vGraphChange\x12\x14\n" +
+	"\x05event\x18\x01 \x01(\tR\x05event\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x03R\tprojectId\x12\x19\n" +
+	"\bgraph_id\x18\x03 \x01(\x03R\agraphId\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12&\n" +
+	"\x05graph\x18\x05 \x01(\v2\x0e.core.v1.GraphH\x00R\x05graph\x12,\n" +
+	"\aservice\x18\x06 \x01(\v2\x10.core.v1.ServiceH\x00R\aservice\x12/\n" +
+	"\brelation\x18\a \x01(\v2\x11.core.v1.RelationH\x00R\brelationB\b\n" +
+	"\x06entity2\xce\r\n" +
+	"\vCoreService\x12K\n" +
+	"\fListProjects\x12\x1c.core.v1.ListProjectsRequest\x1a\x1d.core.v1.ListProjectsResponse\x12@\n" +
+	"\rCreateProject\x12\x1d.core.v1.CreateProjectRequest\x1a\x10.core.v1.Project\x12F\n" +
+	"\rUpdateProject\x12\x1d.core.v1.UpdateProjectRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\rDeleteProject\x12\x1d.core.v1.DeleteProjectRequest\x1a\x16.google.protobuf.Empty\x12Z\n" +
+	"\x11ListProjectGraphs\x12!.core.v1.ListProjectGraphsRequest\x1a\".core.v1.ListProjectGraphsResponse\x12:\n" +
+	"\vCreateGraph\x12\x1b.core.v1.CreateGraphRequest\x1a\x0e.core.v1.Graph\x12B\n" +
+	"\vUpdateGraph\x12\x1b.core.v1.UpdateGraphRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\vDeleteGraph\x12\x1b.core.v1.DeleteGraphRequest\x1a\x16.google.protobuf.Empty\x12:\n" +
+	"\n" +
+	"GetService\x12\x1a.core.v1.GetServiceRequest\x1a\x10.core.v1.Service\x12Z\n" +
+	"\x11ListGraphServices\x12!.core.v1.ListGraphServicesRequest\x1a\".core.v1.ListGraphServicesResponse\x12@\n" +
+	"\rCreateService\x12\x1d.core.v1.CreateServiceRequest\x1a\x10.core.v1.Service\x12Q\n" +
+	"\x0eCreateServices\x12\x1e.core.v1.CreateServicesRequest\x1a\x1f.core.v1.CreateServicesResponse\x12F\n" +
+	"\rUpdateService\x12\x1d.core.v1.UpdateServiceRequest\x1a\x16.google.protobuf.Empty\x12R\n" +
+	"\x13UpdateGraphServices\x12#.core.v1.UpdateGraphServicesRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\rDeleteService\x12\x1d.core.v1.DeleteServiceRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\vGetRelation\x12\x1b.core.v1.GetRelationRequest\x1a\x11.core.v1.Relation\x12]\n" +
+	"\x12ListGraphRelations\x12\".core.v1.ListGraphRelationsRequest\x1a#.core.v1.ListGraphRelationsResponse\x12C\n" +
+	"\x0eCreateRelation\x12\x1e.core.v1.CreateRelationRequest\x1a\x11.core.v1.Relation\x12J\n" +
+	"\x0fCreateRelations\x12\x1f.core.v1.CreateRelationsRequest\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\x0eUpdateRelation\x12\x1e.core.v1.UpdateRelationRequest\x1a\x16.google.protobuf.Empty\x12T\n" +
+	"\x14UpdateGraphRelations\x12$.core.v1.UpdateGraphRelationsRequest\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\x0eDeleteRelation\x12\x1e.core.v1.DeleteRelationRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\x15SubscribeGraphChanges\x12%.core.v1.SubscribeGraphChangesRequest\x1a\x14.core.v1.GraphChange0\x01B6Z4github.com/hse-telescope/core/pkg/api/core/v1;corev1b\x06proto3"
+
+var (
+	file_core_v1_core_proto_rawDescOnce sync.Once
+	file_core_v1_core_proto_rawDescData []byte
+)
+
+func file_core_v1_core_proto_rawDescGZIP() []byte {
+	file_core_v1_core_proto_rawDescOnce.Do(func() {
+		file_core_v1_core_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_v1_core_proto_rawDesc), len(file_core_v1_core_proto_rawDesc)))
+	})
+	return file_core_v1_core_proto_rawDescData
+}
+
+var file_core_v1_core_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_core_v1_core_proto_goTypes = []any{
+	(*Project)(nil),                      // 0: core.v1.Project
+	(*Graph)(nil),                        // 1: core.v1.Graph
+	(*ServiceLinks)(nil),                 // 2: core.v1.ServiceLinks
+	(*Service)(nil),                      // 3: core.v1.Service
+	(*Relation)(nil),                     // 4: core.v1.Relation
+	(*ListProjectsRequest)(nil),          // 5: core.v1.ListProjectsRequest
+	(*ListProjectsResponse)(nil),         // 6: core.v1.ListProjectsResponse
+	(*CreateProjectRequest)(nil),         // 7: core.v1.CreateProjectRequest
+	(*UpdateProjectRequest)(nil),         // 8: core.v1.UpdateProjectRequest
+	(*DeleteProjectRequest)(nil),         // 9: core.v1.DeleteProjectRequest
+	(*ListProjectGraphsRequest)(nil),     // 10: core.v1.ListProjectGraphsRequest
+	(*ListProjectGraphsResponse)(nil),    // 11: core.v1.ListProjectGraphsResponse
+	(*CreateGraphRequest)(nil),           // 12: core.v1.CreateGraphRequest
+	(*UpdateGraphRequest)(nil),           // 13: core.v1.UpdateGraphRequest
+	(*DeleteGraphRequest)(nil),           // 14: core.v1.DeleteGraphRequest
+	(*GetServiceRequest)(nil),            // 15: core.v1.GetServiceRequest
+	(*ServiceFilter)(nil),                // 16: core.v1.ServiceFilter
+	(*ListGraphServicesRequest)(nil),     // 17: core.v1.ListGraphServicesRequest
+	(*ListGraphServicesResponse)(nil),    // 18: core.v1.ListGraphServicesResponse
+	(*CreateServiceRequest)(nil),         // 19: core.v1.CreateServiceRequest
+	(*CreateServicesRequest)(nil),        // 20: core.v1.CreateServicesRequest
+	(*CreateServicesResponse)(nil),       // 21: core.v1.CreateServicesResponse
+	(*UpdateServiceRequest)(nil),         // 22: core.v1.UpdateServiceRequest
+	(*UpdateGraphServicesRequest)(nil),   // 23: core.v1.UpdateGraphServicesRequest
+	(*DeleteServiceRequest)(nil),         // 24: core.v1.DeleteServiceRequest
+	(*GetRelationRequest)(nil),           // 25: core.v1.GetRelationRequest
+	(*ListGraphRelationsRequest)(nil),    // 26: core.v1.ListGraphRelationsRequest
+	(*ListGraphRelationsResponse)(nil),   // 27: core.v1.ListGraphRelationsResponse
+	(*CreateRelationRequest)(nil),        // 28: core.v1.CreateRelationRequest
+	(*CreateRelationsRequest)(nil),       // 29: core.v1.CreateRelationsRequest
+	(*UpdateRelationRequest)(nil),        // 30: core.v1.UpdateRelationRequest
+	(*UpdateGraphRelationsRequest)(nil),  // 31: core.v1.UpdateGraphRelationsRequest
+	(*DeleteRelationRequest)(nil),        // 32: core.v1.DeleteRelationRequest
+	(*SubscribeGraphChangesRequest)(nil), // 33: core.v1.SubscribeGraphChangesRequest
+	(*GraphChange)(nil),                  // 34: core.v1.GraphChange
+	nil,                                  // 35: core.v1.Service.AttributesEntry
+	nil,                                  // 36: core.v1.ServiceFilter.AttributesEntry
+	(*timestamppb.Timestamp)(nil),        // 37: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                // 38: google.protobuf.Empty
+}
+var file_core_v1_core_proto_depIdxs = []int32{
+	35, // 0: core.v1.Service.attributes:type_name -> core.v1.Service.AttributesEntry
+	2,  // 1: core.v1.Service.links:type_name -> core.v1.ServiceLinks
+	0,  // 2: core.v1.ListProjectsResponse.projects:type_name -> core.v1.Project
+	0,  // 3: core.v1.CreateProjectRequest.project:type_name -> core.v1.Project
+	0,  // 4: core.v1.UpdateProjectRequest.project:type_name -> core.v1.Project
+	1,  // 5: core.v1.ListProjectGraphsResponse.graphs:type_name -> core.v1.Graph
+	1,  // 6: core.v1.CreateGraphRequest.graph:type_name -> core.v1.Graph
+	1,  // 7: core.v1.UpdateGraphRequest.graph:type_name -> core.v1.Graph
+	36, // 8: core.v1.ServiceFilter.attributes:type_name -> core.v1.ServiceFilter.AttributesEntry
+	16, // 9: core.v1.ListGraphServicesRequest.filter:type_name -> core.v1.ServiceFilter
+	3,  // 10: core.v1.ListGraphServicesResponse.services:type_name -> core.v1.Service
+	3,  // 11: core.v1.CreateServiceRequest.service:type_name -> core.v1.Service
+	3,  // 12: core.v1.CreateServicesRequest.services:type_name -> core.v1.Service
+	3,  // 13: core.v1.UpdateServiceRequest.service:type_name -> core.v1.Service
+	3,  // 14: core.v1.UpdateGraphServicesRequest.services:type_name -> core.v1.Service
+	4,  // 15: core.v1.ListGraphRelationsResponse.relations:type_name -> core.v1.Relation
+	4,  // 16: core.v1.CreateRelationRequest.relation:type_name -> core.v1.Relation
+	4,  // 17: core.v1.CreateRelationsRequest.relations:type_name -> core.v1.Relation
+	4,  // 18: core.v1.UpdateRelationRequest.relation:type_name -> core.v1.Relation
+	4,  // 19: core.v1.UpdateGraphRelationsRequest.relations:type_name -> core.v1.Relation
+	37, // 20: core.v1.GraphChange.occurred_at:type_name -> google.protobuf.Timestamp
+	1,  // 21: core.v1.GraphChange.graph:type_name -> core.v1.Graph
+	3,  // 22: core.v1.GraphChange.service:type_name -> core.v1.Service
+	4,  // 23: core.v1.GraphChange.relation:type_name -> core.v1.Relation
+	5,  // 24: core.v1.CoreService.ListProjects:input_type -> core.v1.ListProjectsRequest
+	7,  // 25: core.v1.CoreService.CreateProject:input_type -> core.v1.CreateProjectRequest
+	8,  // 26: core.v1.CoreService.UpdateProject:input_type -> core.v1.UpdateProjectRequest
+	9,  // 27: core.v1.CoreService.DeleteProject:input_type -> core.v1.DeleteProjectRequest
+	10, // 28: core.v1.CoreService.ListProjectGraphs:input_type -> core.v1.ListProjectGraphsRequest
+	12, // 29: core.v1.CoreService.CreateGraph:input_type -> core.v1.CreateGraphRequest
+	13, // 30: core.v1.CoreService.UpdateGraph:input_type -> core.v1.UpdateGraphRequest
+	14, // 31: core.v1.CoreService.DeleteGraph:input_type -> core.v1.DeleteGraphRequest
+	15, // 32: core.v1.CoreService.GetService:input_type -> core.v1.GetServiceRequest
+	17, // 33: core.v1.CoreService.ListGraphServices:input_type -> core.v1.ListGraphServicesRequest
+	19, // 34: core.v1.CoreService.CreateService:input_type -> core.v1.CreateServiceRequest
+	20, // 35: core.v1.CoreService.CreateServices:input_type -> core.v1.CreateServicesRequest
+	22, // 36: core.v1.CoreService.UpdateService:input_type -> core.v1.UpdateServiceRequest
+	23, // 37: core.v1.CoreService.UpdateGraphServices:input_type -> core.v1.UpdateGraphServicesRequest
+	24, // 38: core.v1.CoreService.DeleteService:input_type -> core.v1.DeleteServiceRequest
+	25, // 39: core.v1.CoreService.GetRelation:input_type -> core.v1.GetRelationRequest
+	26, // 40: core.v1.CoreService.ListGraphRelations:input_type -> core.v1.ListGraphRelationsRequest
+	28, // 41: core.v1.CoreService.CreateRelation:input_type -> core.v1.CreateRelationRequest
+	29, // 42: core.v1.CoreService.CreateRelations:input_type -> core.v1.CreateRelationsRequest
+	30, // 43: core.v1.CoreService.UpdateRelation:input_type -> core.v1.UpdateRelationRequest
+	31, // 44: core.v1.CoreService.UpdateGraphRelations:input_type -> core.v1.UpdateGraphRelationsRequest
+	32, // 45: core.v1.CoreService.DeleteRelation:input_type -> core.v1.DeleteRelationRequest
+	33, // 46: core.v1.CoreService.SubscribeGraphChanges:input_type -> core.v1.SubscribeGraphChangesRequest
+	6,  // 47: core.v1.CoreService.ListProjects:output_type -> core.v1.ListProjectsResponse
+	0,  // 48: core.v1.CoreService.CreateProject:output_type -> core.v1.Project
+	38, // 49: core.v1.CoreService.UpdateProject:output_type -> google.protobuf.Empty
+	38, // 50: core.v1.CoreService.DeleteProject:output_type -> google.protobuf.Empty
+	11, // 51: core.v1.CoreService.ListProjectGraphs:output_type -> core.v1.ListProjectGraphsResponse
+	1,  // 52: core.v1.CoreService.CreateGraph:output_type -> core.v1.Graph
+	38, // 53: core.v1.CoreService.UpdateGraph:output_type -> google.protobuf.Empty
+	38, // 54: core.v1.CoreService.DeleteGraph:output_type -> google.protobuf.Empty
+	3,  // 55: core.v1.CoreService.GetService:output_type -> core.v1.Service
+	18, // 56: core.v1.CoreService.ListGraphServices:output_type -> core.v1.ListGraphServicesResponse
+	3,  // 57: core.v1.CoreService.CreateService:output_type -> core.v1.Service
+	21, // 58: core.v1.CoreService.CreateServices:output_type -> core.v1.CreateServicesResponse
+	38, // 59: core.v1.CoreService.UpdateService:output_type -> google.protobuf.Empty
+	38, // 60: core.v1.CoreService.UpdateGraphServices:output_type -> google.protobuf.Empty
+	38, // 61: core.v1.CoreService.DeleteService:output_type -> google.protobuf.Empty
+	4,  // 62: core.v1.CoreService.GetRelation:output_type -> core.v1.Relation
+	27, // 63: core.v1.CoreService.ListGraphRelations:output_type -> core.v1.ListGraphRelationsResponse
+	4,  // 64: core.v1.CoreService.CreateRelation:output_type -> core.v1.Relation
+	38, // 65: core.v1.CoreService.CreateRelations:output_type -> google.protobuf.Empty
+	38, // 66: core.v1.CoreService.UpdateRelation:output_type -> google.protobuf.Empty
+	38, // 67: core.v1.CoreService.UpdateGraphRelations:output_type -> google.protobuf.Empty
+	38, // 68: core.v1.CoreService.DeleteRelation:output_type -> google.protobuf.Empty
+	34, // 69: core.v1.CoreService.SubscribeGraphChanges:output_type -> core.v1.GraphChange
+	47, // [47:70] is the sub-list for method output_type
+	24, // [24:47] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_core_v1_core_proto_init() }
+func file_core_v1_core_proto_init() {
+	if File_core_v1_core_proto != nil {
+		return
+	}
+	file_core_v1_core_proto_msgTypes[3].OneofWrappers = []any{}
+	file_core_v1_core_proto_msgTypes[34].OneofWrappers = []any{
+		(*GraphChange_Graph)(nil),
+		(*GraphChange_Service)(nil),
+		(*GraphChange_Relation)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_core_proto_rawDesc), len(file_core_v1_core_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   37,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_core_v1_core_proto_goTypes,
+		DependencyIndexes: file_core_v1_core_proto_depIdxs,
+		MessageInfos:      file_core_v1_core_proto_msgTypes,
+	}.Build()
+	File_core_v1_core_proto = out.File
+	file_core_v1_core_proto_goTypes = nil
+	file_core_v1_core_proto_depIdxs = nil
+}
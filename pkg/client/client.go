@@ -0,0 +1,172 @@
+// Package client is a Go client of the core REST API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	// Timeout of a single attempt, 10s by default
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxRetries is the number of extra attempts of idempotent calls
+	// (GET, PUT and DELETE) failed with a network error or a 5xx status.
+	// 3 by default, negative disables retries.
+	MaxRetries int `yaml:"max_retries"`
+	// MinBackoff is the pause before the first retry, it doubles with every
+	// next one up to MaxBackoff. 100ms and 2s by default.
+	MinBackoff time.Duration `yaml:"min_backoff"`
+	MaxBackoff time.Duration `yaml:"max_backoff"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = 3
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	}
+	if c.MinBackoff == 0 {
+		c.MinBackoff = 100 * time.Millisecond
+	}
+	if c.MaxBackoff == 0 {
+		c.MaxBackoff = 2 * time.Second
+	}
+	return c
+}
+
+type Client struct {
+	baseURL string
+	conf    Config
+	client  *http.Client
+}
+
+// New returns a client of the core instance listening at baseURL, e.g.
+// "http://core:8080".
+func New(baseURL string, conf Config) *Client {
+	conf = conf.withDefaults()
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		conf:    conf,
+		client:  &http.Client{Timeout: conf.Timeout},
+	}
+}
+
+// NewWithHTTPClient is New with a custom transport, the timeout of conf is
+// ignored in favour of the one of httpClient.
+func NewWithHTTPClient(baseURL string, conf Config, httpClient *http.Client) *Client {
+	c := New(baseURL, conf)
+	c.client = httpClient
+	return c
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	backoff := c.conf.MinBackoff
+	for i := 1; i < attempt && backoff < c.conf.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, c.conf.MaxBackoff)
+}
+
+// do sends the request and decodes the response body into out unless it is
+// nil. Idempotent requests are retried on network errors and 5xx statuses.
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, in any, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	retries := 0
+	if idempotent(method) {
+		retries = c.conf.MaxRetries
+	}
+	for attempt := 0; ; attempt++ {
+		err := c.attempt(ctx, method, target, body, out)
+		if err == nil || attempt >= retries || !retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(c.backoff(attempt + 1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, method string, target string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return newError(method, target, resp, strings.TrimSpace(string(message)))
+	}
+	if out == nil {
+		return nil
+	}
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return fmt.Errorf("decode %s %s response: %w", method, target, err)
+	}
+	return nil
+}
+
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	// Network errors
+	return true
+}
+
+func idPath(prefix string, id int, suffix ...string) string {
+	return prefix + "/" + strconv.Itoa(id) + strings.Join(suffix, "")
+}
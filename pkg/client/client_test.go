@@ -0,0 +1,170 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hse-telescope/core/internal/config"
+	"github.com/hse-telescope/core/internal/events"
+	"github.com/hse-telescope/core/internal/providers/catalog"
+	"github.com/hse-telescope/core/internal/providers/graph"
+	"github.com/hse-telescope/core/internal/providers/group"
+	"github.com/hse-telescope/core/internal/providers/idempotency"
+	"github.com/hse-telescope/core/internal/providers/manifest"
+	"github.com/hse-telescope/core/internal/providers/project"
+	"github.com/hse-telescope/core/internal/providers/relation"
+	"github.com/hse-telescope/core/internal/providers/service"
+	"github.com/hse-telescope/core/internal/providers/webhook"
+	"github.com/hse-telescope/core/internal/repository/facade"
+	"github.com/hse-telescope/core/internal/repository/memory"
+	"github.com/hse-telescope/core/internal/server"
+	"github.com/hse-telescope/core/pkg/client"
+)
+
+// newServer serves the REST API over the in-memory storage
+func newServer() http.Handler {
+	f := facade.New(memory.New(), events.NewBroker(), nil)
+	return server.New(config.Config{},
+		project.New(f), graph.New(f), service.New(f), relation.New(f), catalog.New(f),
+		group.New(f), webhook.New(f), manifest.New(f), idempotency.New(f, idempotency.Config{}),
+	)
+}
+
+// flaky answers the first failures requests of method with 503 and hands
+// the rest to next
+type flaky struct {
+	next     http.Handler
+	method   string
+	failures int32
+	requests atomic.Int32
+}
+
+func (h *flaky) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == h.method && h.requests.Add(1) <= h.failures {
+		http.Error(w, "try later", http.StatusServiceUnavailable)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+func newClient(t *testing.T, handler http.Handler) *client.Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return client.New(srv.URL, client.Config{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+}
+
+func newGraph(t *testing.T, c *client.Client) client.Graph {
+	ctx := context.Background()
+	project, err := c.CreateProject(ctx, client.Project{Name: "client"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph, err := c.CreateGraph(ctx, client.Graph{ProjectID: project.ID, Name: "client"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return graph
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newServer())
+	graph := newGraph(t, c)
+
+	created, err := c.CreateService(ctx, client.Service{GraphID: graph.ID, Name: "api", Kind: client.KindAPI})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.GetService(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "api" || got.Kind != client.KindAPI || got.GraphID != graph.ID {
+		t.Fatalf("unexpected service: %+v", got)
+	}
+	services, err := c.GetGraphServices(ctx, graph.ID, client.ServiceFilter{Kind: client.KindAPI})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 1 || services[0].ID != created.ID {
+		t.Fatalf("unexpected services: %+v", services)
+	}
+}
+
+func TestClientMapsErrors(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newServer())
+	graph := newGraph(t, c)
+
+	_, err := c.GetService(ctx, 404)
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("got error %v for a missing service, want %v", err, client.ErrNotFound)
+	}
+	_, err = c.GetGraph(ctx, 404)
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("got error %v for a missing graph, want %v", err, client.ErrNotFound)
+	}
+
+	_, err = c.CreateService(ctx, client.Service{GraphID: graph.ID, Name: "api", Kind: "mainframe"})
+	if !errors.Is(err, client.ErrBadRequest) {
+		t.Fatalf("got error %v for an unknown kind, want %v", err, client.ErrBadRequest)
+	}
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message == "" {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+}
+
+func TestClientRetriesIdempotentCalls(t *testing.T) {
+	ctx := context.Background()
+	handler := &flaky{next: newServer(), method: http.MethodGet, failures: 2}
+	c := newClient(t, handler)
+	graph := newGraph(t, c)
+
+	got, err := c.GetGraph(ctx, graph.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != graph {
+		t.Fatalf("got graph %+v, want %+v", got, graph)
+	}
+	if n := handler.requests.Load(); n != 3 {
+		t.Fatalf("%d requests are sent, want 3", n)
+	}
+
+	handler.requests.Store(0)
+	handler.failures = 10
+	_, err = c.GetGraph(ctx, graph.ID)
+	if !errors.Is(err, client.ErrServer) {
+		t.Fatalf("got error %v, want %v", err, client.ErrServer)
+	}
+	if n := handler.requests.Load(); n != 4 {
+		t.Fatalf("%d requests are sent, want the first one and 3 retries", n)
+	}
+}
+
+func TestClientDoesNotRetryCreates(t *testing.T) {
+	ctx := context.Background()
+	handler := &flaky{next: newServer(), method: http.MethodPost, failures: 1}
+	c := newClient(t, handler)
+
+	_, err := c.CreateProject(ctx, client.Project{Name: "client"})
+	if !errors.Is(err, client.ErrServer) {
+		t.Fatalf("got error %v, want %v", err, client.ErrServer)
+	}
+	if n := handler.requests.Load(); n != 1 {
+		t.Fatalf("%d requests are sent, want 1", n)
+	}
+	projects, err := c.GetProjects(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 0 {
+		t.Fatalf("failed create is stored: %+v", projects)
+	}
+}
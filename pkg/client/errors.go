@@ -0,0 +1,58 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Errors matching the status classes of the server. An *Error returned by
+// the client satisfies errors.Is with one of them:
+//
+//	if errors.Is(err, client.ErrBadRequest) { ... }
+var (
+	ErrBadRequest = errors.New("bad request")
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrServer     = errors.New("server error")
+)
+
+// Error is returned for every non 2xx response. The server reports errors as
+// a plain text message, it is kept in Message.
+type Error struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Message    string
+}
+
+func newError(method string, url string, resp *http.Response, message string) *Error {
+	return &Error{
+		Method:     method,
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		Message:    message,
+	}
+}
+
+func (e *Error) Error() string {
+	status := fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message == "" {
+		return status
+	}
+	return status + ": " + e.Message
+}
+
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
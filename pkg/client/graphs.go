@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+func (c *Client) GetGraph(ctx context.Context, graph_id int) (Graph, error) {
+	var graph Graph
+	err := c.do(ctx, http.MethodGet, idPath("/graphs", graph_id), nil, nil, &graph)
+	return graph, err
+}
+
+func (c *Client) GetProjectGraphs(ctx context.Context, project_id int) ([]Graph, error) {
+	var graphs []Graph
+	err := c.do(ctx, http.MethodGet, idPath("/projects", project_id, "/graphs"), nil, nil, &graphs)
+	return graphs, err
+}
+
+func (c *Client) CreateGraph(ctx context.Context, graph Graph) (Graph, error) {
+	var created Graph
+	err := c.do(ctx, http.MethodPost, "/graphs", nil, graph, &created)
+	return created, err
+}
+
+func (c *Client) UpdateGraph(ctx context.Context, graph_id int, graph Graph) error {
+	return c.do(ctx, http.MethodPut, idPath("/graphs", graph_id), nil, graph, nil)
+}
+
+func (c *Client) DeleteGraph(ctx context.Context, graph_id int) error {
+	return c.do(ctx, http.MethodDelete, idPath("/graphs", graph_id), nil, nil, nil)
+}
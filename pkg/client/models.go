@@ -0,0 +1,140 @@
+package client
+
+type Project struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Graph struct {
+	ID        int    `json:"id"`
+	ProjectID int    `json:"project_id"`
+	Name      string `json:"name"`
+}
+
+type ServiceLinks struct {
+	Documentation string `json:"documentation,omitempty"`
+	Runbook       string `json:"runbook,omitempty"`
+	Repository    string `json:"repository,omitempty"`
+}
+
+// Service kinds known to the server, an empty kind means it is not specified
+const (
+	KindAPI      = "api"
+	KindDatabase = "database"
+	KindQueue    = "queue"
+	KindCache    = "cache"
+	KindExternal = "external"
+	KindFrontend = "frontend"
+)
+
+type Service struct {
+	ID          int               `json:"id"`
+	GraphID     int               `json:"graph_id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	X           float32           `json:"x"`
+	Y           float32           `json:"y"`
+	GroupID     *int              `json:"group_id"`
+	CatalogID   *int              `json:"catalog_id"`
+	Kind        string            `json:"kind"`
+	OwnerTeam   string            `json:"owner_team"`
+	Tags        []string          `json:"tags"`
+	Attributes  map[string]string `json:"attributes"`
+	Links       ServiceLinks      `json:"links"`
+}
+
+// ServiceFilter narrows down graph services, zero fields match everything
+type ServiceFilter struct {
+	Kind       string
+	OwnerTeam  string
+	Tags       []string
+	Attributes map[string]string
+}
+
+// Relation protocols and criticalities known to the server
+const (
+	ProtocolHTTP  = "http"
+	ProtocolGRPC  = "grpc"
+	ProtocolKafka = "kafka"
+	ProtocolSQL   = "sql"
+
+	CriticalityLow      = "low"
+	CriticalityMedium   = "medium"
+	CriticalityHigh     = "high"
+	CriticalityCritical = "critical"
+)
+
+type Relation struct {
+	ID                int     `json:"id"`
+	GraphID           int     `json:"graph_id"`
+	Name              string  `json:"name"`
+	Description       string  `json:"description"`
+	FromService       int     `json:"from_service"`
+	ToService         int     `json:"to_service"`
+	Protocol          string  `json:"protocol"`
+	Async             bool    `json:"async"`
+	Bidirectional     bool    `json:"bidirectional"`
+	Criticality       string  `json:"criticality"`
+	ExpectedRPS       float64 `json:"expected_rps"`
+	ExpectedLatencyMS float64 `json:"expected_latency_ms"`
+}
+
+// Manifest is the declared state of a project, see Client.ApplyProject
+type Manifest struct {
+	Graphs []ManifestGraph `json:"graphs"`
+}
+
+type ManifestGraph struct {
+	Name      string             `json:"name"`
+	Services  []ManifestService  `json:"services"`
+	Relations []ManifestRelation `json:"relations"`
+}
+
+type ManifestService struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	X           float32           `json:"x"`
+	Y           float32           `json:"y"`
+	Kind        string            `json:"kind"`
+	OwnerTeam   string            `json:"owner_team"`
+	Tags        []string          `json:"tags"`
+	Attributes  map[string]string `json:"attributes"`
+	Links       ServiceLinks      `json:"links"`
+}
+
+// ManifestRelation references its services by name
+type ManifestRelation struct {
+	Name              string  `json:"name"`
+	Description       string  `json:"description"`
+	From              string  `json:"from"`
+	To                string  `json:"to"`
+	Protocol          string  `json:"protocol"`
+	Async             bool    `json:"async"`
+	Bidirectional     bool    `json:"bidirectional"`
+	Criticality       string  `json:"criticality"`
+	ExpectedRPS       float64 `json:"expected_rps"`
+	ExpectedLatencyMS float64 `json:"expected_latency_ms"`
+}
+
+// Plan change actions and entity types
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+
+	TypeGraph    = "graph"
+	TypeService  = "service"
+	TypeRelation = "relation"
+)
+
+type PlanChange struct {
+	Action string   `json:"action"`
+	Type   string   `json:"type"`
+	Graph  string   `json:"graph"`
+	Key    string   `json:"key"`
+	Fields []string `json:"fields,omitempty"`
+}
+
+type Plan struct {
+	Changes []PlanChange `json:"changes"`
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+func (c *Client) GetProjects(ctx context.Context) ([]Project, error) {
+	var projects []Project
+	err := c.do(ctx, http.MethodGet, "/projects", nil, nil, &projects)
+	return projects, err
+}
+
+func (c *Client) CreateProject(ctx context.Context, project Project) (Project, error) {
+	var created Project
+	err := c.do(ctx, http.MethodPost, "/projects", nil, project, &created)
+	return created, err
+}
+
+func (c *Client) UpdateProject(ctx context.Context, project_id int, project Project) error {
+	return c.do(ctx, http.MethodPut, idPath("/projects", project_id), nil, project, nil)
+}
+
+func (c *Client) DeleteProject(ctx context.Context, project_id int) error {
+	return c.do(ctx, http.MethodDelete, idPath("/projects", project_id), nil, nil, nil)
+}
+
+// PlanProject lists the changes ApplyProject would make
+func (c *Client) PlanProject(ctx context.Context, project_id int, manifest Manifest) (Plan, error) {
+	var plan Plan
+	err := c.do(ctx, http.MethodPost, idPath("/projects", project_id, "/plan"), nil, manifest, &plan)
+	return plan, err
+}
+
+// ApplyProject brings the project to the state of the manifest in one
+// transaction, graphs, services and relations missing from it are deleted.
+// Existing entities sharing a name fail it with ErrConflict.
+func (c *Client) ApplyProject(ctx context.Context, project_id int, manifest Manifest) (Plan, error) {
+	var plan Plan
+	err := c.do(ctx, http.MethodPost, idPath("/projects", project_id, "/apply"), nil, manifest, &plan)
+	return plan, err
+}
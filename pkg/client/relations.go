@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+func (c *Client) GetRelation(ctx context.Context, relation_id int) (Relation, error) {
+	var relation Relation
+	err := c.do(ctx, http.MethodGet, idPath("/relations", relation_id), nil, nil, &relation)
+	return relation, err
+}
+
+func (c *Client) GetGraphRelations(ctx context.Context, graph_id int) ([]Relation, error) {
+	var relations []Relation
+	err := c.do(ctx, http.MethodGet, idPath("/graphs", graph_id, "/relations"), nil, nil, &relations)
+	return relations, err
+}
+
+func (c *Client) CreateRelation(ctx context.Context, relation Relation) (Relation, error) {
+	var created Relation
+	err := c.do(ctx, http.MethodPost, "/relations", nil, relation, &created)
+	return created, err
+}
+
+func (c *Client) CreateRelations(ctx context.Context, graph_id int, relations []Relation) error {
+	return c.do(ctx, http.MethodPost, idPath("/graphs", graph_id, "/relations"), nil, relations, nil)
+}
+
+func (c *Client) UpdateRelation(ctx context.Context, relation_id int, relation Relation) error {
+	return c.do(ctx, http.MethodPut, idPath("/relations", relation_id), nil, relation, nil)
+}
+
+func (c *Client) UpdateGraphRelations(ctx context.Context, graph_id int, relations []Relation) error {
+	return c.do(ctx, http.MethodPut, idPath("/graphs", graph_id, "/relations"), nil, relations, nil)
+}
+
+func (c *Client) DeleteRelation(ctx context.Context, relation_id int) error {
+	return c.do(ctx, http.MethodDelete, idPath("/relations", relation_id), nil, nil, nil)
+}
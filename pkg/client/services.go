@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+func (c *Client) GetService(ctx context.Context, service_id int) (Service, error) {
+	var service Service
+	err := c.do(ctx, http.MethodGet, idPath("/services", service_id), nil, nil, &service)
+	return service, err
+}
+
+func (c *Client) GetGraphServices(ctx context.Context, graph_id int, filter ServiceFilter) ([]Service, error) {
+	query := url.Values{}
+	if filter.Kind != "" {
+		query.Set("kind", filter.Kind)
+	}
+	if filter.OwnerTeam != "" {
+		query.Set("owner_team", filter.OwnerTeam)
+	}
+	for _, tag := range filter.Tags {
+		query.Add("tag", tag)
+	}
+	keys := make([]string, 0, len(filter.Attributes))
+	for key := range filter.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		query.Add("attr", key+":"+filter.Attributes[key])
+	}
+
+	var services []Service
+	err := c.do(ctx, http.MethodGet, idPath("/graphs", graph_id, "/services"), query, nil, &services)
+	return services, err
+}
+
+func (c *Client) CreateService(ctx context.Context, service Service) (Service, error) {
+	var created Service
+	err := c.do(ctx, http.MethodPost, "/services", nil, service, &created)
+	return created, err
+}
+
+// CreateServices creates services in the graph and returns their IDs in the
+// same order
+func (c *Client) CreateServices(ctx context.Context, graph_id int, services []Service) ([]int, error) {
+	var ids []int
+	err := c.do(ctx, http.MethodPost, idPath("/graphs", graph_id, "/services"), nil, services, &ids)
+	return ids, err
+}
+
+func (c *Client) UpdateService(ctx context.Context, service_id int, service Service) error {
+	return c.do(ctx, http.MethodPut, idPath("/services", service_id), nil, service, nil)
+}
+
+func (c *Client) UpdateGraphServices(ctx context.Context, graph_id int, services []Service) error {
+	return c.do(ctx, http.MethodPut, idPath("/graphs", graph_id, "/services"), nil, services, nil)
+}
+
+func (c *Client) DeleteService(ctx context.Context, service_id int) error {
+	return c.do(ctx, http.MethodDelete, idPath("/services", service_id), nil, nil, nil)
+}